@@ -11,6 +11,7 @@ import (
 	"github.com/joaorufino/gopher-game/pkg/abilities"
 	"github.com/joaorufino/gopher-game/pkg/actions"
 	"github.com/joaorufino/gopher-game/pkg/camera"
+	"github.com/joaorufino/gopher-game/pkg/game"
 	"github.com/joaorufino/gopher-game/pkg/gameAudio"
 	"github.com/joaorufino/gopher-game/pkg/gameMap"
 	"github.com/joaorufino/gopher-game/pkg/input"
@@ -40,8 +41,8 @@ func provideConfiguration() *player.Configuration {
 		ScreenWidth:  800,
 		ScreenHeight: 18000,
 		Gravity:      1000,
-		JumpVelocity: 100,
-		RunVelocity:  200,
+		JumpVelocity: physics.PlayerJumpVelocity,
+		RunVelocity:  physics.PlayerRunVelocity,
 		ImageScale:   0.2,
 	}
 }
@@ -58,7 +59,7 @@ func provideEventManager() interfaces.EventManager {
 
 // Provide the PhysicsEngine implementation
 func providePhysicsEngine(eventManager interfaces.EventManager) interfaces.PhysicsEngine {
-	return physics.NewPhysicsEngine(eventManager, interfaces.Vector2D{X: 0, Y: 9.8}, 3000)
+	return physics.NewPhysicsEngine(eventManager, interfaces.Vector2D{X: 0, Y: physics.EngineGravity}, 3000)
 }
 
 // Provide the GameMap implementation
@@ -99,11 +100,26 @@ func provideScreenDimensions(settings interfaces.Settings) (int, int) {
 
 // Provide the Player implementation
 func providePlayer(resourceManager interfaces.ResourceManager, inputHandler interfaces.InputHandler, config *player.Configuration, engine interfaces.PhysicsEngine, events interfaces.EventManager) interfaces.Player {
+	// The game moves the player to the start of the level once one is loaded
 	startX := 200.0
 	startY := 200.0
 	return player.NewPlayer(startX, startY, resourceManager, config, engine, events, 800)
 }
 
+// Provide the factory of the players sharing the screen with the first one;
+// the second player gets the arrows, right shift and the first gamepad
+func providePlayerFactory(resourceManager interfaces.ResourceManager, config *player.Configuration, engine interfaces.PhysicsEngine, events interfaces.EventManager) game.PlayerFactory {
+	return func(index int, position interfaces.Vector2D) (interfaces.Player, interfaces.InputHandler) {
+		scheme := input.PlayerTwoScheme()
+		if index != 1 {
+			// There are no more keys to share
+			scheme = input.Scheme{Gamepad: index - 1}
+		}
+		local := player.NewLocalPlayer(index, position.X, position.Y, resourceManager, config, engine, events, 800)
+		return local, input.NewPlayerInputHandler(events, index, scheme)
+	}
+}
+
 // Provide screen dimensions
 func provideScreenWidth(config *player.Configuration) int {
 	return config.ScreenWidth
@@ -121,8 +137,8 @@ func loadBackgroundImage(path string) (*ebiten.Image, error) {
 	return bg, nil
 }
 
-func provideItemManager(abilitiesMgr interfaces.AbilitiesManager) interfaces.ItemManager {
-	im := items.NewItemManager(abilitiesMgr)
+func provideItemManager() interfaces.ItemManager {
+	im := items.NewItemManager()
 	err := im.LoadItems("game/items.json")
 	if err != nil {
 		log.Fatalf("Failed to load items: %v", err)
@@ -147,7 +163,7 @@ func provideResourceManager(itemManager interfaces.ItemManager) interfaces.Resou
 }
 
 // Provide the PlatformGenerator implementation
-func providePlatformGenerator(config *player.Configuration, physicsEngine interfaces.PhysicsEngine) *gameMap.PlatformGenerator {
+func providePlatformGenerator(config *player.Configuration, physicsEngine interfaces.PhysicsEngine, settings interfaces.Settings) *gameMap.PlatformGenerator {
 	platformGenConfig := gameMap.PlatformGeneratorConfig{
 		MinPlatformDistance: 50,
 		MaxPlatformDistance: 150,
@@ -155,6 +171,18 @@ func providePlatformGenerator(config *player.Configuration, physicsEngine interf
 		PlatformHeight:      20,
 		ScreenWidth:         float64(config.ScreenWidth),
 		ScreenHeight:        float64(config.ScreenHeight),
+		Seed:                gameMap.GeneratorSeed(settings),
+		JumpArc:             physics.NewJumpArc(physics.EngineGravity, config.JumpVelocity, config.RunVelocity),
+		MinPlatformWidth:    60,
 	}
 	return gameMap.NewPlatformGenerator(platformGenConfig, physicsEngine)
 }
+
+// endlessLevelHeight is how far each level of endless mode climbs
+const endlessLevelHeight = 2000
+
+// Provide the levels of endless mode, generated like the platforms but
+// registered with the physics engine by the map once applied
+func provideEndlessLevels(platformGenerator *gameMap.PlatformGenerator) *gameMap.EndlessLevels {
+	return gameMap.NewEndlessLevels(gameMap.NewPlatformGenerator(platformGenerator.Config(), nil), endlessLevelHeight)
+}
@@ -0,0 +1,61 @@
+// Command levellint validates the level and game data files under assets and
+// exits with a non-zero status when content mistakes are found.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	"github.com/joaorufino/gopher-game/pkg/validation"
+)
+
+func main() {
+	assetsDir := flag.String("assets", "assets", "asset root containing the levels and game directories")
+	format := flag.String("format", "json", "output format: json or text")
+	gravity := flag.Float64("gravity", physics.EngineGravity, "gravity applied by the physics engine")
+	jumpVelocity := flag.Float64("jump-velocity", physics.PlayerJumpVelocity, "player jump velocity")
+	runVelocity := flag.Float64("run-velocity", physics.PlayerRunVelocity, "player run velocity")
+	flag.Parse()
+
+	if *format != "json" && *format != "text" {
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		os.Exit(2)
+	}
+
+	// Asset paths inside the data files are relative to the asset root, as in the game.
+	if err := os.Chdir(*assetsDir); err != nil {
+		log.Printf("failed to open assets directory: %v", err)
+		os.Exit(2)
+	}
+
+	validator := validation.NewValidator(validation.Config{
+		JumpArc: physics.NewJumpArc(*gravity, *jumpVelocity, *runVelocity),
+	})
+	report, err := validator.Run()
+	if err != nil {
+		log.Printf("validation failed: %v", err)
+		os.Exit(2)
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Printf("failed to write report: %v", err)
+			os.Exit(2)
+		}
+	} else {
+		for _, issue := range report.Issues {
+			fmt.Printf("%s: %s: [%s] %s\n", issue.File, issue.Severity, issue.Code, issue.Message)
+		}
+		fmt.Printf("%d files, %d errors, %d warnings\n", len(report.Files), report.Errors, report.Warnings)
+	}
+
+	if report.HasErrors() {
+		os.Exit(1)
+	}
+}
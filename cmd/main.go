@@ -1,17 +1,23 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"mime"
 	"net/http"
 )
 
 func main() {
+	// Serving the assets directory instead, together with ?hotreload in the
+	// page URL, makes the game pick up edits to its data files
+	dir := flag.String("dir", "dist", "directory to serve")
+	flag.Parse()
+
 	if err := mime.AddExtensionType(".wasm", "application/wasm"); err != nil {
 		panic(err)
 	}
 
-	fs := http.FileServer(http.Dir("dist"))
+	fs := http.FileServer(http.Dir(*dir))
 	http.Handle("/", noCacheHandler(fs))
 
 	log.Println("Listening on :8000...")
@@ -21,6 +27,7 @@ func main() {
 // noCacheHandler wraps an http.Handler to add no-cache headers to the response.
 func noCacheHandler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
 		h.ServeHTTP(w, r)
 	})
 }
@@ -6,9 +6,12 @@ package main
 import (
 	"context"
 	"log"
+	"net/url"
+	"strings"
 	"syscall/js"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
 	"github.com/joaorufino/gopher-game/pkg/game"
 	"go.uber.org/fx"
 
@@ -22,6 +25,7 @@ func main() {
 	app := fx.New(
 		fx.Provide(
 			providePlatformGenerator,
+			provideEndlessLevels,
 			provideConfiguration,
 			provideResourceManager,
 			provideInputHandler,
@@ -34,12 +38,14 @@ func main() {
 			provideParticleSystem,
 			provideBackgroundImage,
 			providePlayer,
+			providePlayerFactory,
 			provideItemManager,
 			provideAbilitiesManager,
 			fx.Annotate(provideScreenWidth, fx.ResultTags(`name:"screenWidth"`)),
 			fx.Annotate(provideScreenHeight, fx.ResultTags(`name:"screenHeight"`)),
 			game.NewGame,
 		),
+		fx.Decorate(devSettings),
 		fx.Invoke(startGame),
 	)
 
@@ -50,6 +56,50 @@ func main() {
 	<-c
 }
 
+// devSettings turns on the development features named in the page URL, e.g.
+// index.html?hotreload reloads game data and levels when they change,
+// index.html?mode=career picks the game mode, index.html?difficulty=hard
+// sets how well the soccer AI plays, index.html?players=2 adds a second
+// player on the keyboard, index.html?player2Team=blue puts it on the blue
+// team, index.html?blueFormation=4-3-3&redFormation=5-3-2 lines the teams
+// up, index.html?mode=tournament&tournament=knockout&tournamentTeam=Crabs
+// starts a tournament, index.html?aiDebug logs what the behavior trees of
+// enemies are doing and index.html?level=endless starts endless mode.
+func devSettings(settings interfaces.Settings) interfaces.Settings {
+	query, err := url.ParseQuery(strings.TrimPrefix(js.Global().Get("location").Get("search").String(), "?"))
+	if err != nil {
+		log.Printf("ignoring page query: %v", err)
+		return settings
+	}
+	if query.Has("hotreload") {
+		settings.Set("hotReload", true)
+	}
+	if query.Has("aiDebug") {
+		settings.Set("aiDebug", true)
+	}
+	if mode := query.Get("mode"); mode != "" {
+		settings.Set("mode", mode)
+	}
+	if difficulty := query.Get("difficulty"); difficulty != "" {
+		settings.Set("difficulty", difficulty)
+	}
+	if players := query.Get("players"); players != "" {
+		settings.Set("players", players)
+	}
+	if team := query.Get("player2Team"); team != "" {
+		settings.Set("player2Team", team)
+	}
+	for _, key := range []string{"blueFormation", "redFormation", "tournament", "tournamentTeam"} {
+		if name := query.Get(key); name != "" {
+			settings.Set(key, name)
+		}
+	}
+	if level := query.Get("level"); level != "" {
+		settings.Set("level", level)
+	}
+	return settings
+}
+
 func startGame(lc fx.Lifecycle, gameInstance *game.Game) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -0,0 +1,58 @@
+package core
+
+// AIAgent defines the interface for an AI agent in the game.
+type AIAgent interface {
+	// Initialize initializes the AI agent. This is called once when the agent is created.
+	Initialize() error
+	// Update updates the AI agent with the given delta time.
+	// deltaTime: The time elapsed since the last update in seconds.
+	Update(deltaTime float64) error
+	// SetTarget sets the target for the AI agent.
+	// target: The target point the AI agent should move towards or interact with.
+	SetTarget(target Vector2D)
+	// GetTarget returns the current target of the AI agent.
+	GetTarget() Vector2D
+	// GetPosition returns the current position of the AI agent.
+	GetPosition() Vector2D
+	// SetPosition sets the position of the AI agent.
+	// position: The new position for the AI agent.
+	SetPosition(position Vector2D)
+	// OnEnter is called when the AI agent is activated or enters a new state.
+	OnEnter() error
+	// OnExit is called when the AI agent is deactivated or exits its current state.
+	OnExit() error
+}
+
+// AISenses is what enemies know about the level around them.
+type AISenses interface {
+	// Target returns the area of the player enemies chase or run from, and
+	// false when there is nobody to go after.
+	Target() (Rect, bool)
+	// Platforms returns the areas enemies can stand and jump on.
+	Platforms() []Rect
+}
+
+// PetSenses is what a pet knows about the level around it, besides where the
+// player it follows is.
+type PetSenses interface {
+	// Platforms returns the areas the pet can stand and jump on.
+	Platforms() []Rect
+	// Obstacles returns the solid areas the pet jumps over or stops at.
+	Obstacles() []Rect
+	// Items returns the areas of the items the pet can fetch within radius
+	// of point.
+	Items(point Vector2D, radius float64) []Rect
+	// Enemies returns the areas of the enemies the pet can attack.
+	Enemies() []Rect
+	// Hazards returns the areas that hurt the player, for the pet to warn
+	// about.
+	Hazards() []Rect
+}
+
+// SpawnSenses tells an enemy spawner whether what it waits for has happened.
+type SpawnSenses interface {
+	// InTrigger reports whether the player is in the trigger so named.
+	InTrigger(name string) bool
+	// LogicOn reports whether the logic object with the id is on.
+	LogicOn(id string) bool
+}
@@ -0,0 +1,15 @@
+// Package core holds the game types that do not depend on ebiten, so that
+// headless tools such as levellint build without a graphics or audio driver.
+// Package interfaces re-exports them under the same names.
+package core
+
+// Vector2D represents a 2D point.
+type Vector2D struct {
+	X, Y float64
+}
+
+// Rect represents a rectangle.
+type Rect struct {
+	Position Vector2D `json:"position"`
+	Size     Vector2D `json:"size"`
+}
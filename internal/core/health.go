@@ -0,0 +1,51 @@
+package core
+
+// DamageType is the kind of harm dealt; items can resist some kinds.
+type DamageType string
+
+const (
+	DamagePhysical DamageType = "physical"
+	DamageFire     DamageType = "fire"
+	DamageElectric DamageType = "electric"
+	DamagePoison   DamageType = "poison"
+)
+
+// DamageTypes lists every known damage type.
+var DamageTypes = []DamageType{DamagePhysical, DamageFire, DamageElectric, DamagePoison}
+
+// Damage describes how much a source hurts, as level files declare it.
+type Damage struct {
+	Amount float64 `json:"amount"`
+	// Type defaults to physical damage.
+	Type DamageType `json:"type,omitempty"`
+	// Knockback is the speed the hit pushes the character away with.
+	Knockback float64 `json:"knockback,omitempty"`
+}
+
+// Hit is one instance of damage dealt to a character.
+type Hit struct {
+	Damage
+	// Source names what dealt the damage, e.g. "obstacle:research_paper".
+	Source string
+	// From is where the damage came from; knockback pushes away from it.
+	From Vector2D
+}
+
+// Health tracks the hit points and lives of a character.
+type Health interface {
+	HP() float64
+	MaxHP() float64
+	Lives() int
+	// Dead reports whether the character ran out of hit points.
+	Dead() bool
+	// GameOver reports whether the character ran out of lives.
+	GameOver() bool
+	// Invulnerable reports whether hits are ignored after a recent one.
+	Invulnerable() bool
+	// TakeDamage applies a hit and returns the damage actually taken.
+	TakeDamage(hit Hit) float64
+	// Kill takes a life no matter how many hit points are left.
+	Kill()
+	// Revive restores the hit points after dying.
+	Revive()
+}
@@ -1,5 +1,7 @@
 package interfaces
 
+import "github.com/joaorufino/gopher-game/internal/core"
+
 // AIManager defines the methods for managing AI behaviors.
 type AIManager interface {
 	// Initialize initializes the AI manager with necessary parameters.
@@ -15,25 +17,10 @@ type AIManager interface {
 	AddEnemy(x, y float64, behavior string)
 }
 
-// AIAgent defines the interface for an AI agent in the game.
-type AIAgent interface {
-	// Initialize initializes the AI agent. This is called once when the agent is created.
-	Initialize() error
-	// Update updates the AI agent with the given delta time.
-	// deltaTime: The time elapsed since the last update in seconds.
-	Update(deltaTime float64) error
-	// SetTarget sets the target for the AI agent.
-	// target: The target point the AI agent should move towards or interact with.
-	SetTarget(target Vector2D)
-	// GetTarget returns the current target of the AI agent.
-	GetTarget() Vector2D
-	// GetPosition returns the current position of the AI agent.
-	GetPosition() Vector2D
-	// SetPosition sets the position of the AI agent.
-	// position: The new position for the AI agent.
-	SetPosition(position Vector2D)
-	// OnEnter is called when the AI agent is activated or enters a new state.
-	OnEnter() error
-	// OnExit is called when the AI agent is deactivated or exits its current state.
-	OnExit() error
-}
+// The AI types that do not depend on ebiten live in package core.
+type (
+	AIAgent     = core.AIAgent
+	AISenses    = core.AISenses
+	PetSenses   = core.PetSenses
+	SpawnSenses = core.SpawnSenses
+)
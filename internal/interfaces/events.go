@@ -1,42 +1,40 @@
 package interfaces
 
-// EventType represents the type of an event.
-type EventType string
+import "github.com/joaorufino/gopher-game/internal/core"
 
-// Event represents a generic event with a type, priority, and payload.
-type Event struct {
-	Type     EventType
-	Priority int
-	Payload  interface{}
-}
-
-// EventHandler is a function that handles an event.
-type EventHandler func(Event)
-
-// EventManager defines the methods for managing event registration and dispatching.
-type EventManager interface {
-	// RegisterHandler registers a handler for the specified event type.
-	RegisterHandler(eventType EventType, handler EventHandler)
-	// Dispatch dispatches an event to the registered handlers.
-	Dispatch(event Event)
-	// Wait waits for all events to be processed.
-	Wait()
-}
+// The event types live in package core.
+type (
+	EventType    = core.EventType
+	Event        = core.Event
+	EventHandler = core.EventHandler
+	EventManager = core.EventManager
+)
 
-// Define your event types as needed.
 const (
-	EventPlayerJump   EventType = "PlayerJump"
-	EventPlayerMove   EventType = "PlayerMove"
-	EventItemEquipped EventType = "ItemEquipped"
-	EventSceneSwitch  EventType = "SceneSwitch"
-	EventTypeInput    EventType = "Input"
-
-	// Input Events
-	EventKeyPressed             EventType = "KeyPressed"
-	EventKeyJustPressed         EventType = "KeyJustPressed"
-	EventMouseButtonPressed     EventType = "MouseButtonPressed"
-	EventMouseButtonJustPressed EventType = "MouseButtonJustPressed"
-
-	EventTypeAbilityUsed         EventType = "AbilityUsed"
-	EventTypeAchievementUnlocked EventType = "AchievementUnlocked"
+	EventPlayerJump              = core.EventPlayerJump
+	EventPlayerMove              = core.EventPlayerMove
+	EventItemEquipped            = core.EventItemEquipped
+	EventSceneSwitch             = core.EventSceneSwitch
+	EventTypeInput               = core.EventTypeInput
+	EventKeyPressed              = core.EventKeyPressed
+	EventKeyJustPressed          = core.EventKeyJustPressed
+	EventMouseButtonPressed      = core.EventMouseButtonPressed
+	EventMouseButtonJustPressed  = core.EventMouseButtonJustPressed
+	EventTypeAbilityUsed         = core.EventTypeAbilityUsed
+	EventTypeAchievementUnlocked = core.EventTypeAchievementUnlocked
+	EventCheckpointActivated     = core.EventCheckpointActivated
+	EventPlayerDied              = core.EventPlayerDied
+	EventPlayerRespawned         = core.EventPlayerRespawned
+	EventDamageTaken             = core.EventDamageTaken
+	EventHealthChanged           = core.EventHealthChanged
+	EventGameOver                = core.EventGameOver
+	EventLogicChanged            = core.EventLogicChanged
+	EventLevelComplete           = core.EventLevelComplete
+	EventEnemyDefeated           = core.EventEnemyDefeated
+	EventWaveStarted             = core.EventWaveStarted
+	EventWaveCleared             = core.EventWaveCleared
+	EventWavesComplete           = core.EventWavesComplete
+	EventPetLevelUp              = core.EventPetLevelUp
+	EventBallKicked              = core.EventBallKicked
+	EventMatchPhaseChanged       = core.EventMatchPhaseChanged
 )
@@ -0,0 +1,21 @@
+package interfaces
+
+import "github.com/joaorufino/gopher-game/internal/core"
+
+// The damage and health types live in package core.
+type (
+	DamageType = core.DamageType
+	Damage     = core.Damage
+	Hit        = core.Hit
+	Health     = core.Health
+)
+
+const (
+	DamagePhysical = core.DamagePhysical
+	DamageFire     = core.DamageFire
+	DamageElectric = core.DamageElectric
+	DamagePoison   = core.DamagePoison
+)
+
+// DamageTypes lists every known damage type.
+var DamageTypes = core.DamageTypes
@@ -1,5 +1,7 @@
 package interfaces
 
+import "fmt"
+
 // InputHandler defines the interface for input handling
 type InputHandler interface {
 	IsJumpPressed() bool
@@ -7,6 +9,10 @@ type InputHandler interface {
 	IsDownPressed() bool
 	IsLeftPressed() bool
 	IsRightPressed() bool
+	// IsKickPressed reports whether the kick key or mouse button is held.
+	IsKickPressed() bool
+	// GetMousePosition returns the position of the cursor on the screen.
+	GetMousePosition() (int, int)
 	Update() error
 }
 
@@ -20,3 +26,14 @@ const (
 	KeyArrowDown
 	KeySpace
 )
+
+// PlayerEvent returns the type of the input events of eventType for one of
+// the players sharing the screen, counted from 0. The first player keeps the
+// plain event types; the others get their number in front, e.g.
+// "P2:KeyPressed_32", so every player only listens to its own input.
+func PlayerEvent(player int, eventType EventType) EventType {
+	if player == 0 {
+		return eventType
+	}
+	return EventType(fmt.Sprintf("P%d:%s", player+1, eventType))
+}
@@ -13,6 +13,8 @@ type ItemOnMap struct {
 type Map interface {
 	Update(deltaTime float64)
 	Draw(screen *ebiten.Image, camera Camera)
+	// DrawForeground draws the parts of the map that cover the player.
+	DrawForeground(screen *ebiten.Image, camera Camera)
 	SetObstacles(obstacles []interface{})
 	SetPlatforms(platforms []interface{})
 
@@ -0,0 +1,23 @@
+package interfaces
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// GameMode is a way of playing the game on top of the shared player, physics
+// and map, such as a soccer match or the career platformer.
+type GameMode interface {
+	// Name returns the name the mode is selected by.
+	Name() string
+	// Setup prepares the map, the player and anything else the mode needs.
+	Setup() error
+	// Hold runs before the world moves and reports whether it stays still
+	// this frame, e.g. while the player respawns.
+	Hold(deltaTime float64) bool
+	// Update runs the rules of the mode once the world moved this frame.
+	Update(deltaTime float64) error
+	// Draw draws what belongs to the mode over the world.
+	Draw(screen *ebiten.Image, camera Camera)
+	// Rules tells the player how to win, shown when the mode starts.
+	Rules() string
+	// Teardown removes everything the mode set up.
+	Teardown()
+}
@@ -4,6 +4,7 @@ import (
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/core"
 )
 
 // ParticleSystem defines the methods for handling particle effects.
@@ -17,12 +18,7 @@ type ParticleSystem interface {
 }
 
 // Vector2D represents a 2D point.
-type Vector2D struct {
-	X, Y float64
-}
+type Vector2D = core.Vector2D
 
 // Rect represents a rectangle.
-type Rect struct {
-	Position Vector2D `json:"position"`
-	Size     Vector2D `json:"size"`
-}
+type Rect = core.Rect
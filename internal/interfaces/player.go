@@ -8,5 +8,9 @@ type Player interface {
 	Draw(screen *ebiten.Image, camera Camera) error
 	GetPosition() Vector2D
 	SetPosition(position Vector2D)
+	GetSize() Vector2D
+	GetHealth() Health
+	// Damage hurts the player and knocks it away from the hit.
+	Damage(hit Hit) float64
 	EquipItem(item Item)
 }
@@ -35,3 +35,11 @@ func loadDataFromURL(url string, callback DataCallback) error {
 	}
 	return callback(data)
 }
+
+// SaveData writes data to a file in non-WASM builds.
+func SaveData(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
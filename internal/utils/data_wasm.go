@@ -6,6 +6,7 @@ package utils
 import (
 	"errors"
 	"log"
+	"path"
 	"syscall/js"
 )
 
@@ -46,3 +47,30 @@ func LoadData(path string, callback DataCallback) error {
 	<-done
 	return nil
 }
+
+// SaveData offers data to the user as a file download, since the browser has
+// no writable file system. The file is named after the last element of path.
+func SaveData(filePath string, data []byte) error {
+	document := js.Global().Get("document")
+	if document.IsUndefined() {
+		return errors.New("no document to download from")
+	}
+
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	blob := js.Global().Get("Blob").New([]interface{}{array}, map[string]interface{}{"type": "application/octet-stream"})
+	urlAPI := js.Global().Get("URL")
+	url := urlAPI.Call("createObjectURL", blob)
+
+	link := document.Call("createElement", "a")
+	link.Set("href", url)
+	link.Set("download", path.Base(filePath))
+	document.Get("body").Call("appendChild", link)
+	link.Call("click")
+	document.Get("body").Call("removeChild", link)
+
+	// Give the browser time to start the download before releasing the blob.
+	revoke := urlAPI.Get("revokeObjectURL").Call("bind", urlAPI, url)
+	js.Global().Call("setTimeout", revoke, 1000)
+	return nil
+}
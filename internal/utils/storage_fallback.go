@@ -0,0 +1,49 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ReadStorage returns the data kept under key in the user's config
+// directory, or nil when there is none.
+func ReadStorage(key string) ([]byte, error) {
+	path, err := storagePath(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// WriteStorage keeps data under key in the user's config directory.
+func WriteStorage(key string, data []byte) error {
+	path, err := storagePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return SaveData(path, data)
+}
+
+// storagePath returns the file data under key is kept in.
+func storagePath(key string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("no directory to store %s in: %w", key, err)
+	}
+	return filepath.Join(dir, "gopher-game", key), nil
+}
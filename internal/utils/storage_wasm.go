@@ -0,0 +1,39 @@
+//go:build js && wasm
+// +build js,wasm
+
+package utils
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// ReadStorage returns the data kept under key in the browser's local
+// storage, or nil when there is none.
+func ReadStorage(key string) ([]byte, error) {
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return nil, errors.New("no local storage")
+	}
+	value := storage.Call("getItem", key)
+	if value.IsNull() {
+		return nil, nil
+	}
+	return []byte(value.String()), nil
+}
+
+// WriteStorage keeps data under key in the browser's local storage.
+func WriteStorage(key string, data []byte) (err error) {
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return errors.New("no local storage")
+	}
+	// setItem throws once the storage is full
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("local storage is full")
+		}
+	}()
+	storage.Call("setItem", key, string(data))
+	return nil
+}
@@ -37,6 +37,8 @@ func (am *AbilitiesManagerImpl) LoadAbilities(path string) error {
 		if err := json.Unmarshal(data, &abilitiesData); err != nil {
 			return fmt.Errorf("failed to unmarshal abilities JSON: %w", err)
 		}
+		am.mu.Lock()
+		defer am.mu.Unlock()
 		for _, abilityData := range abilitiesData {
 			am.abilities[abilityData.Name] = NewAbility(
 				abilityData.Name,
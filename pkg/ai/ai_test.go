@@ -0,0 +1,409 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/behavior"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AI Suite")
+}
+
+type recordedEvents struct {
+	events []interfaces.Event
+}
+
+func (r *recordedEvents) RegisterHandler(interfaces.EventType, interfaces.EventHandler) {}
+func (r *recordedEvents) Dispatch(event interfaces.Event)                               { r.events = append(r.events, event) }
+func (r *recordedEvents) Wait()                                                         {}
+
+// countingAgent counts what the manager does with it.
+type countingAgent struct {
+	position interfaces.Vector2D
+	updates  int
+	entered  bool
+	fail     error
+}
+
+func (c *countingAgent) Initialize() error { return c.fail }
+func (c *countingAgent) Update(deltaTime float64) error {
+	c.updates++
+	return nil
+}
+func (c *countingAgent) SetTarget(target interfaces.Vector2D)     {}
+func (c *countingAgent) GetTarget() interfaces.Vector2D           { return interfaces.Vector2D{} }
+func (c *countingAgent) GetPosition() interfaces.Vector2D         { return c.position }
+func (c *countingAgent) SetPosition(position interfaces.Vector2D) { c.position = position }
+func (c *countingAgent) OnEnter() error {
+	c.entered = true
+	return nil
+}
+func (c *countingAgent) OnExit() error {
+	c.entered = false
+	return nil
+}
+
+var _ = Describe("Manager", func() {
+	var (
+		manager *Manager
+		created []*countingAgent
+	)
+
+	BeforeEach(func() {
+		created = nil
+		manager = NewManager()
+		manager.RegisterBehavior("count", func(position interfaces.Vector2D) (interfaces.AIAgent, error) {
+			agent := &countingAgent{position: position}
+			created = append(created, agent)
+			return agent, nil
+		})
+	})
+
+	It("should add enemies with a known behavior", func() {
+		manager.AddEnemy(10, 20, "count")
+		manager.AddEnemy(0, 0, "unknown")
+		Expect(manager.Agents()).To(HaveLen(1))
+		Expect(created[0].position).To(Equal(interfaces.Vector2D{X: 10, Y: 20}))
+		Expect(created[0].entered).To(BeTrue())
+	})
+
+	It("should place enemies in the middle of each area", func() {
+		manager.SetDefaultBehavior("count")
+		manager.PlaceEnemies([]interfaces.Rect{{Position: interfaces.Vector2D{X: 0, Y: 0}, Size: interfaces.Vector2D{X: 20, Y: 40}}})
+		Expect(created[0].position).To(Equal(interfaces.Vector2D{X: 10, Y: 20}))
+	})
+
+	It("should update agents until cleared", func() {
+		manager.AddEnemy(0, 0, "count")
+		manager.Update(1)
+		manager.Update(1)
+		Expect(created[0].updates).To(Equal(2))
+
+		manager.Clear()
+		Expect(created[0].entered).To(BeFalse())
+		Expect(manager.Agents()).To(BeEmpty())
+	})
+
+	It("should stop updating removed agents", func() {
+		manager.AddEnemy(0, 0, "count")
+		manager.AddEnemy(0, 0, "count")
+		manager.Remove(created[0])
+		manager.Update(1)
+		Expect(created[0].entered).To(BeFalse())
+		Expect(created[0].updates).To(Equal(0))
+		Expect(manager.Agents()).To(ConsistOf(created[1]))
+	})
+
+	It("should not add agents that fail to initialize", func() {
+		Expect(manager.Add(&countingAgent{fail: errors.New("broken")})).NotTo(Succeed())
+		Expect(manager.Agents()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("SoccerPlayer", func() {
+	var (
+		events *recordedEvents
+		ball   *physics.RigidBody
+		pitch  *Pitch
+	)
+
+	// addPlayer puts a player of the role in play with its center at x, y.
+	addPlayer := func(team, role string, x, y float64) *SoccerPlayer {
+		body := physics.NewRigidBody(interfaces.Vector2D{X: x - 15, Y: y - 15}, interfaces.Vector2D{X: 30, Y: 30}, 1, true, team+"_"+role)
+		player, err := pitch.AddPlayer(body, team, role, DifficultyHard)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(player.Initialize()).To(Succeed())
+		Expect(player.OnEnter()).To(Succeed())
+		return player
+	}
+	placeBall := func(x, y float64) {
+		ball.Teleport(interfaces.Vector2D{X: x - 10, Y: y - 10})
+	}
+
+	BeforeEach(func() {
+		events = &recordedEvents{}
+		ball = physics.NewRigidBody(interfaces.Vector2D{}, interfaces.Vector2D{X: 20, Y: 20}, 0.2, false, "ball")
+		pitch = NewPitch(interfaces.Rect{Size: interfaces.Vector2D{X: 800, Y: 600}}, ball, events, 1)
+		pitch.SetGoal("blue", interfaces.Rect{Position: interfaces.Vector2D{X: 0, Y: 225}, Size: interfaces.Vector2D{X: 20, Y: 150}})
+		pitch.SetGoal("red", interfaces.Rect{Position: interfaces.Vector2D{X: 780, Y: 225}, Size: interfaces.Vector2D{X: 20, Y: 150}})
+	})
+
+	It("should refuse unknown roles and teams", func() {
+		body := physics.NewRigidBody(interfaces.Vector2D{}, interfaces.Vector2D{X: 30, Y: 30}, 1, true, "x")
+		_, err := pitch.AddPlayer(body, "blue", "referee", DifficultyNormal)
+		Expect(err).To(HaveOccurred())
+		_, err = pitch.AddPlayer(body, "green", RoleStriker, DifficultyNormal)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should send the closest player of a team after the ball", func() {
+		near := addPlayer("blue", RoleMidfielder, 300, 300)
+		far := addPlayer("blue", RoleStriker, 450, 200)
+		placeBall(250, 320)
+
+		Expect(near.Update(0)).To(Succeed())
+		Expect(far.Update(0)).To(Succeed())
+		Expect(near.GetTarget()).To(Equal(interfaces.Vector2D{X: 250, Y: 320}))
+		Expect(far.GetTarget()).NotTo(Equal(near.GetTarget()))
+	})
+
+	It("should shoot at the goal it attacks once in range", func() {
+		striker := addPlayer("blue", RoleStriker, 600, 300)
+		placeBall(625, 300)
+
+		Expect(striker.Update(0)).To(Succeed())
+		Expect(ball.Velocity.X).To(BeNumerically(">", 350))
+		Expect(events.events).To(HaveLen(1))
+		Expect(events.events[0].Type).To(Equal(interfaces.EventBallKicked))
+		Expect(events.events[0].Payload).To(HaveKeyWithValue("kind", "shot"))
+		Expect(events.events[0].Payload).To(HaveKeyWithValue("team", "blue"))
+	})
+
+	It("should pass to a free teammate nearer the goal", func() {
+		defender := addPlayer("blue", RoleDefender, 150, 300)
+		striker := addPlayer("blue", RoleStriker, 450, 150)
+		addPlayer("red", RoleDefender, 150, 420)
+		placeBall(175, 300)
+
+		Expect(defender.Update(0)).To(Succeed())
+		toStriker := direction(bodyCenter(ball), bodyCenter(striker.Body))
+		Expect(ball.Velocity.X / ball.Velocity.Y).To(BeNumerically("~", toStriker.X/toStriker.Y, 0.5))
+		Expect(ball.Velocity.Y).To(BeNumerically("<", 0))
+	})
+
+	It("should keep the goalkeeper on its goal line", func() {
+		keeper := addPlayer("red", RoleGoalkeeper, 750, 300)
+		placeBall(400, 50)
+
+		Expect(keeper.Update(0)).To(Succeed())
+		Expect(keeper.GetTarget()).To(Equal(interfaces.Vector2D{X: 750, Y: 225}))
+
+		placeBall(700, 300)
+		Expect(keeper.Update(1)).To(Succeed())
+		Expect(keeper.GetTarget()).To(Equal(interfaces.Vector2D{X: 700, Y: 300}))
+	})
+
+	It("should mark opponents in its own half as a defender", func() {
+		addPlayer("blue", RoleMidfielder, 150, 500)
+		defender := addPlayer("blue", RoleDefender, 150, 150)
+		addPlayer("red", RoleStriker, 250, 150)
+		placeBall(200, 500)
+
+		Expect(defender.Update(0)).To(Succeed())
+		Expect(defender.GetTarget().X).To(BeNumerically("<", 250))
+		Expect(defender.GetTarget().Y).To(BeNumerically(">", 150))
+	})
+
+	It("should run no faster than its difficulty allows", func() {
+		player := addPlayer("blue", RoleMidfielder, 300, 300)
+		placeBall(700, 300)
+
+		Expect(player.Update(0.5)).To(Succeed())
+		Expect(bodyCenter(player.Body).X).To(BeNumerically("~", 360, 0.01))
+	})
+
+	It("should parse difficulty names", func() {
+		Expect(ParseDifficulty("hard")).To(Equal(DifficultyHard))
+		_, err := ParseDifficulty("impossible")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// fakeSenses puts the player and the platforms wherever a test needs them.
+type fakeSenses struct {
+	player    interfaces.Rect
+	visible   bool
+	platforms []interfaces.Rect
+}
+
+func (f *fakeSenses) Target() (interfaces.Rect, bool) { return f.player, f.visible }
+func (f *fakeSenses) Platforms() []interfaces.Rect    { return f.platforms }
+
+var _ = Describe("Enemy", func() {
+	var senses *fakeSenses
+
+	// addEnemy puts an awake enemy of the behavior with its center at x, y.
+	addEnemy := func(behavior string, x, y float64) *Enemy {
+		body := physics.NewRigidBody(interfaces.Vector2D{X: x - 16, Y: y - 16}, interfaces.Vector2D{X: 32, Y: 32}, 1, false, "enemy")
+		body.OnGround = true
+		enemy, err := NewEnemy(body, behavior, EnemyConfig{Gravity: 9.8}, senses)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(enemy.Initialize()).To(Succeed())
+		Expect(enemy.OnEnter()).To(Succeed())
+		return enemy
+	}
+	// placePlayer shows the player with its center at x, y.
+	placePlayer := func(x, y float64) {
+		senses.player = interfaces.Rect{Position: interfaces.Vector2D{X: x - 10, Y: y - 10}, Size: interfaces.Vector2D{X: 20, Y: 20}}
+		senses.visible = true
+	}
+
+	BeforeEach(func() {
+		senses = &fakeSenses{}
+	})
+
+	It("should refuse unknown behaviors", func() {
+		body := physics.NewRigidBody(interfaces.Vector2D{}, interfaces.Vector2D{X: 32, Y: 32}, 1, false, "enemy")
+		_, err := NewEnemy(body, "dance", EnemyConfig{}, senses)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should stand still until it is woken up", func() {
+		enemy := addEnemy(BehaviorPatrol, 100, 100)
+		Expect(enemy.OnExit()).To(Succeed())
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.Body.Velocity.X).To(BeZero())
+		Expect(enemy.State()).To(Equal(StateIdle))
+	})
+
+	It("should patrol back and forth around its start", func() {
+		enemy := addEnemy(BehaviorPatrol, 100, 100)
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StatePatrol))
+		Expect(enemy.GetTarget()).To(Equal(interfaces.Vector2D{X: 100 - patrolDistance, Y: 100}))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically("<", 0))
+
+		enemy.SetPosition(interfaces.Vector2D{X: 100 - patrolDistance - 16, Y: 84})
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.GetTarget()).To(Equal(interfaces.Vector2D{X: 100 + patrolDistance, Y: 100}))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically(">", 0))
+	})
+
+	It("should chase the player within its radius and jump after it", func() {
+		enemy := addEnemy(BehaviorChase, 100, 100)
+		placePlayer(500, 100)
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StatePatrol))
+
+		placePlayer(200, 40)
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateChase))
+		Expect(enemy.Body.Velocity.X).To(Equal(defaultEnemySpeed))
+		Expect(enemy.Body.Velocity.Y).To(Equal(-defaultEnemyJump))
+	})
+
+	It("should run away from the player within its radius", func() {
+		enemy := addEnemy(BehaviorFlee, 100, 100)
+		placePlayer(150, 100)
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateFlee))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically("<", 0))
+	})
+
+	It("should chase only near its post and go back to it", func() {
+		enemy := addEnemy(BehaviorGuard, 100, 100)
+		placePlayer(250, 100)
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateChase))
+
+		enemy.SetPosition(interfaces.Vector2D{X: 184, Y: 84})
+		placePlayer(400, 100)
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateReturn))
+		Expect(enemy.GetTarget()).To(Equal(enemy.Home()))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically("<", 0))
+	})
+
+	It("should hop to a platform within reach after resting", func() {
+		enemy := addEnemy(BehaviorJump, 100, 100)
+		senses.platforms = []interfaces.Rect{
+			{Position: interfaces.Vector2D{X: 50, Y: 116}, Size: interfaces.Vector2D{X: 100, Y: 20}},
+			{Position: interfaces.Vector2D{X: 200, Y: 80}, Size: interfaces.Vector2D{X: 100, Y: 20}},
+		}
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateJump))
+		Expect(enemy.GetTarget()).To(Equal(interfaces.Vector2D{X: 250, Y: 64}))
+		Expect(enemy.Body.Velocity.Y).To(Equal(-defaultEnemyJump))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically(">", 0))
+
+		enemy.Body.OnGround = true
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateIdle))
+	})
+})
+
+var _ = Describe("Enemy trees", func() {
+	It("should build every tree of the game from the enemy nodes", func() {
+		library, err := behavior.Load("../../assets/game/behaviors.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(library.Validate(EnemyNodes())).To(Succeed())
+	})
+
+	It("should guard its post following the sentry tree", func() {
+		library, err := behavior.Load("../../assets/game/behaviors.json")
+		Expect(err).NotTo(HaveOccurred())
+		tree, err := library.New("sentry", EnemyNodes())
+		Expect(err).NotTo(HaveOccurred())
+
+		senses := &fakeSenses{}
+		body := physics.NewRigidBody(interfaces.Vector2D{X: 84, Y: 84}, interfaces.Vector2D{X: 32, Y: 32}, 1, false, "enemy")
+		enemy, err := NewEnemy(body, BehaviorGuard, EnemyConfig{}, senses)
+		Expect(err).NotTo(HaveOccurred())
+		enemy.SetTree(tree)
+		Expect(enemy.Initialize()).To(Succeed())
+		Expect(enemy.OnEnter()).To(Succeed())
+
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateIdle))
+		Expect(tree.Path()).To(Equal("reactiveSelector/stop"))
+
+		senses.player = interfaces.Rect{Position: interfaces.Vector2D{X: 240, Y: 90}, Size: interfaces.Vector2D{X: 20, Y: 20}}
+		senses.visible = true
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateChase))
+		Expect(tree.Path()).To(Equal("reactiveSelector/defend/chase"))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically(">", 0))
+
+		enemy.SetPosition(interfaces.Vector2D{X: 184, Y: 84})
+		senses.visible = false
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateReturn))
+		Expect(tree.Path()).To(Equal("reactiveSelector/goBack/returnHome"))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically("<", 0))
+	})
+})
+
+var _ = Describe("Enemy navigation", func() {
+	It("should jump its way to a player on another platform", func() {
+		senses := &fakeSenses{visible: true}
+		platforms := []interfaces.Rect{
+			{Position: interfaces.Vector2D{X: 0, Y: 300}, Size: interfaces.Vector2D{X: 200, Y: 20}},
+			{Position: interfaces.Vector2D{X: 240, Y: 260}, Size: interfaces.Vector2D{X: 100, Y: 20}},
+		}
+		body := physics.NewRigidBody(interfaces.Vector2D{X: 84, Y: 268}, interfaces.Vector2D{X: 32, Y: 32}, 1, false, "enemy")
+		body.OnGround = true
+		config := EnemyConfig{Gravity: 9.8}
+		enemy, err := NewEnemy(body, BehaviorChase, config, senses)
+		Expect(err).NotTo(HaveOccurred())
+		graph := navigation.NewGraph(config.Navigation(body.Size))
+		graph.Rebuild(platforms)
+		enemy.SetGraph(graph)
+		Expect(enemy.Initialize()).To(Succeed())
+		Expect(enemy.OnEnter()).To(Succeed())
+
+		// The player stands on the higher platform, out of the way of a
+		// straight run
+		senses.player = interfaces.Rect{Position: interfaces.Vector2D{X: 280, Y: 240}, Size: interfaces.Vector2D{X: 20, Y: 20}}
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.State()).To(Equal(StateChase))
+		Expect(enemy.Plan()).NotTo(BeNil())
+		Expect(enemy.Plan().Links).To(HaveLen(1))
+		Expect(enemy.Plan().Links[0].Kind).To(Equal(navigation.LinkJump))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically(">", 0))
+		Expect(enemy.Body.Velocity.Y).To(BeZero())
+
+		// At the edge it takes off
+		enemy.SetPosition(interfaces.Vector2D{X: 168, Y: 268})
+		Expect(enemy.Update(0.1)).To(Succeed())
+		Expect(enemy.Body.Velocity.Y).To(Equal(-defaultEnemyJump))
+		Expect(enemy.Body.Velocity.X).To(BeNumerically(">", 0))
+	})
+})
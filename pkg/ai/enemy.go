@@ -0,0 +1,412 @@
+package ai
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/behavior"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// Behaviors of the enemies, as named in level files.
+const (
+	// BehaviorPatrol walks back and forth between points.
+	BehaviorPatrol = "patrol"
+	// BehaviorChase patrols until the player comes within its radius, then
+	// runs after the player, jumping up after it.
+	BehaviorChase = "chase"
+	// BehaviorFlee patrols until the player comes within its radius, then
+	// runs away from the player.
+	BehaviorFlee = "flee"
+	// BehaviorGuard stands at its post and chases the player only within its
+	// radius of the post, going back once the player leaves.
+	BehaviorGuard = "guard"
+	// BehaviorJump hops from platform to platform, resting in between.
+	BehaviorJump = "jump"
+)
+
+// EnemyBehaviors lists every enemy behavior.
+var EnemyBehaviors = []string{BehaviorPatrol, BehaviorChase, BehaviorFlee, BehaviorGuard, BehaviorJump}
+
+// What an enemy is doing, e.g. to draw it.
+const (
+	StateIdle   = "idle"
+	StatePatrol = "patrol"
+	StateChase  = "chase"
+	StateFlee   = "flee"
+	StateReturn = "return"
+	StateJump   = "jump"
+)
+
+const (
+	defaultEnemySpeed  = 60.0  // Running speed in pixels per second
+	defaultSightRadius = 200.0 // Distance within which the player is noticed
+	defaultEnemyJump   = 80.0  // Take-off speed of a jump
+	patrolDistance     = 80.0  // How far a patrol goes either side of its start
+	arriveDistance     = 4.0   // Gap to a point the enemy stops at
+	climbHeight        = 20.0  // How far above a chaser the player has to be to jump after it
+	jumpRest           = 1.0   // Seconds a jumping enemy rests between jumps
+	groundTolerance    = 2.0   // Gap between feet and a platform that still counts as standing on it
+	replanInterval     = 0.5   // Seconds between finding the way to a target again
+	replanDistance     = 32.0  // How far a target moves before the way to it is found again
+)
+
+// EnemyConfig tunes an enemy; fields left at zero take the defaults.
+type EnemyConfig struct {
+	Speed        float64 // Running speed in pixels per second
+	Radius       float64 // Distance within which the enemy notices the player
+	JumpVelocity float64 // Take-off speed of a jump
+	Gravity      float64 // Gravity of the physics engine, to tell how far a jump goes
+	// Points are where a patrol goes, by the center of the enemy. A patrol
+	// goes patrolDistance either side of its start unless set.
+	Points []interfaces.Vector2D
+}
+
+// withDefaults returns the config with the defaults in the fields left at
+// zero.
+func (c EnemyConfig) withDefaults() EnemyConfig {
+	if c.Speed <= 0 {
+		c.Speed = defaultEnemySpeed
+	}
+	if c.Radius <= 0 {
+		c.Radius = defaultSightRadius
+	}
+	if c.JumpVelocity <= 0 {
+		c.JumpVelocity = defaultEnemyJump
+	}
+	return c
+}
+
+// Navigation returns how a navigation graph is built for enemies of the
+// given size tuned by the config.
+func (c EnemyConfig) Navigation(size interfaces.Vector2D) navigation.Config {
+	c = c.withDefaults()
+	return navigation.Config{
+		Arc:      physics.NewJumpArc(c.Gravity, c.JumpVelocity, c.Speed),
+		BodySize: size,
+	}
+}
+
+// Enemy implements interfaces.AIAgent for an enemy of a level. It is backed
+// by a rigid body the physics engine moves: the enemy only sets how fast it
+// runs and when it jumps.
+type Enemy struct {
+	Body     *physics.RigidBody
+	Behavior string
+	config   EnemyConfig
+	senses   interfaces.AISenses
+	home     interfaces.Vector2D
+	target   interfaces.Vector2D
+	state    string
+	waypoint int
+	platform int // The platform a jumping enemy jumped to last
+	restIn   float64
+	active   bool
+	tree     *behavior.Tree
+	graph    *navigation.Graph
+	plan     *navigation.Plan
+	replanIn float64
+}
+
+// NewEnemy creates an enemy with the named behavior on body, which knows
+// where the player and the platforms are through senses. Its start is the
+// post it guards.
+func NewEnemy(body *physics.RigidBody, behavior string, config EnemyConfig, senses interfaces.AISenses) (*Enemy, error) {
+	known := false
+	for _, name := range EnemyBehaviors {
+		known = known || name == behavior
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown enemy behavior %q", behavior)
+	}
+	config = config.withDefaults()
+	home := bodyCenter(body)
+	if len(config.Points) == 0 {
+		config.Points = []interfaces.Vector2D{{X: home.X - patrolDistance, Y: home.Y}, {X: home.X + patrolDistance, Y: home.Y}}
+	}
+	return &Enemy{Body: body, Behavior: behavior, config: config, senses: senses, home: home, platform: -1}, nil
+}
+
+// Initialize makes the enemy stand still until its first update.
+func (e *Enemy) Initialize() error {
+	e.target = bodyCenter(e.Body)
+	e.state = StateIdle
+	return nil
+}
+
+// SetTree makes the enemy follow a behavior tree instead of its behavior.
+// The leaves of the tree come from EnemyNodes.
+func (e *Enemy) SetTree(tree *behavior.Tree) {
+	e.tree = tree
+}
+
+// Tree returns the behavior tree the enemy follows, or nil.
+func (e *Enemy) Tree() *behavior.Tree {
+	return e.tree
+}
+
+// SetGraph lets the enemy find its way to targets on other platforms when
+// chasing or going back to its post.
+func (e *Enemy) SetGraph(graph *navigation.Graph) {
+	e.graph = graph
+	e.plan = nil
+}
+
+// Plan returns the way the enemy is following to its target, or nil.
+func (e *Enemy) Plan() *navigation.Plan {
+	return e.plan
+}
+
+// Update lets the behavior, or the behavior tree, pick where to go and runs
+// there.
+func (e *Enemy) Update(deltaTime float64) error {
+	if !e.active {
+		return nil
+	}
+	if e.tree != nil {
+		e.tree.Tick(e, deltaTime)
+		e.run(deltaTime)
+		return nil
+	}
+	switch e.Behavior {
+	case BehaviorPatrol:
+		e.patrol()
+	case BehaviorChase:
+		if player, ok := e.noticed(bodyCenter(e.Body)); ok {
+			e.state, e.target = StateChase, player
+		} else {
+			e.patrol()
+		}
+	case BehaviorFlee:
+		if player, ok := e.noticed(bodyCenter(e.Body)); ok {
+			e.state, e.target = StateFlee, e.awayFrom(player)
+		} else {
+			e.patrol()
+		}
+	case BehaviorGuard:
+		e.guard()
+	case BehaviorJump:
+		e.hop(deltaTime)
+	}
+	e.run(deltaTime)
+	return nil
+}
+
+// SetTarget sends the enemy to target, which is where its center goes,
+// until its behavior picks another one.
+func (e *Enemy) SetTarget(target interfaces.Vector2D) {
+	e.target = target
+}
+
+// GetTarget returns where the center of the enemy is going.
+func (e *Enemy) GetTarget() interfaces.Vector2D {
+	return e.target
+}
+
+func (e *Enemy) GetPosition() interfaces.Vector2D {
+	return e.Body.Position
+}
+
+func (e *Enemy) SetPosition(position interfaces.Vector2D) {
+	e.Body.Teleport(position)
+}
+
+// OnEnter wakes the enemy up.
+func (e *Enemy) OnEnter() error {
+	e.active = true
+	return nil
+}
+
+// OnExit stops the enemy where it is.
+func (e *Enemy) OnExit() error {
+	e.active = false
+	e.Body.Velocity.X = 0
+	e.state = StateIdle
+	return nil
+}
+
+// State returns what the enemy is doing.
+func (e *Enemy) State() string {
+	return e.state
+}
+
+// Home returns the center of the post of the enemy.
+func (e *Enemy) Home() interfaces.Vector2D {
+	return e.home
+}
+
+// patrol heads for the next point of the patrol, turning around at each.
+func (e *Enemy) patrol() {
+	e.state = StatePatrol
+	point := e.config.Points[e.waypoint%len(e.config.Points)]
+	if math.Abs(point.X-bodyCenter(e.Body).X) <= arriveDistance {
+		e.waypoint = (e.waypoint + 1) % len(e.config.Points)
+		point = e.config.Points[e.waypoint]
+	}
+	e.target = point
+}
+
+// guard chases the player while it is near the post and goes back to the
+// post otherwise.
+func (e *Enemy) guard() {
+	if player, ok := e.noticed(e.home); ok {
+		e.state, e.target = StateChase, player
+		return
+	}
+	e.target = e.home
+	e.state = StateIdle
+	if math.Abs(e.home.X-bodyCenter(e.Body).X) > arriveDistance {
+		e.state = StateReturn
+	}
+}
+
+// hop jumps to another platform within reach once the enemy has rested on
+// the one it stands on, and steers toward it in the air.
+func (e *Enemy) hop(deltaTime float64) {
+	if !e.Body.OnGround {
+		e.state = StateJump
+		return
+	}
+	e.state = StateIdle
+	e.target = bodyCenter(e.Body)
+	e.restIn -= deltaTime
+	if e.restIn > 0 {
+		return
+	}
+	e.restIn = jumpRest
+	e.leap()
+}
+
+// leap jumps to the next platform within reach, reporting false when there
+// is none.
+func (e *Enemy) leap() bool {
+	if e.senses == nil {
+		return false
+	}
+	platforms := e.senses.Platforms()
+	next, ok := e.nextPlatform(platforms)
+	if !ok {
+		return false
+	}
+	e.platform = next
+	top := platforms[next]
+	e.target = interfaces.Vector2D{X: top.Position.X + top.Size.X/2, Y: top.Position.Y - e.Body.Size.Y/2}
+	e.jump()
+	e.state = StateJump
+	return true
+}
+
+// nextPlatform returns the first platform within a jump after the one the
+// enemy jumped to last, other than the one it stands on.
+func (e *Enemy) nextPlatform(platforms []interfaces.Rect) (int, bool) {
+	arc := physics.NewJumpArc(e.config.Gravity, e.config.JumpVelocity, e.config.Speed)
+	feet := interfaces.Rect{
+		Position: interfaces.Vector2D{X: e.Body.Position.X, Y: e.Body.Position.Y + e.Body.Size.Y},
+		Size:     interfaces.Vector2D{X: e.Body.Size.X},
+	}
+	first := -1
+	for i, platform := range platforms {
+		standing := physics.HorizontalGap(feet, platform) == 0 && math.Abs(platform.Position.Y-feet.Position.Y) <= groundTolerance
+		if standing || !arc.CanReach(feet, platform) {
+			continue
+		}
+		if i > e.platform {
+			return i, true
+		}
+		if first < 0 {
+			first = i
+		}
+	}
+	return first, first >= 0
+}
+
+// noticed returns the center of the player when it is within the radius of
+// point.
+func (e *Enemy) noticed(point interfaces.Vector2D) (interfaces.Vector2D, bool) {
+	if e.senses == nil {
+		return interfaces.Vector2D{}, false
+	}
+	player, ok := e.senses.Target()
+	if !ok {
+		return interfaces.Vector2D{}, false
+	}
+	center := rectCenter(player)
+	return center, distance(point, center) <= e.config.Radius
+}
+
+// awayFrom returns a point a radius away from player, behind the enemy.
+func (e *Enemy) awayFrom(player interfaces.Vector2D) interfaces.Vector2D {
+	center := bodyCenter(e.Body)
+	away := 1.0
+	if player.X > center.X {
+		away = -1
+	}
+	return interfaces.Vector2D{X: center.X + away*e.config.Radius, Y: center.Y}
+}
+
+// run sets the horizontal speed toward the target, and jumps after a target
+// above a chaser standing on the ground. Chasing or going back to its post,
+// it follows the way to a target on another platform instead.
+func (e *Enemy) run(deltaTime float64) {
+	if e.state != StateChase && e.state != StateReturn {
+		e.plan, e.replanIn = nil, 0
+	} else if e.navigate(deltaTime) {
+		return
+	}
+	center := bodyCenter(e.Body)
+	e.runTo(e.target.X)
+	if e.state == StateChase && e.Body.OnGround && center.Y-e.target.Y > climbHeight {
+		e.jump()
+	}
+}
+
+// runTo sets the horizontal speed toward x.
+func (e *Enemy) runTo(x float64) {
+	dx := x - bodyCenter(e.Body).X
+	if math.Abs(dx) <= arriveDistance {
+		e.Body.Velocity.X = 0
+	} else {
+		e.Body.Velocity.X = math.Copysign(e.config.Speed, dx)
+	}
+}
+
+// navigate follows the way through the graph to a target on another
+// platform, finding it again every so often or once the target moves away
+// from its end. It reports false when there is no graph, the target is on
+// the same platform or there is no way to it.
+func (e *Enemy) navigate(deltaTime float64) bool {
+	if e.graph == nil {
+		return false
+	}
+	feet := interfaces.Vector2D{X: e.Body.Position.X + e.Body.Size.X/2, Y: e.Body.Position.Y + e.Body.Size.Y}
+	e.replanIn -= deltaTime
+	moved := e.plan != nil && distance(e.plan.Goal(), e.target) > replanDistance+e.Body.Size.Y
+	if e.Body.OnGround && (e.replanIn <= 0 || moved) {
+		e.replanIn = replanInterval
+		e.plan = nil
+		if plan, ok := e.graph.FindPath(feet, e.target); ok && len(plan.Links) > 0 {
+			e.plan = plan
+		}
+	}
+	if e.plan == nil {
+		return false
+	}
+	x, jump, ok := e.plan.Next(feet, e.Body.OnGround, arriveDistance)
+	if !ok {
+		e.plan = nil
+		return false
+	}
+	e.runTo(x)
+	if jump {
+		e.jump()
+	}
+	return true
+}
+
+// jump takes off from the ground.
+func (e *Enemy) jump() {
+	e.Body.Velocity.Y = -e.config.JumpVelocity
+	e.Body.OnGround = false
+}
@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/behavior"
+)
+
+// KeyPlayer is the blackboard entry where the conditions that notice the
+// player put its center, for the actions that go after it or away from it.
+const KeyPlayer = "player"
+
+// EnemyNodes returns the actions and conditions the behavior trees of
+// enemies are built from:
+//
+//   - seesPlayer: the player is within the radius of the enemy.
+//   - playerNearHome: the player is within the radius of the post.
+//   - atHome: the enemy stands at its post.
+//   - onGround: the enemy is not in the air.
+//   - patrol: walks the patrol, forever.
+//   - chase: runs after the player noticed last, forever.
+//   - flee: runs away from the player noticed last, forever.
+//   - returnHome: goes back to the post.
+//   - hop: jumps to the next platform within reach and lands on it.
+//   - jump: takes off from the ground.
+//   - stop: stands still.
+func EnemyNodes() *behavior.Registry {
+	registry := behavior.NewRegistry()
+	registry.RegisterCondition("seesPlayer", enemyCondition(func(e *Enemy, ctx *behavior.TickContext) bool {
+		return e.remember(ctx, bodyCenter(e.Body))
+	}))
+	registry.RegisterCondition("playerNearHome", enemyCondition(func(e *Enemy, ctx *behavior.TickContext) bool {
+		return e.remember(ctx, e.home)
+	}))
+	registry.RegisterCondition("atHome", enemyCondition(func(e *Enemy, ctx *behavior.TickContext) bool {
+		return math.Abs(e.home.X-bodyCenter(e.Body).X) <= arriveDistance
+	}))
+	registry.RegisterCondition("onGround", enemyCondition(func(e *Enemy, ctx *behavior.TickContext) bool {
+		return e.Body.OnGround
+	}))
+
+	registry.RegisterAction("patrol", enemyAction(func(e *Enemy, ctx *behavior.TickContext) behavior.Status {
+		e.patrol()
+		return behavior.Running
+	}))
+	registry.RegisterAction("chase", enemyAction(func(e *Enemy, ctx *behavior.TickContext) behavior.Status {
+		player, ok := ctx.Blackboard.Vector(KeyPlayer)
+		if !ok {
+			return behavior.Failure
+		}
+		e.state, e.target = StateChase, player
+		return behavior.Running
+	}))
+	registry.RegisterAction("flee", enemyAction(func(e *Enemy, ctx *behavior.TickContext) behavior.Status {
+		player, ok := ctx.Blackboard.Vector(KeyPlayer)
+		if !ok {
+			return behavior.Failure
+		}
+		e.state, e.target = StateFlee, e.awayFrom(player)
+		return behavior.Running
+	}))
+	registry.RegisterAction("returnHome", enemyAction(func(e *Enemy, ctx *behavior.TickContext) behavior.Status {
+		e.target = e.home
+		if math.Abs(e.home.X-bodyCenter(e.Body).X) <= arriveDistance {
+			e.state = StateIdle
+			return behavior.Success
+		}
+		e.state = StateReturn
+		return behavior.Running
+	}))
+	registry.RegisterAction("hop", enemyAction(func(e *Enemy, ctx *behavior.TickContext) behavior.Status {
+		if e.state == StateJump {
+			if !e.Body.OnGround {
+				return behavior.Running
+			}
+			e.state, e.target = StateIdle, bodyCenter(e.Body)
+			return behavior.Success
+		}
+		if !e.Body.OnGround || !e.leap() {
+			return behavior.Failure
+		}
+		return behavior.Running
+	}))
+	registry.RegisterAction("jump", enemyAction(func(e *Enemy, ctx *behavior.TickContext) behavior.Status {
+		if !e.Body.OnGround {
+			return behavior.Failure
+		}
+		e.jump()
+		return behavior.Success
+	}))
+	registry.RegisterAction("stop", enemyAction(func(e *Enemy, ctx *behavior.TickContext) behavior.Status {
+		e.state, e.target = StateIdle, bodyCenter(e.Body)
+		return behavior.Success
+	}))
+	return registry
+}
+
+// remember notes the center of the player on the blackboard when it is
+// within the radius of point, and forgets it otherwise.
+func (e *Enemy) remember(ctx *behavior.TickContext, point interfaces.Vector2D) bool {
+	player, ok := e.noticed(point)
+	if !ok {
+		ctx.Blackboard.Delete(KeyPlayer)
+		return false
+	}
+	ctx.Blackboard.Set(KeyPlayer, player)
+	return true
+}
+
+// enemyAction makes an action of a tree run by an enemy.
+func enemyAction(action func(e *Enemy, ctx *behavior.TickContext) behavior.Status) behavior.Action {
+	return func(ctx *behavior.TickContext) behavior.Status {
+		e, ok := ctx.Agent.(*Enemy)
+		if !ok {
+			return behavior.Failure
+		}
+		return action(e, ctx)
+	}
+}
+
+// enemyCondition makes a condition of a tree run by an enemy.
+func enemyCondition(condition func(e *Enemy, ctx *behavior.TickContext) bool) behavior.Condition {
+	return func(ctx *behavior.TickContext) bool {
+		e, ok := ctx.Agent.(*Enemy)
+		return ok && condition(e, ctx)
+	}
+}
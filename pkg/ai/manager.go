@@ -0,0 +1,114 @@
+package ai
+
+import (
+	"log"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// BehaviorFactory creates an agent with some behavior at a position.
+type BehaviorFactory func(position interfaces.Vector2D) (interfaces.AIAgent, error)
+
+// Manager implements interfaces.AIManager. It owns the agents it is given and
+// updates them every frame.
+type Manager struct {
+	world           interfaces.World
+	agents          []interfaces.AIAgent
+	behaviors       map[string]BehaviorFactory
+	defaultBehavior string
+}
+
+// NewManager creates a manager without agents or behaviors.
+func NewManager() *Manager {
+	return &Manager{behaviors: map[string]BehaviorFactory{}}
+}
+
+// Initialize keeps the world the agents live in.
+func (m *Manager) Initialize(world interfaces.World) {
+	m.world = world
+}
+
+// RegisterBehavior makes a behavior available to AddEnemy by name.
+func (m *Manager) RegisterBehavior(name string, factory BehaviorFactory) {
+	m.behaviors[name] = factory
+}
+
+// SetDefaultBehavior sets the behavior of the enemies PlaceEnemies places.
+func (m *Manager) SetDefaultBehavior(name string) {
+	m.defaultBehavior = name
+}
+
+// PlaceEnemies places an enemy with the default behavior in the middle of
+// each rectangle.
+func (m *Manager) PlaceEnemies(rects []interfaces.Rect) {
+	for _, rect := range rects {
+		m.AddEnemy(rect.Position.X+rect.Size.X/2, rect.Position.Y+rect.Size.Y/2, m.defaultBehavior)
+	}
+}
+
+// AddEnemy creates an agent with the named behavior at x, y.
+func (m *Manager) AddEnemy(x, y float64, behavior string) {
+	factory, ok := m.behaviors[behavior]
+	if !ok {
+		log.Printf("unknown AI behavior %q", behavior)
+		return
+	}
+	agent, err := factory(interfaces.Vector2D{X: x, Y: y})
+	if err != nil {
+		log.Printf("could not create AI agent %q: %v", behavior, err)
+		return
+	}
+	if err := m.Add(agent); err != nil {
+		log.Printf("could not add AI agent %q: %v", behavior, err)
+	}
+}
+
+// Add initializes an agent and starts updating it.
+func (m *Manager) Add(agent interfaces.AIAgent) error {
+	if err := agent.Initialize(); err != nil {
+		return err
+	}
+	if err := agent.OnEnter(); err != nil {
+		return err
+	}
+	m.agents = append(m.agents, agent)
+	return nil
+}
+
+// Agents returns the agents being updated.
+func (m *Manager) Agents() []interfaces.AIAgent {
+	return m.agents
+}
+
+// Update updates every agent. An agent failing does not stop the others.
+func (m *Manager) Update(deltaTime float64) {
+	for _, agent := range m.agents {
+		if err := agent.Update(deltaTime); err != nil {
+			log.Printf("AI agent failed: %v", err)
+		}
+	}
+}
+
+// Remove deactivates an agent and stops updating it.
+func (m *Manager) Remove(agent interfaces.AIAgent) {
+	for i, a := range m.agents {
+		if a != agent {
+			continue
+		}
+		if err := agent.OnExit(); err != nil {
+			log.Printf("AI agent failed to exit: %v", err)
+		}
+		m.agents = append(m.agents[:i], m.agents[i+1:]...)
+		return
+	}
+}
+
+// Clear deactivates and forgets every agent.
+func (m *Manager) Clear() {
+	for _, agent := range m.agents {
+		if err := agent.OnExit(); err != nil {
+			log.Printf("AI agent failed to exit: %v", err)
+		}
+	}
+	m.agents = nil
+}
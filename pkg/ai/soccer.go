@@ -0,0 +1,449 @@
+package ai
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/kick"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// Roles of the soccer players, as named in the team formations.
+const (
+	RoleGoalkeeper = "goalkeeper"
+	RoleDefender   = "defender"
+	RoleMidfielder = "midfielder"
+	RoleStriker    = "striker"
+)
+
+// Difficulty sets how fast, how quick to react and how accurate the soccer
+// players are.
+type Difficulty int
+
+const (
+	DifficultyEasy Difficulty = iota
+	DifficultyNormal
+	DifficultyHard
+)
+
+var difficultyNames = map[string]Difficulty{
+	"easy":   DifficultyEasy,
+	"normal": DifficultyNormal,
+	"hard":   DifficultyHard,
+}
+
+// ParseDifficulty returns the difficulty with the given name: easy, normal or
+// hard.
+func ParseDifficulty(name string) (Difficulty, error) {
+	difficulty, ok := difficultyNames[name]
+	if !ok {
+		return DifficultyNormal, fmt.Errorf("unknown difficulty %q", name)
+	}
+	return difficulty, nil
+}
+
+// skill is what the difficulty changes about a player.
+type skill struct {
+	speed    float64 // Running speed in pixels per second
+	reaction float64 // Seconds between two decisions
+	aimError float64 // Largest angle a kick goes off target by, in radians
+}
+
+var skills = map[Difficulty]skill{
+	DifficultyEasy:   {speed: 60, reaction: 0.6, aimError: 0.35},
+	DifficultyNormal: {speed: 90, reaction: 0.35, aimError: 0.2},
+	DifficultyHard:   {speed: 120, reaction: 0.15, aimError: 0.08},
+}
+
+// role is how the players of a role play.
+type role struct {
+	shift      float64 // How far the player follows the ball away from its spot
+	shootRange float64 // Distance to the goal the player shoots from, 0 for never
+	chases     bool    // Whether the player goes for the ball when closest to it
+}
+
+var roles = map[string]role{
+	RoleGoalkeeper: {},
+	RoleDefender:   {shift: 0.3, chases: true},
+	RoleMidfielder: {shift: 0.5, shootRange: 250, chases: true},
+	RoleStriker:    {shift: 0.6, shootRange: 350, chases: true},
+}
+
+const (
+	reach        = 10.0  // Gap to the ball a player kicks it from
+	kickCooldown = 0.5   // Seconds before a player kicks again
+	shotPower    = 400.0 // Speed of a shot on goal
+	clearPower   = 350.0 // Speed of a defensive clearance
+	dribblePower = 120.0 // Speed of the ball pushed forward
+	passSpeed    = 1.5   // Speed of a pass for every pixel it travels
+	markRadius   = 60.0  // Distance within which an opponent marks a player
+	markDistance = 40.0  // How far a defender stays goal side of its opponent
+	keeperRange  = 120.0 // Distance to the goal the goalkeeper comes out from
+)
+
+// Pitch is what the soccer players know about the match: the field, the
+// ball, the goals and everyone playing.
+type Pitch struct {
+	Field        interfaces.Rect
+	Ball         *physics.RigidBody
+	goals        map[string]interfaces.Rect
+	players      []*SoccerPlayer
+	eventManager interfaces.EventManager
+	rng          *rand.Rand
+}
+
+// NewPitch creates a pitch without goals or players. Kicks are reported to
+// eventManager, if any, and the seed drives how far off target they go.
+func NewPitch(field interfaces.Rect, ball *physics.RigidBody, eventManager interfaces.EventManager, seed int64) *Pitch {
+	return &Pitch{
+		Field:        field,
+		Ball:         ball,
+		goals:        map[string]interfaces.Rect{},
+		eventManager: eventManager,
+		rng:          rand.New(rand.NewSource(seed)),
+	}
+}
+
+// SetGoal sets the goal a team defends.
+func (p *Pitch) SetGoal(team string, goal interfaces.Rect) {
+	p.goals[team] = goal
+}
+
+// AddPlayer puts a player of a team on the pitch, taking its current position
+// as its spot in the formation.
+func (p *Pitch) AddPlayer(body *physics.RigidBody, team, roleName string, difficulty Difficulty) (*SoccerPlayer, error) {
+	if _, ok := roles[roleName]; !ok {
+		return nil, fmt.Errorf("unknown soccer role %q", roleName)
+	}
+	if _, ok := p.goals[team]; !ok {
+		return nil, fmt.Errorf("team %q has no goal", team)
+	}
+	player := &SoccerPlayer{
+		Body:  body,
+		Team:  team,
+		Role:  roleName,
+		pitch: p,
+		home:  bodyCenter(body),
+		skill: skills[difficulty],
+	}
+	p.players = append(p.players, player)
+	return player, nil
+}
+
+// Players returns everyone on the pitch.
+func (p *Pitch) Players() []*SoccerPlayer {
+	return p.players
+}
+
+// opponent returns the team playing against team.
+func (p *Pitch) opponent(team string) string {
+	for other := range p.goals {
+		if other != team {
+			return other
+		}
+	}
+	return team
+}
+
+// closestToBall returns the player of team who should go for the ball.
+func (p *Pitch) closestToBall(team string) *SoccerPlayer {
+	ball := bodyCenter(p.Ball)
+	var closest *SoccerPlayer
+	best := math.Inf(1)
+	for _, player := range p.players {
+		if player.Team != team || !player.active || !roles[player.Role].chases {
+			continue
+		}
+		if d := distance(bodyCenter(player.Body), ball); d < best {
+			closest, best = player, d
+		}
+	}
+	return closest
+}
+
+// marked reports whether an opponent of player is close enough to stop a pass
+// to it.
+func (p *Pitch) marked(player *SoccerPlayer) bool {
+	position := bodyCenter(player.Body)
+	for _, other := range p.players {
+		if other.Team != player.Team && distance(bodyCenter(other.Body), position) < markRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// SoccerPlayer implements interfaces.AIAgent for a soccer player of some
+// role. It holds its spot in the formation, goes for the ball when it is the
+// closest of its team, marks opponents in its half as a defender, and passes
+// or shoots once the ball is at its feet. The goalkeeper guards the goal
+// line instead.
+type SoccerPlayer struct {
+	Body     *physics.RigidBody
+	Team     string
+	Role     string
+	pitch    *Pitch
+	home     interfaces.Vector2D
+	skill    skill
+	target   interfaces.Vector2D
+	decideIn float64
+	kickIn   float64
+	active   bool
+}
+
+// Initialize makes the player stand still until its first decision.
+func (s *SoccerPlayer) Initialize() error {
+	s.target = bodyCenter(s.Body)
+	return nil
+}
+
+// Update decides where to go every reaction time of the difficulty, runs
+// there and kicks the ball when it is within reach.
+func (s *SoccerPlayer) Update(deltaTime float64) error {
+	if !s.active || s.pitch.Ball == nil {
+		return nil
+	}
+	s.decideIn -= deltaTime
+	s.kickIn -= deltaTime
+	if s.decideIn <= 0 {
+		s.decide()
+		s.decideIn = s.skill.reaction
+	}
+	s.runTowards(s.target, deltaTime)
+	if s.kickIn <= 0 && s.canReachBall() {
+		s.kick()
+		s.kickIn = kickCooldown
+	}
+	return nil
+}
+
+// SetTarget sends the player to target, which is where its center goes,
+// until its next decision.
+func (s *SoccerPlayer) SetTarget(target interfaces.Vector2D) {
+	s.target = target
+}
+
+// GetTarget returns where the center of the player is going.
+func (s *SoccerPlayer) GetTarget() interfaces.Vector2D {
+	return s.target
+}
+
+func (s *SoccerPlayer) GetPosition() interfaces.Vector2D {
+	return s.Body.Position
+}
+
+func (s *SoccerPlayer) SetPosition(position interfaces.Vector2D) {
+	s.Body.Teleport(position)
+}
+
+// Home returns the center of the spot of the player in the formation.
+func (s *SoccerPlayer) Home() interfaces.Vector2D {
+	return s.home
+}
+
+// SetHome moves the spot of the player in the formation.
+func (s *SoccerPlayer) SetHome(home interfaces.Vector2D) {
+	s.home = home
+}
+
+// OnEnter puts the player in play.
+func (s *SoccerPlayer) OnEnter() error {
+	s.active = true
+	s.decideIn = 0
+	return nil
+}
+
+// OnExit takes the player out of play.
+func (s *SoccerPlayer) OnExit() error {
+	s.active = false
+	return nil
+}
+
+// decide picks where to go next.
+func (s *SoccerPlayer) decide() {
+	ball := bodyCenter(s.pitch.Ball)
+	switch {
+	case s.Role == RoleGoalkeeper:
+		s.target = s.guardGoal(ball)
+	case s.pitch.closestToBall(s.Team) == s:
+		s.target = ball
+	case s.Role == RoleDefender && s.inOwnHalf(ball):
+		if opponent := s.opponentToMark(); opponent != nil {
+			s.target = s.markPosition(opponent)
+		} else {
+			s.target = s.formationPosition(ball)
+		}
+	default:
+		s.target = s.formationPosition(ball)
+	}
+	s.target = s.clampToField(s.target)
+}
+
+// guardGoal keeps the goalkeeper between the ball and the goal, coming out
+// for the ball once it gets close.
+func (s *SoccerPlayer) guardGoal(ball interfaces.Vector2D) interfaces.Vector2D {
+	goal := s.pitch.goals[s.Team]
+	if distance(ball, rectCenter(goal)) < keeperRange {
+		return ball
+	}
+	return interfaces.Vector2D{
+		X: s.home.X,
+		Y: clamp(ball.Y, goal.Position.Y, goal.Position.Y+goal.Size.Y),
+	}
+}
+
+// formationPosition is the spot of the player in the formation, moved along
+// with the ball as much as the role does.
+func (s *SoccerPlayer) formationPosition(ball interfaces.Vector2D) interfaces.Vector2D {
+	shift := roles[s.Role].shift
+	center := rectCenter(s.pitch.Field)
+	return interfaces.Vector2D{
+		X: s.home.X + (ball.X-center.X)*shift,
+		Y: s.home.Y + (ball.Y-center.Y)*shift,
+	}
+}
+
+// opponentToMark returns the opponent in the half of the player closest to
+// its spot in the formation.
+func (s *SoccerPlayer) opponentToMark() *SoccerPlayer {
+	var nearest *SoccerPlayer
+	best := math.Inf(1)
+	for _, other := range s.pitch.players {
+		position := bodyCenter(other.Body)
+		if other.Team == s.Team || !s.inOwnHalf(position) {
+			continue
+		}
+		if d := distance(position, s.home); d < best {
+			nearest, best = other, d
+		}
+	}
+	return nearest
+}
+
+// markPosition stands the player between opponent and the goal it defends.
+func (s *SoccerPlayer) markPosition(opponent *SoccerPlayer) interfaces.Vector2D {
+	position := bodyCenter(opponent.Body)
+	towardGoal := direction(position, rectCenter(s.pitch.goals[s.Team]))
+	return interfaces.Vector2D{
+		X: position.X + towardGoal.X*markDistance,
+		Y: position.Y + towardGoal.Y*markDistance,
+	}
+}
+
+// kick shoots when the goal is in range of the role, passes to a free
+// teammate nearer the goal, clears the ball out of its own half as a
+// defender or goalkeeper, and otherwise dribbles forward.
+func (s *SoccerPlayer) kick() {
+	attack := rectCenter(s.pitch.goals[s.pitch.opponent(s.Team)])
+	position := bodyCenter(s.Body)
+	if shootRange := roles[s.Role].shootRange; shootRange > 0 && distance(position, attack) <= shootRange {
+		s.kickTowards(kick.KindShot, attack, shotPower)
+		return
+	}
+	if teammate := s.passTarget(attack); teammate != nil {
+		to := bodyCenter(teammate.Body)
+		s.kickTowards(kick.KindPass, to, math.Min(distance(position, to)*passSpeed, shotPower))
+		return
+	}
+	if (s.Role == RoleGoalkeeper || s.Role == RoleDefender) && s.inOwnHalf(position) {
+		s.kickTowards(kick.KindClear, attack, clearPower)
+		return
+	}
+	s.kickTowards(kick.KindDribble, attack, dribblePower)
+}
+
+// passTarget returns the free teammate closest to the goal attacked, if one
+// is closer to it than the player.
+func (s *SoccerPlayer) passTarget(attack interfaces.Vector2D) *SoccerPlayer {
+	var best *SoccerPlayer
+	bestDistance := distance(bodyCenter(s.Body), attack)
+	for _, teammate := range s.pitch.players {
+		if teammate == s || teammate.Team != s.Team || !teammate.active || s.pitch.marked(teammate) {
+			continue
+		}
+		if d := distance(bodyCenter(teammate.Body), attack); d < bestDistance {
+			best, bestDistance = teammate, d
+		}
+	}
+	return best
+}
+
+// kickTowards sends the ball to point at the given speed, off target by up
+// to the aim error of the difficulty.
+func (s *SoccerPlayer) kickTowards(kind string, point interfaces.Vector2D, power float64) {
+	ball := s.pitch.Ball
+	from := bodyCenter(ball)
+	aim := direction(from, point)
+	angle := math.Atan2(aim.Y, aim.X) + (s.pitch.rng.Float64()*2-1)*s.skill.aimError
+	ball.Velocity = kick.Velocity(interfaces.Vector2D{X: math.Cos(angle), Y: math.Sin(angle)}, power)
+	if s.pitch.eventManager != nil {
+		s.pitch.eventManager.Dispatch(kick.Event(s.Team, s.Body.Identifier, kind, from, ball.Velocity))
+	}
+}
+
+// runTowards moves the player toward target as far as its speed allows.
+func (s *SoccerPlayer) runTowards(target interfaces.Vector2D, deltaTime float64) {
+	position := bodyCenter(s.Body)
+	d := distance(position, target)
+	step := s.skill.speed * deltaTime
+	if d <= step {
+		step = d
+	}
+	if step == 0 {
+		return
+	}
+	move := direction(position, target)
+	s.Body.Position.X += move.X * step
+	s.Body.Position.Y += move.Y * step
+}
+
+// canReachBall reports whether the ball is within kicking reach.
+func (s *SoccerPlayer) canReachBall() bool {
+	body, ball := s.Body, s.pitch.Ball
+	return ball.Position.X <= body.Position.X+body.Size.X+reach &&
+		ball.Position.X+ball.Size.X >= body.Position.X-reach &&
+		ball.Position.Y <= body.Position.Y+body.Size.Y+reach &&
+		ball.Position.Y+ball.Size.Y >= body.Position.Y-reach
+}
+
+// inOwnHalf reports whether point is in the half of the goal the player
+// defends.
+func (s *SoccerPlayer) inOwnHalf(point interfaces.Vector2D) bool {
+	return math.Abs(point.X-rectCenter(s.pitch.goals[s.Team]).X) < s.pitch.Field.Size.X/2
+}
+
+// clampToField keeps point on the field.
+func (s *SoccerPlayer) clampToField(point interfaces.Vector2D) interfaces.Vector2D {
+	field := s.pitch.Field
+	return interfaces.Vector2D{
+		X: clamp(point.X, field.Position.X, field.Position.X+field.Size.X),
+		Y: clamp(point.Y, field.Position.Y, field.Position.Y+field.Size.Y),
+	}
+}
+
+func bodyCenter(body *physics.RigidBody) interfaces.Vector2D {
+	return interfaces.Vector2D{X: body.Position.X + body.Size.X/2, Y: body.Position.Y + body.Size.Y/2}
+}
+
+func rectCenter(rect interfaces.Rect) interfaces.Vector2D {
+	return interfaces.Vector2D{X: rect.Position.X + rect.Size.X/2, Y: rect.Position.Y + rect.Size.Y/2}
+}
+
+func distance(a, b interfaces.Vector2D) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// direction returns the unit vector from a to b, or zero when they meet.
+func direction(a, b interfaces.Vector2D) interfaces.Vector2D {
+	d := distance(a, b)
+	if d == 0 {
+		return interfaces.Vector2D{}
+	}
+	return interfaces.Vector2D{X: (b.X - a.X) / d, Y: (b.Y - a.Y) / d}
+}
+
+func clamp(value, low, high float64) float64 {
+	return math.Max(low, math.Min(high, value))
+}
@@ -0,0 +1,200 @@
+package behavior
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBehavior(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Behavior Suite")
+}
+
+// scripted is an agent whose leaves report what the test tells them to.
+type scripted struct {
+	statuses map[string]Status
+	ticks    map[string]int
+}
+
+// testRegistry has an action "do" reporting the status the agent holds for
+// its "name" parameter, and a condition "is" true when that status is a
+// success.
+func testRegistry() *Registry {
+	registry := NewRegistry()
+	registry.RegisterAction("do", func(ctx *TickContext) Status {
+		agent := ctx.Agent.(*scripted)
+		name := ctx.Text("name", "")
+		agent.ticks[name]++
+		return agent.statuses[name]
+	})
+	registry.RegisterCondition("is", func(ctx *TickContext) bool {
+		agent := ctx.Agent.(*scripted)
+		name := ctx.Text("name", "")
+		agent.ticks[name]++
+		return agent.statuses[name] == Success
+	})
+	return registry
+}
+
+// do describes an action leaf called name.
+func do(name string) Spec {
+	return Spec{Type: TypeAction, Name: name, Action: "do", Params: map[string]any{"name": name}}
+}
+
+// is describes a condition leaf called name.
+func is(name string) Spec {
+	return Spec{Type: TypeCondition, Name: name, Condition: "is", Params: map[string]any{"name": name}}
+}
+
+var _ = Describe("Tree", func() {
+	var agent *scripted
+
+	build := func(root Spec) *Tree {
+		node, err := Build(root, testRegistry())
+		Expect(err).NotTo(HaveOccurred())
+		return NewTree("test", node)
+	}
+
+	BeforeEach(func() {
+		agent = &scripted{statuses: map[string]Status{}, ticks: map[string]int{}}
+	})
+
+	It("should run a sequence until a child fails and resume a running child", func() {
+		tree := build(Spec{Type: TypeSequence, Name: "root", Children: []Spec{do("a"), do("b"), do("c")}})
+		agent.statuses["b"] = Running
+		Expect(tree.Tick(agent, 0.1)).To(Equal(Running))
+		Expect(tree.ActivePaths()).To(Equal([]string{"root/b"}))
+
+		agent.statuses["b"] = Failure
+		Expect(tree.Tick(agent, 0.1)).To(Equal(Failure))
+		Expect(agent.ticks).To(Equal(map[string]int{"a": 1, "b": 2}))
+	})
+
+	It("should run a selector until a child does not fail", func() {
+		tree := build(Spec{Type: TypeSelector, Children: []Spec{do("a"), do("b"), do("c")}})
+		agent.statuses = map[string]Status{"a": Failure, "b": Success}
+		Expect(tree.Tick(agent, 0.1)).To(Equal(Success))
+		Expect(agent.ticks).To(Equal(map[string]int{"a": 1, "b": 1}))
+		Expect(tree.Path()).To(Equal("selector/b"))
+	})
+
+	It("should let a higher priority child of a reactive selector take over", func() {
+		tree := build(Spec{Type: TypeReactiveSelector, Name: "root", Children: []Spec{
+			{Type: TypeReactiveSequence, Name: "attack", Children: []Spec{is("seen"), do("chase")}},
+			do("patrol"),
+		}})
+		agent.statuses = map[string]Status{"seen": Failure, "chase": Running, "patrol": Running}
+		Expect(tree.Tick(agent, 0.1)).To(Equal(Running))
+		Expect(tree.Path()).To(Equal("root/patrol"))
+
+		agent.statuses["seen"] = Success
+		Expect(tree.Tick(agent, 0.1)).To(Equal(Running))
+		Expect(tree.Path()).To(Equal("root/attack/chase"))
+
+		agent.statuses["seen"] = Failure
+		Expect(tree.Tick(agent, 0.1)).To(Equal(Running))
+		Expect(tree.Path()).To(Equal("root/patrol"))
+		Expect(agent.ticks["seen"]).To(Equal(3))
+	})
+
+	It("should finish a parallel by its policy", func() {
+		all := build(Spec{Type: TypeParallel, Children: []Spec{do("a"), do("b")}})
+		agent.statuses = map[string]Status{"a": Success, "b": Running}
+		Expect(all.Tick(agent, 0.1)).To(Equal(Running))
+		Expect(all.ActivePaths()).To(Equal([]string{"parallel/b"}))
+		agent.statuses["b"] = Success
+		Expect(all.Tick(agent, 0.1)).To(Equal(Success))
+		Expect(agent.ticks["a"]).To(Equal(1))
+
+		one := build(Spec{Type: TypeParallel, Policy: PolicyOne, Children: []Spec{do("a"), do("c")}})
+		agent.statuses["c"] = Running
+		Expect(one.Tick(agent, 0.1)).To(Equal(Success))
+	})
+
+	It("should invert, repeat and cool down its child", func() {
+		agent.statuses = map[string]Status{"a": Success}
+		inverted := build(Spec{Type: TypeInverter, Children: []Spec{do("a")}})
+		Expect(inverted.Tick(agent, 0.1)).To(Equal(Failure))
+
+		repeated := build(Spec{Type: TypeRepeat, Times: 3, Children: []Spec{do("a")}})
+		Expect(repeated.Tick(agent, 0.1)).To(Equal(Running))
+		Expect(repeated.Tick(agent, 0.1)).To(Equal(Running))
+		Expect(repeated.Tick(agent, 0.1)).To(Equal(Success))
+
+		agent.ticks["a"] = 0
+		cooled := build(Spec{Type: TypeCooldown, Seconds: 1, Children: []Spec{do("a")}})
+		Expect(cooled.Tick(agent, 0.4)).To(Equal(Success))
+		Expect(cooled.Tick(agent, 0.4)).To(Equal(Failure))
+		Expect(cooled.Path()).To(Equal("cooldown"))
+		Expect(cooled.Tick(agent, 0.4)).To(Equal(Failure))
+		Expect(cooled.Tick(agent, 0.4)).To(Equal(Success))
+		Expect(agent.ticks["a"]).To(Equal(2))
+	})
+
+	It("should wait for some seconds", func() {
+		tree := build(Spec{Type: TypeWait, Seconds: 1})
+		Expect(tree.Tick(agent, 0.6)).To(Equal(Running))
+		Expect(tree.Tick(agent, 0.6)).To(Equal(Success))
+	})
+
+	It("should share the blackboard between its leaves", func() {
+		registry := NewRegistry()
+		registry.RegisterCondition("spot", func(ctx *TickContext) bool {
+			ctx.Blackboard.Set("distance", 12.0)
+			return true
+		})
+		registry.RegisterAction("close", func(ctx *TickContext) Status {
+			if distance, ok := ctx.Blackboard.Float("distance"); ok && distance < ctx.Float("within", 0) {
+				return Success
+			}
+			return Failure
+		})
+		node, err := Build(Spec{Type: TypeSequence, Children: []Spec{
+			{Type: TypeCondition, Condition: "spot"},
+			{Type: TypeAction, Action: "close", Params: map[string]any{"within": 20.0}},
+		}}, registry)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(NewTree("test", node).Tick(nil, 0.1)).To(Equal(Success))
+	})
+})
+
+var _ = Describe("Library", func() {
+	It("should refuse trees it cannot build", func() {
+		registry := testRegistry()
+		for _, spec := range []Spec{
+			{Type: "dance"},
+			{Type: TypeSequence},
+			{Type: TypeInverter, Children: []Spec{do("a"), do("b")}},
+			{Type: TypeCooldown, Children: []Spec{do("a")}},
+			{Type: TypeParallel, Policy: "most", Children: []Spec{do("a")}},
+			{Type: TypeAction, Action: "fly"},
+			{Type: TypeCondition, Condition: "do"},
+			{Type: TypeAction, Action: "do", Children: []Spec{do("a")}},
+		} {
+			_, err := Build(spec, registry)
+			Expect(err).To(HaveOccurred(), "%+v", spec)
+		}
+	})
+
+	It("should read trees from JSON and build a fresh copy for each agent", func() {
+		data, err := json.Marshal(Library{Trees: []TreeSpec{{Name: "guard", Root: Spec{Type: TypeRepeat, Children: []Spec{do("a")}}}}})
+		Expect(err).NotTo(HaveOccurred())
+		library, err := Parse(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(library.Validate(testRegistry())).To(Succeed())
+
+		first, err := library.New("guard", testRegistry())
+		Expect(err).NotTo(HaveOccurred())
+		second, err := library.New("guard", testRegistry())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).NotTo(BeIdenticalTo(second))
+		_, err = library.New("thief", testRegistry())
+		Expect(err).To(HaveOccurred())
+
+		_, err = Parse([]byte(`{"trees": [{"name": "a"}, {"name": "a"}]}`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,64 @@
+package behavior
+
+import "github.com/joaorufino/gopher-game/internal/interfaces"
+
+// Blackboard is the memory an agent shares between the nodes of its tree,
+// e.g. a condition noting where it saw the player for an action to go there.
+type Blackboard struct {
+	values map[string]any
+}
+
+// NewBlackboard creates an empty blackboard.
+func NewBlackboard() *Blackboard {
+	return &Blackboard{values: map[string]any{}}
+}
+
+// Get returns the value stored under key.
+func (b *Blackboard) Get(key string) (any, bool) {
+	value, ok := b.values[key]
+	return value, ok
+}
+
+// Set stores value under key.
+func (b *Blackboard) Set(key string, value any) {
+	b.values[key] = value
+}
+
+// Delete forgets the value stored under key.
+func (b *Blackboard) Delete(key string) {
+	delete(b.values, key)
+}
+
+// Has reports whether a value is stored under key.
+func (b *Blackboard) Has(key string) bool {
+	_, ok := b.values[key]
+	return ok
+}
+
+// Float returns the number stored under key.
+func (b *Blackboard) Float(key string) (float64, bool) {
+	switch value := b.values[key].(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+// Bool returns the flag stored under key, false when there is none.
+func (b *Blackboard) Bool(key string) bool {
+	value, _ := b.values[key].(bool)
+	return value
+}
+
+// Vector returns the point stored under key.
+func (b *Blackboard) Vector(key string) (interfaces.Vector2D, bool) {
+	value, ok := b.values[key].(interfaces.Vector2D)
+	return value, ok
+}
+
+// Clear forgets every value.
+func (b *Blackboard) Clear() {
+	b.values = map[string]any{}
+}
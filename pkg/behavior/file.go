@@ -0,0 +1,140 @@
+package behavior
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/joaorufino/gopher-game/internal/utils"
+)
+
+// Node types, as named in tree files.
+const (
+	TypeSequence         = "sequence"
+	TypeSelector         = "selector"
+	TypeReactiveSequence = "reactiveSequence"
+	TypeReactiveSelector = "reactiveSelector"
+	TypeParallel         = "parallel"
+	TypeInverter         = "inverter"
+	TypeRepeat           = "repeat"
+	TypeCooldown         = "cooldown"
+	TypeWait             = "wait"
+	TypeAction           = "action"
+	TypeCondition        = "condition"
+)
+
+// Policies of a parallel node.
+const (
+	PolicyAll = "all" // Succeed once every child succeeds
+	PolicyOne = "one" // Succeed as soon as one child succeeds
+)
+
+// Spec describes a node of a tree and, through its children, the nodes
+// below it.
+type Spec struct {
+	Type      string         `json:"type"`
+	Name      string         `json:"name,omitempty"`
+	Children  []Spec         `json:"children,omitempty"`
+	Action    string         `json:"action,omitempty"`    // The registered action of an action node
+	Condition string         `json:"condition,omitempty"` // The registered condition of a condition node
+	Params    map[string]any `json:"params,omitempty"`    // Passed to the action or condition
+	Policy    string         `json:"policy,omitempty"`    // When a parallel node succeeds
+	Times     int            `json:"times,omitempty"`     // How often a repeat node runs, forever when zero
+	Seconds   float64        `json:"seconds,omitempty"`   // How long a cooldown or wait node waits
+}
+
+// label returns the name of the node in the active path: its own name, or
+// else its action or condition, or else its type.
+func (s Spec) label() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.Action != "":
+		return s.Action
+	case s.Condition != "":
+		return s.Condition
+	}
+	return s.Type
+}
+
+// TreeSpec is a named tree.
+type TreeSpec struct {
+	Name string `json:"name"`
+	Root Spec   `json:"root"`
+}
+
+// Library is the trees agents pick from by name.
+type Library struct {
+	Trees []TreeSpec `json:"trees"`
+}
+
+// Parse reads a library of trees from JSON.
+func Parse(data []byte) (*Library, error) {
+	var library Library
+	if err := json.Unmarshal(data, &library); err != nil {
+		return nil, fmt.Errorf("failed to parse behavior trees: %w", err)
+	}
+	names := make(map[string]bool)
+	for _, tree := range library.Trees {
+		if tree.Name == "" {
+			return nil, errors.New("behavior tree without a name")
+		}
+		if names[tree.Name] {
+			return nil, fmt.Errorf("behavior tree %s is defined twice", tree.Name)
+		}
+		names[tree.Name] = true
+	}
+	return &library, nil
+}
+
+// Load reads a library of trees from a JSON file.
+func Load(path string) (*Library, error) {
+	var library *Library
+	err := utils.LoadData(path, func(data []byte) error {
+		var err error
+		library, err = Parse(data)
+		return err
+	})
+	if err == nil && library == nil {
+		err = errors.New("no behavior trees")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load behavior trees %s: %w", path, err)
+	}
+	return library, nil
+}
+
+// Find returns the tree with the given name.
+func (l *Library) Find(name string) (TreeSpec, bool) {
+	for _, tree := range l.Trees {
+		if tree.Name == name {
+			return tree, true
+		}
+	}
+	return TreeSpec{}, false
+}
+
+// New builds a fresh copy of the named tree for an agent.
+func (l *Library) New(name string, registry *Registry) (*Tree, error) {
+	spec, ok := l.Find(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown behavior tree %q", name)
+	}
+	root, err := Build(spec.Root, registry)
+	if err != nil {
+		return nil, fmt.Errorf("behavior tree %s: %w", name, err)
+	}
+	return NewTree(name, root), nil
+}
+
+// Validate builds every tree once, to report trees using unknown nodes,
+// actions or conditions when they are loaded rather than when an agent
+// first needs them.
+func (l *Library) Validate(registry *Registry) error {
+	for _, tree := range l.Trees {
+		if _, err := l.New(tree.Name, registry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
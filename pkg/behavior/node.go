@@ -0,0 +1,304 @@
+package behavior
+
+import "strings"
+
+// Status is what a node reports after a tick.
+type Status int
+
+const (
+	// Success means the node did what it does.
+	Success Status = iota
+	// Failure means the node could not do it.
+	Failure
+	// Running means the node needs more ticks to finish.
+	Running
+)
+
+func (s Status) String() string {
+	switch s {
+	case Success:
+		return "success"
+	case Failure:
+		return "failure"
+	case Running:
+		return "running"
+	}
+	return "unknown"
+}
+
+// TickContext is what the nodes of a tree see while it ticks.
+type TickContext struct {
+	Agent      any         // The agent the tree runs for
+	Blackboard *Blackboard // The memory of the agent
+	DeltaTime  float64     // Seconds since the last tick
+	Time       float64     // Seconds the tree has been ticking
+	// Params are the parameters of the action or condition being ticked, as
+	// set in its tree file.
+	Params map[string]any
+
+	path   []string
+	active []string
+	last   string
+}
+
+// Float returns the number parameter called key, or fallback when it is not
+// set.
+func (c *TickContext) Float(key string, fallback float64) float64 {
+	if value, ok := c.Params[key].(float64); ok {
+		return value
+	}
+	return fallback
+}
+
+// Text returns the string parameter called key, or fallback when it is not
+// set.
+func (c *TickContext) Text(key, fallback string) string {
+	if value, ok := c.Params[key].(string); ok {
+		return value
+	}
+	return fallback
+}
+
+// visit notes that the tick stopped at the current node: the nodes still
+// running make up the active path of the tree.
+func (c *TickContext) visit(status Status) {
+	c.last = strings.Join(c.path, "/")
+	if status == Running {
+		c.active = append(c.active, c.last)
+	}
+}
+
+// Node is a node of a behavior tree.
+type Node interface {
+	// Name returns how the node shows in the active path.
+	Name() string
+	// Tick runs the node for a frame.
+	Tick(ctx *TickContext) Status
+	// Reset makes the node start over on its next tick, e.g. after its parent
+	// moved on to another child while it was running.
+	Reset()
+}
+
+// tick runs a child node, keeping track of the path to it.
+func tick(node Node, ctx *TickContext) Status {
+	ctx.path = append(ctx.path, node.Name())
+	status := node.Tick(ctx)
+	ctx.path = ctx.path[:len(ctx.path)-1]
+	return status
+}
+
+// composite ticks its children in order until one reports stop: a sequence
+// stops at the first child that fails and a selector at the first one that
+// does not. It resumes from a running child on the next tick, unless it is
+// reactive and starts over from the first child every tick, so higher
+// priority children can take over.
+type composite struct {
+	name     string
+	children []Node
+	next     Status // What a child reports for the composite to go on
+	reactive bool
+	current  int
+}
+
+func (c *composite) Name() string { return c.name }
+
+func (c *composite) Tick(ctx *TickContext) Status {
+	start := c.current
+	if c.reactive {
+		start = 0
+	}
+	for i := start; i < len(c.children); i++ {
+		status := tick(c.children[i], ctx)
+		if status == c.next {
+			continue
+		}
+		if status == Running {
+			if c.current != i {
+				c.children[c.current].Reset()
+			}
+			c.current = i
+			return Running
+		}
+		c.Reset()
+		return status
+	}
+	c.Reset()
+	return c.next
+}
+
+func (c *composite) Reset() {
+	for _, child := range c.children {
+		child.Reset()
+	}
+	c.current = 0
+}
+
+// parallel ticks every child each tick until enough of them finish: it
+// succeeds once all of them succeed and fails as soon as one fails, or with
+// requireOne, succeeds as soon as one succeeds and fails once all fail.
+type parallel struct {
+	name       string
+	children   []Node
+	requireOne bool
+	results    []Status
+}
+
+func (p *parallel) Name() string { return p.name }
+
+func (p *parallel) Tick(ctx *TickContext) Status {
+	if p.results == nil {
+		p.Reset()
+	}
+	successes, failures := 0, 0
+	for i, child := range p.children {
+		if p.results[i] == Running {
+			p.results[i] = tick(child, ctx)
+		}
+		switch p.results[i] {
+		case Success:
+			successes++
+		case Failure:
+			failures++
+		}
+	}
+	status := Running
+	switch {
+	case p.requireOne && successes > 0, !p.requireOne && successes == len(p.children):
+		status = Success
+	case p.requireOne && failures == len(p.children), !p.requireOne && failures > 0:
+		status = Failure
+	}
+	if status != Running {
+		p.Reset()
+	}
+	return status
+}
+
+func (p *parallel) Reset() {
+	p.results = make([]Status, len(p.children))
+	for i, child := range p.children {
+		child.Reset()
+		p.results[i] = Running
+	}
+}
+
+// inverter turns the success of its child into a failure and the other way
+// around.
+type inverter struct {
+	name  string
+	child Node
+}
+
+func (n *inverter) Name() string { return n.name }
+
+func (n *inverter) Tick(ctx *TickContext) Status {
+	switch status := tick(n.child, ctx); status {
+	case Success:
+		return Failure
+	case Failure:
+		return Success
+	default:
+		return status
+	}
+}
+
+func (n *inverter) Reset() { n.child.Reset() }
+
+// repeat runs its child again each time it succeeds, up to times (forever
+// when zero), and fails as soon as the child fails. It runs the child once
+// per tick at most.
+type repeat struct {
+	name  string
+	child Node
+	times int
+	count int
+}
+
+func (r *repeat) Name() string { return r.name }
+
+func (r *repeat) Tick(ctx *TickContext) Status {
+	switch tick(r.child, ctx) {
+	case Running:
+		return Running
+	case Failure:
+		r.Reset()
+		return Failure
+	}
+	r.count++
+	if r.times > 0 && r.count >= r.times {
+		r.Reset()
+		return Success
+	}
+	return Running
+}
+
+func (r *repeat) Reset() {
+	r.child.Reset()
+	r.count = 0
+}
+
+// cooldown fails without ticking its child for some seconds after the child
+// finished. The wait outlasts resets.
+type cooldown struct {
+	name    string
+	child   Node
+	seconds float64
+	readyAt float64
+}
+
+func (c *cooldown) Name() string { return c.name }
+
+func (c *cooldown) Tick(ctx *TickContext) Status {
+	if ctx.Time < c.readyAt {
+		ctx.visit(Failure)
+		return Failure
+	}
+	status := tick(c.child, ctx)
+	if status != Running {
+		c.readyAt = ctx.Time + c.seconds
+	}
+	return status
+}
+
+func (c *cooldown) Reset() { c.child.Reset() }
+
+// wait runs for some seconds, then succeeds.
+type wait struct {
+	name    string
+	seconds float64
+	elapsed float64
+}
+
+func (w *wait) Name() string { return w.name }
+
+func (w *wait) Tick(ctx *TickContext) Status {
+	w.elapsed += ctx.DeltaTime
+	status := Running
+	if w.elapsed >= w.seconds {
+		status = Success
+		w.elapsed = 0
+	}
+	ctx.visit(status)
+	return status
+}
+
+func (w *wait) Reset() { w.elapsed = 0 }
+
+// leaf runs a registered action or condition with its parameters.
+type leaf struct {
+	name   string
+	params map[string]any
+	run    Action
+}
+
+func (l *leaf) Name() string { return l.name }
+
+func (l *leaf) Tick(ctx *TickContext) Status {
+	ctx.Params = l.params
+	status := l.run(ctx)
+	ctx.Params = nil
+	ctx.visit(status)
+	return status
+}
+
+func (l *leaf) Reset() {}
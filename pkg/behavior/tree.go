@@ -0,0 +1,172 @@
+package behavior
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Action is a leaf of a tree written in Go.
+type Action func(ctx *TickContext) Status
+
+// Condition is a leaf of a tree that checks something without taking time:
+// it succeeds when true and fails otherwise.
+type Condition func(ctx *TickContext) bool
+
+// Registry holds the actions and conditions trees can use by name.
+type Registry struct {
+	actions    map[string]Action
+	conditions map[string]Condition
+}
+
+// NewRegistry creates a registry without actions or conditions.
+func NewRegistry() *Registry {
+	return &Registry{actions: map[string]Action{}, conditions: map[string]Condition{}}
+}
+
+// RegisterAction makes an action available to trees by name.
+func (r *Registry) RegisterAction(name string, action Action) {
+	r.actions[name] = action
+}
+
+// RegisterCondition makes a condition available to trees by name.
+func (r *Registry) RegisterCondition(name string, condition Condition) {
+	r.conditions[name] = condition
+}
+
+// Tree is a behavior tree run for one agent: every agent needs a tree of its
+// own, as the nodes remember where they are.
+type Tree struct {
+	Name       string
+	Blackboard *Blackboard
+	// Debug logs the active path after every tick.
+	Debug bool
+
+	root   Node
+	time   float64
+	active []string
+}
+
+// NewTree creates a tree with root as its top node.
+func NewTree(name string, root Node) *Tree {
+	return &Tree{Name: name, Blackboard: NewBlackboard(), root: root}
+}
+
+// Tick runs the tree for a frame on behalf of agent.
+func (t *Tree) Tick(agent any, deltaTime float64) Status {
+	t.time += deltaTime
+	ctx := &TickContext{Agent: agent, Blackboard: t.Blackboard, DeltaTime: deltaTime, Time: t.time}
+	status := tick(t.root, ctx)
+	t.active = ctx.active
+	if len(t.active) == 0 && ctx.last != "" {
+		t.active = []string{ctx.last}
+	}
+	if t.Debug {
+		log.Printf("behavior tree %s: %s (%s)", t.Name, t.Path(), status)
+	}
+	return status
+}
+
+// ActivePaths returns the paths, from the top node down, to the nodes left
+// running by the last tick, e.g. "root/attack/chase", or to the last node
+// ticked when none was left running.
+func (t *Tree) ActivePaths() []string {
+	return t.active
+}
+
+// Path returns the active paths as one line.
+func (t *Tree) Path() string {
+	return strings.Join(t.active, ", ")
+}
+
+// Reset makes the tree start over from its top node, keeping the
+// blackboard.
+func (t *Tree) Reset() {
+	t.root.Reset()
+	t.active = nil
+}
+
+// Build creates the nodes of spec, using the actions and conditions of
+// registry for its leaves.
+func Build(spec Spec, registry *Registry) (Node, error) {
+	children := make([]Node, len(spec.Children))
+	for i, childSpec := range spec.Children {
+		child, err := Build(childSpec, registry)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = child
+	}
+	name := spec.label()
+	if leaf := spec.Type == TypeWait || spec.Type == TypeAction || spec.Type == TypeCondition; leaf && len(children) > 0 {
+		return nil, fmt.Errorf("%s %s cannot have children", spec.Type, name)
+	}
+
+	switch spec.Type {
+	case TypeSequence, TypeSelector, TypeReactiveSequence, TypeReactiveSelector:
+		if len(children) == 0 {
+			return nil, fmt.Errorf("%s %s has no children", spec.Type, name)
+		}
+		next := Success
+		if spec.Type == TypeSelector || spec.Type == TypeReactiveSelector {
+			next = Failure
+		}
+		reactive := spec.Type == TypeReactiveSequence || spec.Type == TypeReactiveSelector
+		return &composite{name: name, children: children, next: next, reactive: reactive}, nil
+	case TypeParallel:
+		if len(children) == 0 {
+			return nil, fmt.Errorf("parallel %s has no children", name)
+		}
+		var requireOne bool
+		switch spec.Policy {
+		case "", PolicyAll:
+		case PolicyOne:
+			requireOne = true
+		default:
+			return nil, fmt.Errorf("parallel %s has unknown policy %q", name, spec.Policy)
+		}
+		return &parallel{name: name, children: children, requireOne: requireOne}, nil
+	case TypeInverter, TypeRepeat, TypeCooldown:
+		if len(children) != 1 {
+			return nil, fmt.Errorf("%s %s needs one child, not %d", spec.Type, name, len(children))
+		}
+		switch spec.Type {
+		case TypeInverter:
+			return &inverter{name: name, child: children[0]}, nil
+		case TypeRepeat:
+			if spec.Times < 0 {
+				return nil, fmt.Errorf("repeat %s has negative times", name)
+			}
+			return &repeat{name: name, child: children[0], times: spec.Times}, nil
+		default:
+			if spec.Seconds <= 0 {
+				return nil, fmt.Errorf("cooldown %s needs seconds", name)
+			}
+			return &cooldown{name: name, child: children[0], seconds: spec.Seconds}, nil
+		}
+	case TypeWait:
+		if spec.Seconds <= 0 {
+			return nil, fmt.Errorf("wait %s needs seconds", name)
+		}
+		return &wait{name: name, seconds: spec.Seconds}, nil
+	case TypeAction:
+		action, ok := registry.actions[spec.Action]
+		if !ok {
+			return nil, fmt.Errorf("unknown action %q", spec.Action)
+		}
+		return &leaf{name: name, params: spec.Params, run: action}, nil
+	case TypeCondition:
+		condition, ok := registry.conditions[spec.Condition]
+		if !ok {
+			return nil, fmt.Errorf("unknown condition %q", spec.Condition)
+		}
+		run := func(ctx *TickContext) Status {
+			if condition(ctx) {
+				return Success
+			}
+			return Failure
+		}
+		return &leaf{name: name, params: spec.Params, run: run}, nil
+	}
+	return nil, fmt.Errorf("unknown node type %q", spec.Type)
+}
@@ -56,6 +56,14 @@ func (ci *ChapterIntro) createLetters() {
 	}
 }
 
+// Close removes the letters from the physics engine.
+func (ci *ChapterIntro) Close() {
+	for _, letter := range ci.letters {
+		ci.physicsEngine.RemoveRigidBody(letter.RigidBody)
+	}
+	ci.letters = nil
+}
+
 func (ci *ChapterIntro) Update(deltaTime float64) {
 	for _, letter := range ci.letters {
 		letter.RigidBody.Update(deltaTime)
@@ -0,0 +1,200 @@
+// Package checkpoint keeps track of where the player respawns and brings it
+// back there after falling out of the level.
+package checkpoint
+
+import (
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// Point is an area that becomes the respawn point once the player touches it.
+type Point struct {
+	Name string
+	Area interfaces.Rect
+}
+
+// SaveFunc captures some state when a checkpoint is reached and returns the
+// function that puts it back on respawn.
+type SaveFunc func() (restore func())
+
+// RespawnFunc puts the player back at the given area, standing on its bottom.
+type RespawnFunc func(area interfaces.Rect)
+
+// Config holds the checkpoint settings.
+type Config struct {
+	// TransitionDuration is how long the fade out and back in takes, in seconds.
+	TransitionDuration float64
+}
+
+// CauseFall is the cause of death of a player that fell out of the level.
+const CauseFall = "fall"
+
+type state int
+
+const (
+	statePlaying state = iota
+	stateFadingOut
+	stateFadingIn
+)
+
+// Manager activates checkpoints, detects the player falling out of the level
+// and runs the respawn transition.
+type Manager struct {
+	config       Config
+	eventManager interfaces.EventManager
+	respawn      RespawnFunc
+
+	points   []Point
+	start    interfaces.Rect
+	fallY    float64
+	active   int
+	saves    []SaveFunc
+	restores []func()
+	onDeath  func(cause string)
+
+	state state
+	timer float64
+}
+
+// NewManager creates a manager calling respawn to put the player back.
+func NewManager(config Config, eventManager interfaces.EventManager, respawn RespawnFunc) *Manager {
+	if config.TransitionDuration <= 0 {
+		config.TransitionDuration = 0.8
+	}
+	return &Manager{
+		config:       config,
+		eventManager: eventManager,
+		respawn:      respawn,
+		active:       -1,
+	}
+}
+
+// Track registers state to restore on respawn, as it was when the last
+// checkpoint was reached.
+func (m *Manager) Track(save SaveFunc) {
+	m.saves = append(m.saves, save)
+	m.restores = append(m.restores, save())
+}
+
+// OnDeath registers a function called whenever the player dies, before the
+// respawn transition starts.
+func (m *Manager) OnDeath(handler func(cause string)) {
+	m.onDeath = handler
+}
+
+// Reset starts over with the checkpoints of a new level. The player respawns
+// at start until a checkpoint is reached and falls out below fallY.
+func (m *Manager) Reset(points []Point, start interfaces.Rect, fallY float64) {
+	m.points = points
+	m.start = start
+	m.fallY = fallY
+	m.active = -1
+	m.state = statePlaying
+	m.timer = 0
+	m.save()
+}
+
+// save captures the tracked state for the next respawn.
+func (m *Manager) save() {
+	m.restores = m.restores[:0]
+	for _, save := range m.saves {
+		m.restores = append(m.restores, save())
+	}
+}
+
+// Active returns the checkpoint the player respawns at, if any.
+func (m *Manager) Active() (Point, bool) {
+	if m.active < 0 {
+		return Point{}, false
+	}
+	return m.points[m.active], true
+}
+
+// Forget makes the player respawn at the start again, as if no checkpoint had
+// been reached.
+func (m *Manager) Forget() {
+	m.active = -1
+}
+
+// Respawning reports whether a respawn transition is running; the game is
+// paused meanwhile.
+func (m *Manager) Respawning() bool {
+	return m.state != statePlaying
+}
+
+// Kill starts the respawn transition, e.g. when the player runs out of health.
+func (m *Manager) Kill(cause string) {
+	if m.state != statePlaying {
+		return
+	}
+	m.state = stateFadingOut
+	m.timer = 0
+	m.dispatch(interfaces.EventPlayerDied, map[string]interface{}{"cause": cause})
+	if m.onDeath != nil {
+		m.onDeath(cause)
+	}
+}
+
+// Update activates the checkpoints the player touches and respawns it once it
+// falls below the level.
+func (m *Manager) Update(deltaTime float64, player interfaces.Rect) {
+	half := m.config.TransitionDuration / 2
+	switch m.state {
+	case statePlaying:
+		for i, point := range m.points {
+			if i != m.active && overlaps(point.Area, player) {
+				m.active = i
+				m.save()
+				m.dispatch(interfaces.EventCheckpointActivated, map[string]interface{}{
+					"checkpoint": point.Name,
+					"position":   point.Area.Position,
+				})
+			}
+		}
+		if player.Position.Y >= m.fallY {
+			m.Kill(CauseFall)
+		}
+	case stateFadingOut:
+		m.timer += deltaTime
+		if m.timer >= half {
+			area := m.start
+			if point, ok := m.Active(); ok {
+				area = point.Area
+			}
+			m.respawn(area)
+			for _, restore := range m.restores {
+				restore()
+			}
+			m.state = stateFadingIn
+			m.dispatch(interfaces.EventPlayerRespawned, map[string]interface{}{"position": area.Position})
+		}
+	case stateFadingIn:
+		m.timer += deltaTime
+		if m.timer >= m.config.TransitionDuration {
+			m.state = statePlaying
+		}
+	}
+}
+
+// fade returns how dark the screen is during the transition, from 0 to 1.
+func (m *Manager) fade() float64 {
+	half := m.config.TransitionDuration / 2
+	switch m.state {
+	case stateFadingOut:
+		return min(m.timer/half, 1)
+	case stateFadingIn:
+		return max(1-(m.timer-half)/half, 0)
+	}
+	return 0
+}
+
+func (m *Manager) dispatch(eventType interfaces.EventType, payload map[string]interface{}) {
+	if m.eventManager == nil {
+		return
+	}
+	m.eventManager.Dispatch(interfaces.Event{Type: eventType, Priority: 1, Payload: payload})
+}
+
+func overlaps(a, b interfaces.Rect) bool {
+	return a.Position.X < b.Position.X+b.Size.X && b.Position.X < a.Position.X+a.Size.X &&
+		a.Position.Y < b.Position.Y+b.Size.Y && b.Position.Y < a.Position.Y+a.Size.Y
+}
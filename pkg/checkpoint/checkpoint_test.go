@@ -0,0 +1,98 @@
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckpoint(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Checkpoint Suite")
+}
+
+type recordedEvents struct {
+	events []interfaces.Event
+}
+
+func (r *recordedEvents) RegisterHandler(interfaces.EventType, interfaces.EventHandler) {}
+func (r *recordedEvents) Dispatch(event interfaces.Event)                               { r.events = append(r.events, event) }
+func (r *recordedEvents) Wait()                                                         {}
+
+func rect(x, y, w, h float64) interfaces.Rect {
+	return interfaces.Rect{Position: interfaces.Vector2D{X: x, Y: y}, Size: interfaces.Vector2D{X: w, Y: h}}
+}
+
+var _ = Describe("Manager", func() {
+	var (
+		events    *recordedEvents
+		manager   *Manager
+		respawned []interfaces.Rect
+		crate     float64
+	)
+
+	// runTransition updates the manager until the respawn transition is over.
+	runTransition := func() {
+		for i := 0; i < 100 && manager.Respawning(); i++ {
+			manager.Update(0.1, rect(0, 0, 10, 10))
+		}
+		Expect(manager.Respawning()).To(BeFalse())
+	}
+
+	BeforeEach(func() {
+		events = &recordedEvents{}
+		respawned = nil
+		crate = 100
+		manager = NewManager(Config{TransitionDuration: 0.4}, events, func(area interfaces.Rect) {
+			respawned = append(respawned, area)
+		})
+		manager.Track(func() func() {
+			saved := crate
+			return func() { crate = saved }
+		})
+		manager.Reset([]Point{{Name: "cave", Area: rect(500, 200, 32, 64)}}, rect(0, 0, 0, 0), 1000)
+	})
+
+	It("should respawn at the start before any checkpoint is reached", func() {
+		manager.Update(0.1, rect(100, 1000, 10, 10))
+		Expect(manager.Respawning()).To(BeTrue())
+		runTransition()
+
+		Expect(respawned).To(Equal([]interfaces.Rect{rect(0, 0, 0, 0)}))
+		Expect(events.events[0].Type).To(Equal(interfaces.EventPlayerDied))
+		Expect(events.events[1].Type).To(Equal(interfaces.EventPlayerRespawned))
+	})
+
+	It("should activate a checkpoint once and respawn there", func() {
+		manager.Update(0.1, rect(510, 220, 10, 10))
+		manager.Update(0.1, rect(512, 220, 10, 10))
+		point, ok := manager.Active()
+		Expect(ok).To(BeTrue())
+		Expect(point.Name).To(Equal("cave"))
+		Expect(events.events).To(HaveLen(1))
+		Expect(events.events[0].Type).To(Equal(interfaces.EventCheckpointActivated))
+
+		manager.Kill("spikes")
+		runTransition()
+		Expect(respawned).To(Equal([]interfaces.Rect{rect(500, 200, 32, 64)}))
+	})
+
+	It("should restore tracked state as it was at the checkpoint", func() {
+		crate = 150
+		manager.Update(0.1, rect(510, 220, 10, 10))
+		crate = 300
+
+		manager.Update(0.1, rect(510, 1200, 10, 10))
+		runTransition()
+		Expect(crate).To(Equal(150.0))
+	})
+
+	It("should forget the active checkpoint on reset", func() {
+		manager.Update(0.1, rect(510, 220, 10, 10))
+		manager.Reset(nil, rect(0, 0, 0, 0), 1000)
+		_, ok := manager.Active()
+		Expect(ok).To(BeFalse())
+	})
+})
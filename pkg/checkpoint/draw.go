@@ -0,0 +1,43 @@
+package checkpoint
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+const (
+	flagWidth  = 16
+	flagHeight = 10
+)
+
+// Draw draws a flag on every checkpoint; the active one is raised in green.
+func (m *Manager) Draw(screen *ebiten.Image, camera interfaces.Camera) {
+	offsetX, offsetY := camera.GetOffset()
+	for i, point := range m.points {
+		poleX := float32(point.Area.Position.X + point.Area.Size.X/2 - offsetX)
+		bottom := float32(point.Area.Position.Y + point.Area.Size.Y - offsetY)
+		top := float32(point.Area.Position.Y - offsetY)
+		vector.StrokeLine(screen, poleX, bottom, poleX, top, 2, color.RGBA{200, 200, 200, 255}, true)
+
+		flagColor := color.RGBA{150, 150, 150, 255}
+		flagY := bottom - flagHeight - 4
+		if i == m.active {
+			flagColor = color.RGBA{50, 205, 50, 255}
+			flagY = top
+		}
+		vector.DrawFilledRect(screen, poleX, flagY, flagWidth, flagHeight, flagColor, true)
+	}
+}
+
+// DrawTransition darkens the screen while the player respawns.
+func (m *Manager) DrawTransition(screen *ebiten.Image) {
+	fade := m.fade()
+	if fade <= 0 {
+		return
+	}
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), color.RGBA{0, 0, 0, uint8(255 * fade)}, false)
+}
@@ -0,0 +1,78 @@
+package editor
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+const (
+	minZoom = 0.25
+	maxZoom = 4
+)
+
+// Camera is a free camera that can be panned and zoomed. The map is drawn
+// through it onto an unscaled canvas, which is then scaled by the zoom.
+type Camera struct {
+	x, y       float64
+	zoom       float64
+	viewWidth  float64
+	viewHeight float64
+}
+
+// NewCamera creates a camera at the origin without zoom.
+func NewCamera() *Camera {
+	return &Camera{zoom: 1}
+}
+
+// Follow centers the camera on the target.
+func (c *Camera) Follow(targetX, targetY float64) {
+	c.x = targetX - c.viewWidth/2
+	c.y = targetY - c.viewHeight/2
+}
+
+// Apply applies the camera's translation to the given DrawImageOptions.
+func (c *Camera) Apply(opts *ebiten.DrawImageOptions) {
+	opts.GeoM.Translate(-c.x, -c.y)
+}
+
+// GetOffset returns the world position of the top left corner of the view.
+func (c *Camera) GetOffset() (float64, float64) {
+	return c.x, c.y
+}
+
+// Zoom returns the current zoom factor.
+func (c *Camera) Zoom() float64 {
+	return c.zoom
+}
+
+// Pan moves the camera by the given screen distance.
+func (c *Camera) Pan(dx, dy float64) {
+	c.x += dx / c.zoom
+	c.y += dy / c.zoom
+}
+
+// ZoomAt changes the zoom by factor while keeping the world point under the
+// given screen position in place.
+func (c *Camera) ZoomAt(factor, screenX, screenY float64) {
+	anchor := c.ScreenToWorld(screenX, screenY)
+	c.zoom *= factor
+	if c.zoom < minZoom {
+		c.zoom = minZoom
+	}
+	if c.zoom > maxZoom {
+		c.zoom = maxZoom
+	}
+	c.x = anchor.X - screenX/c.zoom
+	c.y = anchor.Y - screenY/c.zoom
+}
+
+// ScreenToWorld converts a screen position into world coordinates.
+func (c *Camera) ScreenToWorld(screenX, screenY float64) interfaces.Vector2D {
+	return interfaces.Vector2D{X: c.x + screenX/c.zoom, Y: c.y + screenY/c.zoom}
+}
+
+// setView records the size of the area the camera shows, in world units.
+func (c *Camera) setView(screenWidth, screenHeight int) {
+	c.viewWidth = float64(screenWidth) / c.zoom
+	c.viewHeight = float64(screenHeight) / c.zoom
+}
@@ -0,0 +1,846 @@
+// Package editor implements the in-game level editor.
+package editor
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/internal/utils"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// Tool is what a left click does in the editor.
+type Tool int
+
+const (
+	ToolSelect Tool = iota
+	ToolPlatform
+	ToolObstacle
+	ToolItem
+	ToolTrigger
+	ToolCheckpoint
+	ToolHazard
+)
+
+var toolNames = map[Tool]string{
+	ToolSelect:     "select",
+	ToolPlatform:   "platform",
+	ToolObstacle:   "obstacle",
+	ToolItem:       "item",
+	ToolTrigger:    "trigger",
+	ToolCheckpoint: "checkpoint",
+	ToolHazard:     "hazard",
+}
+
+var movementTypes = []string{"", "horizontal", "vertical"}
+
+var platformKinds = []gameMap.PlatformKind{
+	gameMap.PlatformStatic,
+	gameMap.PlatformMoving,
+	gameMap.PlatformCrumbling,
+	gameMap.PlatformOneWay,
+}
+
+var gridSizes = []float64{8, 16, 32, 64}
+
+const (
+	handleSize = 8
+	panSpeed   = 10
+	spawnSize  = 16
+	// defaultHazardDamage is the damage of a newly placed hazard.
+	defaultHazardDamage = 10
+)
+
+// Config holds the editor settings.
+type Config struct {
+	// DefaultPath is where a level that was not loaded from a file is saved.
+	DefaultPath string
+	// GridSize is the initial grid spacing in world units.
+	GridSize float64
+	// ObstacleTypes are the types N cycles through for a selected obstacle.
+	ObstacleTypes []string
+}
+
+type kind int
+
+const (
+	kindPlatform kind = iota
+	kindObstacle
+	kindItem
+	kindTrigger
+	kindCheckpoint
+	kindHazard
+	kindSpawn
+)
+
+// selection identifies an object of the edited level.
+type selection struct {
+	kind  kind
+	index int
+}
+
+type dragMode int
+
+const (
+	dragNone dragMode = iota
+	dragMove
+	dragResize
+	dragCreate
+	dragPan
+)
+
+type dragState struct {
+	mode   dragMode
+	start  interfaces.Vector2D
+	offset interfaces.Vector2D
+	screen interfaces.Vector2D
+}
+
+// Editor lets a designer change the loaded level with the mouse and save it.
+type Editor struct {
+	config      Config
+	gameMap     *gameMap.Map
+	itemManager interfaces.ItemManager
+	camera      *Camera
+	canvas      *ebiten.Image
+
+	active   bool
+	level    *gameMap.LevelData
+	path     string
+	applied  bool
+	tool     Tool
+	gridSize float64
+	snap     bool
+	selected *selection
+	drag     dragState
+	draft    *interfaces.Rect
+	status   string
+}
+
+// NewEditor creates an editor for the given map.
+func NewEditor(config Config, gameMapInstance *gameMap.Map, itemManager interfaces.ItemManager) *Editor {
+	if config.DefaultPath == "" {
+		config.DefaultPath = "levels/untitled.json"
+	}
+	if config.GridSize == 0 {
+		config.GridSize = 16
+	}
+	if len(config.ObstacleTypes) == 0 {
+		config.ObstacleTypes = []string{"docker_container", "docker_image"}
+	}
+	return &Editor{
+		config:      config,
+		gameMap:     gameMapInstance,
+		itemManager: itemManager,
+		camera:      NewCamera(),
+		gridSize:    config.GridSize,
+		snap:        true,
+	}
+}
+
+// IsActive reports whether the editor is open.
+func (e *Editor) IsActive() bool {
+	return e.active
+}
+
+// Toggle opens or closes the editor.
+func (e *Editor) Toggle(gameCamera interfaces.Camera) {
+	if e.active {
+		e.Exit()
+	} else {
+		e.Enter(gameCamera)
+	}
+}
+
+// Enter opens the editor on the map's current level, starting from the view
+// of the game camera.
+func (e *Editor) Enter(gameCamera interfaces.Camera) {
+	e.active = true
+	e.camera.x, e.camera.y = gameCamera.GetOffset()
+	e.camera.zoom = 1
+	e.selected = nil
+	e.drag = dragState{}
+	e.draft = nil
+	e.status = ""
+
+	e.level = e.gameMap.Level()
+	e.applied = e.level != nil
+	if e.level == nil {
+		// Nothing is replaced until the first object is placed.
+		e.level = &gameMap.LevelData{}
+		e.path = e.config.DefaultPath
+		return
+	}
+	e.path = e.gameMap.LevelPath()
+	if e.path == "" {
+		e.path = e.config.DefaultPath
+	}
+
+	// Put everything back where the level places it.
+	for _, obstacle := range e.level.Obstacles {
+		obstacle.RigidBody.Position = interfaces.Vector2D{X: obstacle.Movement.InitialPosX, Y: obstacle.Movement.InitialPosY}
+	}
+	for _, platform := range e.level.Platforms {
+		if platform.Movement.Type != "" {
+			platform.RigidBody.Position = interfaces.Vector2D{X: platform.Movement.InitialPosX, Y: platform.Movement.InitialPosY}
+		}
+	}
+	e.gameMap.ApplyLevel(e.level)
+}
+
+// Exit closes the editor and hands the edited level back to the game.
+func (e *Editor) Exit() {
+	e.active = false
+	e.drag = dragState{}
+	e.draft = nil
+	if e.applied {
+		e.gameMap.ApplyLevel(e.level)
+	}
+}
+
+// apply pushes the edited level to the map so it shows the changes.
+func (e *Editor) apply() {
+	e.gameMap.ApplyLevel(e.level)
+	e.applied = true
+}
+
+// Update handles the editor input.
+func (e *Editor) Update() error {
+	if !e.active {
+		return nil
+	}
+	e.handleCamera()
+	e.handleKeys()
+	e.handleMouse()
+	return nil
+}
+
+func (e *Editor) handleCamera() {
+	dx, dy := 0.0, 0.0
+	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyLeft) {
+		dx -= panSpeed
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyRight) {
+		dx += panSpeed
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyUp) {
+		dy -= panSpeed
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyS) && !isCtrlPressed() || ebiten.IsKeyPressed(ebiten.KeyDown) {
+		dy += panSpeed
+	}
+	e.camera.Pan(dx, dy)
+
+	cursorX, cursorY := ebiten.CursorPosition()
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		e.camera.ZoomAt(math.Pow(1.1, wheel), float64(cursorX), float64(cursorY))
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		e.camera.ZoomAt(1.25, float64(cursorX), float64(cursorY))
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		e.camera.ZoomAt(0.8, float64(cursorX), float64(cursorY))
+	}
+
+	// Dragging with the right mouse button pans the view.
+	screen := interfaces.Vector2D{X: float64(cursorX), Y: float64(cursorY)}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		e.drag = dragState{mode: dragPan, screen: screen}
+	}
+	if e.drag.mode == dragPan {
+		e.camera.Pan(e.drag.screen.X-screen.X, e.drag.screen.Y-screen.Y)
+		e.drag.screen = screen
+		if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
+			e.drag = dragState{}
+		}
+	}
+}
+
+func isCtrlPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
+
+func (e *Editor) handleKeys() {
+	if isCtrlPressed() && inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		if err := e.Save(); err != nil {
+			e.status = err.Error()
+			log.Printf("failed to save level: %v", err)
+		}
+		return
+	}
+
+	tools := map[ebiten.Key]Tool{
+		ebiten.KeyV: ToolSelect,
+		ebiten.KeyP: ToolPlatform,
+		ebiten.KeyO: ToolObstacle,
+		ebiten.KeyI: ToolItem,
+		ebiten.KeyT: ToolTrigger,
+		ebiten.KeyC: ToolCheckpoint,
+		ebiten.KeyH: ToolHazard,
+	}
+	for key, tool := range tools {
+		if inpututil.IsKeyJustPressed(key) {
+			e.tool = tool
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+			e.gridSize = nextGridSize(e.gridSize)
+		} else {
+			e.snap = !e.snap
+		}
+	}
+
+	if e.selected == nil {
+		return
+	}
+	changed := false
+	if inpututil.IsKeyJustPressed(ebiten.KeyDelete) || inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+		e.remove(*e.selected)
+		e.selected = nil
+		e.apply()
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+		changed = e.cycleName(*e.selected)
+	}
+	if e.selected.kind == kindPlatform && inpututil.IsKeyJustPressed(ebiten.KeyK) {
+		e.cyclePlatformKind(&e.level.Platforms[e.selected.index])
+		changed = true
+	}
+	if movement := e.selectedMovement(); movement != nil {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyM) && e.selected.kind == kindObstacle:
+			movement.Type = movementTypes[(indexOf(movementTypes, movement.Type)+1)%len(movementTypes)]
+			if movement.Type != "" && movement.Distance == 0 && movement.Speed == 0 {
+				movement.Distance = e.gridSize * 4
+				movement.Speed = 50
+			}
+			changed = true
+		case inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft):
+			movement.Distance = math.Max(0, movement.Distance-e.gridSize)
+			changed = true
+		case inpututil.IsKeyJustPressed(ebiten.KeyBracketRight):
+			movement.Distance += e.gridSize
+			changed = true
+		// The sign of the speed is the direction, so only its size changes
+		case inpututil.IsKeyJustPressed(ebiten.KeySemicolon):
+			movement.Speed = math.Copysign(math.Max(0, math.Abs(movement.Speed)-10), movement.Speed)
+			changed = true
+		case inpututil.IsKeyJustPressed(ebiten.KeyQuote):
+			movement.Speed = math.Copysign(math.Abs(movement.Speed)+10, movement.Speed)
+			changed = true
+		}
+	}
+	if changed {
+		e.apply()
+	}
+}
+
+// selectedMovement returns the movement of the selected obstacle or moving platform.
+func (e *Editor) selectedMovement() *gameMap.Movement {
+	switch e.selected.kind {
+	case kindObstacle:
+		return &e.level.Obstacles[e.selected.index].Movement
+	case kindPlatform:
+		if platform := &e.level.Platforms[e.selected.index]; platform.Kind == gameMap.PlatformMoving {
+			return &platform.Movement
+		}
+	}
+	return nil
+}
+
+// cyclePlatformKind switches a platform to the next kind, giving moving
+// platforms a default movement.
+func (e *Editor) cyclePlatformKind(platform *gameMap.Platform) {
+	index := 0
+	for i, kind := range platformKinds {
+		if kind == platform.Kind {
+			index = i
+		}
+	}
+	platform.Kind = platformKinds[(index+1)%len(platformKinds)]
+	platform.RigidBody.IsOneWay = platform.Kind == gameMap.PlatformOneWay
+	if platform.Kind == gameMap.PlatformMoving {
+		platform.Movement.Type = "horizontal"
+		if platform.Movement.Distance == 0 && platform.Movement.Speed == 0 {
+			platform.Movement.Distance = e.gridSize * 4
+			platform.Movement.Speed = 50
+		}
+	} else {
+		platform.Movement.Type = ""
+	}
+}
+
+func (e *Editor) handleMouse() {
+	cursorX, cursorY := ebiten.CursorPosition()
+	mouse := e.camera.ScreenToWorld(float64(cursorX), float64(cursorY))
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		e.startDrag(mouse)
+	}
+	if e.drag.mode == dragNone || e.drag.mode == dragPan {
+		return
+	}
+
+	switch e.drag.mode {
+	case dragMove:
+		rect, _ := e.rectOf(*e.selected)
+		rect.Position = e.snapPoint(interfaces.Vector2D{X: mouse.X - e.drag.offset.X, Y: mouse.Y - e.drag.offset.Y})
+		e.setRect(*e.selected, rect)
+	case dragResize:
+		rect, _ := e.rectOf(*e.selected)
+		corner := e.snapPoint(mouse)
+		rect.Size = interfaces.Vector2D{
+			X: math.Max(e.gridSize, corner.X-rect.Position.X),
+			Y: math.Max(e.gridSize, corner.Y-rect.Position.Y),
+		}
+		e.setRect(*e.selected, rect)
+	case dragCreate:
+		corner := e.snapPoint(mouse)
+		e.draft = &interfaces.Rect{
+			Position: interfaces.Vector2D{X: math.Min(e.drag.start.X, corner.X), Y: math.Min(e.drag.start.Y, corner.Y)},
+			Size:     interfaces.Vector2D{X: math.Abs(corner.X - e.drag.start.X), Y: math.Abs(corner.Y - e.drag.start.Y)},
+		}
+	}
+
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if e.drag.mode == dragCreate {
+			e.create(*e.draft)
+			e.draft = nil
+		}
+		e.drag = dragState{}
+		e.apply()
+	}
+}
+
+func (e *Editor) startDrag(mouse interfaces.Vector2D) {
+	if e.tool != ToolSelect {
+		start := e.snapPoint(mouse)
+		e.drag = dragState{mode: dragCreate, start: start}
+		e.draft = &interfaces.Rect{Position: start}
+		return
+	}
+
+	hit, ok := e.hitTest(mouse)
+	if !ok {
+		e.selected = nil
+		return
+	}
+	e.selected = &hit
+	rect, _ := e.rectOf(hit)
+	e.drag = dragState{
+		mode:   dragMove,
+		offset: interfaces.Vector2D{X: mouse.X - rect.Position.X, Y: mouse.Y - rect.Position.Y},
+	}
+	handle := handleSize / e.camera.Zoom()
+	corner := interfaces.Vector2D{X: rect.Position.X + rect.Size.X, Y: rect.Position.Y + rect.Size.Y}
+	if hit.kind != kindSpawn && mouse.X >= corner.X-handle && mouse.Y >= corner.Y-handle {
+		e.drag.mode = dragResize
+	}
+}
+
+func (e *Editor) snapPoint(point interfaces.Vector2D) interfaces.Vector2D {
+	if !e.snap {
+		return point
+	}
+	return interfaces.Vector2D{
+		X: math.Round(point.X/e.gridSize) * e.gridSize,
+		Y: math.Round(point.Y/e.gridSize) * e.gridSize,
+	}
+}
+
+// rectOf returns the area covered by the selected object.
+func (e *Editor) rectOf(sel selection) (interfaces.Rect, bool) {
+	switch sel.kind {
+	case kindPlatform:
+		return physics.BodyRect(e.level.Platforms[sel.index].RigidBody), true
+	case kindObstacle:
+		return physics.BodyRect(e.level.Obstacles[sel.index].RigidBody), true
+	case kindItem:
+		return physics.BodyRect(e.level.Items[sel.index].RigidBody), true
+	case kindTrigger:
+		return e.level.Triggers[sel.index].Body, true
+	case kindCheckpoint:
+		return e.level.Checkpoints[sel.index].Body, true
+	case kindHazard:
+		return e.level.Hazards[sel.index].Body, true
+	case kindSpawn:
+		position := e.level.SpawnPoints[sel.index].Position
+		return interfaces.Rect{
+			Position: interfaces.Vector2D{X: position.X - spawnSize/2, Y: position.Y - spawnSize/2},
+			Size:     interfaces.Vector2D{X: spawnSize, Y: spawnSize},
+		}, true
+	}
+	return interfaces.Rect{}, false
+}
+
+// setRect moves and resizes the selected object.
+func (e *Editor) setRect(sel selection, rect interfaces.Rect) {
+	switch sel.kind {
+	case kindPlatform:
+		platform := &e.level.Platforms[sel.index]
+		platform.RigidBody.Position, platform.RigidBody.Size = rect.Position, rect.Size
+		platform.Movement.InitialPosX = rect.Position.X
+		platform.Movement.InitialPosY = rect.Position.Y
+		e.gameMap.Invalidate()
+	case kindObstacle:
+		obstacle := &e.level.Obstacles[sel.index]
+		obstacle.RigidBody.Position, obstacle.RigidBody.Size = rect.Position, rect.Size
+		obstacle.Movement.InitialPosX = rect.Position.X
+		obstacle.Movement.InitialPosY = rect.Position.Y
+	case kindItem:
+		rb := e.level.Items[sel.index].RigidBody
+		rb.Position, rb.Size = rect.Position, rect.Size
+	case kindTrigger:
+		e.level.Triggers[sel.index].Body = rect
+	case kindCheckpoint:
+		e.level.Checkpoints[sel.index].Body = rect
+	case kindHazard:
+		e.level.Hazards[sel.index].Body = rect
+	case kindSpawn:
+		e.level.SpawnPoints[sel.index].Position = interfaces.Vector2D{X: rect.Position.X + spawnSize/2, Y: rect.Position.Y + spawnSize/2}
+	}
+}
+
+// hitTest returns the topmost object under the given world position.
+func (e *Editor) hitTest(point interfaces.Vector2D) (selection, bool) {
+	counts := []struct {
+		kind  kind
+		count int
+	}{
+		{kindSpawn, len(e.level.SpawnPoints)},
+		{kindCheckpoint, len(e.level.Checkpoints)},
+		{kindHazard, len(e.level.Hazards)},
+		{kindTrigger, len(e.level.Triggers)},
+		{kindItem, len(e.level.Items)},
+		{kindObstacle, len(e.level.Obstacles)},
+		{kindPlatform, len(e.level.Platforms)},
+	}
+	for _, group := range counts {
+		for i := group.count - 1; i >= 0; i-- {
+			sel := selection{kind: group.kind, index: i}
+			rect, _ := e.rectOf(sel)
+			if point.X >= rect.Position.X && point.X <= rect.Position.X+rect.Size.X &&
+				point.Y >= rect.Position.Y && point.Y <= rect.Position.Y+rect.Size.Y {
+				return sel, true
+			}
+		}
+	}
+	return selection{}, false
+}
+
+// create adds an object of the current tool's kind. A click without dragging
+// places an object of the default size.
+func (e *Editor) create(rect interfaces.Rect) {
+	defaults := map[Tool]interfaces.Vector2D{
+		ToolPlatform:   {X: 200, Y: 20},
+		ToolObstacle:   {X: 50, Y: 50},
+		ToolItem:       {X: 50, Y: 50},
+		ToolTrigger:    {X: 64, Y: 64},
+		ToolCheckpoint: {X: 32, Y: 64},
+		ToolHazard:     {X: 64, Y: 16},
+	}
+	if rect.Size.X < e.gridSize/2 || rect.Size.Y < e.gridSize/2 {
+		rect.Size = defaults[e.tool]
+	}
+
+	var sel selection
+	switch e.tool {
+	case ToolPlatform:
+		body := physics.NewRigidBody(rect.Position, rect.Size, 1, true, "platform")
+		e.level.Platforms = append(e.level.Platforms, gameMap.Platform{RigidBody: body})
+		sel = selection{kind: kindPlatform, index: len(e.level.Platforms) - 1}
+	case ToolObstacle:
+		obstacleType := e.config.ObstacleTypes[0]
+		body := physics.NewRigidBody(rect.Position, rect.Size, 1, true, obstacleType)
+		e.level.Obstacles = append(e.level.Obstacles, gameMap.Obstacle{
+			Type:      obstacleType,
+			RigidBody: body,
+			Movement:  gameMap.Movement{InitialPosX: rect.Position.X, InitialPosY: rect.Position.Y},
+		})
+		sel = selection{kind: kindObstacle, index: len(e.level.Obstacles) - 1}
+	case ToolItem:
+		name := ""
+		if names := e.itemNames(); len(names) > 0 {
+			name = names[0]
+		}
+		body := physics.NewRigidBody(rect.Position, rect.Size, 1, true, name)
+		body.SetPickable(true)
+		e.level.Items = append(e.level.Items, gameMap.ItemOnMap{Name: name, RigidBody: body})
+		sel = selection{kind: kindItem, index: len(e.level.Items) - 1}
+	case ToolTrigger:
+		e.level.Triggers = append(e.level.Triggers, gameMap.Trigger{
+			Name: fmt.Sprintf("trigger%d", len(e.level.Triggers)+1),
+			Body: rect,
+		})
+		sel = selection{kind: kindTrigger, index: len(e.level.Triggers) - 1}
+	case ToolCheckpoint:
+		e.level.Checkpoints = append(e.level.Checkpoints, gameMap.Checkpoint{
+			Name: fmt.Sprintf("checkpoint%d", len(e.level.Checkpoints)+1),
+			Body: rect,
+		})
+		sel = selection{kind: kindCheckpoint, index: len(e.level.Checkpoints) - 1}
+	case ToolHazard:
+		e.level.Hazards = append(e.level.Hazards, gameMap.Hazard{
+			Name:   fmt.Sprintf("hazard%d", len(e.level.Hazards)+1),
+			Body:   rect,
+			Damage: interfaces.Damage{Amount: defaultHazardDamage},
+		})
+		sel = selection{kind: kindHazard, index: len(e.level.Hazards) - 1}
+	default:
+		return
+	}
+	e.selected = &sel
+}
+
+// remove deletes the selected object from the level.
+func (e *Editor) remove(sel selection) {
+	switch sel.kind {
+	case kindPlatform:
+		e.level.Platforms = append(e.level.Platforms[:sel.index], e.level.Platforms[sel.index+1:]...)
+	case kindObstacle:
+		e.level.Obstacles = append(e.level.Obstacles[:sel.index], e.level.Obstacles[sel.index+1:]...)
+	case kindItem:
+		e.level.Items = append(e.level.Items[:sel.index], e.level.Items[sel.index+1:]...)
+	case kindTrigger:
+		e.level.Triggers = append(e.level.Triggers[:sel.index], e.level.Triggers[sel.index+1:]...)
+	case kindCheckpoint:
+		e.level.Checkpoints = append(e.level.Checkpoints[:sel.index], e.level.Checkpoints[sel.index+1:]...)
+	case kindHazard:
+		e.level.Hazards = append(e.level.Hazards[:sel.index], e.level.Hazards[sel.index+1:]...)
+	case kindSpawn:
+		e.level.SpawnPoints = append(e.level.SpawnPoints[:sel.index], e.level.SpawnPoints[sel.index+1:]...)
+	}
+}
+
+// cycleName switches the selected obstacle's type or item's name to the next
+// known one.
+func (e *Editor) cycleName(sel selection) bool {
+	switch sel.kind {
+	case kindObstacle:
+		obstacle := &e.level.Obstacles[sel.index]
+		types := e.config.ObstacleTypes
+		obstacle.Type = types[(indexOf(types, obstacle.Type)+1)%len(types)]
+		obstacle.RigidBody.Identifier = obstacle.Type
+		return true
+	case kindItem:
+		names := e.itemNames()
+		if len(names) == 0 {
+			return false
+		}
+		item := &e.level.Items[sel.index]
+		item.Name = names[(indexOf(names, item.Name)+1)%len(names)]
+		item.RigidBody.Identifier = item.Name
+		return true
+	}
+	return false
+}
+
+func (e *Editor) itemNames() []string {
+	if e.itemManager == nil {
+		return nil
+	}
+	var names []string
+	for _, item := range e.itemManager.GetAllItems() {
+		names = append(names, item.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func nextGridSize(current float64) float64 {
+	for i, size := range gridSizes {
+		if size == current {
+			return gridSizes[(i+1)%len(gridSizes)]
+		}
+	}
+	return gridSizes[0]
+}
+
+// SavePath returns the file Save writes to. Levels imported from Tiled are
+// saved next to their source so the Tiled file is not overwritten.
+func (e *Editor) SavePath() string {
+	ext := path.Ext(e.path)
+	if e.level != nil && e.level.Imported() {
+		return strings.TrimSuffix(e.path, ext) + ".level.json"
+	}
+	if !strings.EqualFold(ext, ".json") {
+		return strings.TrimSuffix(e.path, ext) + ".json"
+	}
+	return e.path
+}
+
+// Save writes the edited level in the native level format.
+func (e *Editor) Save() error {
+	data, err := e.level.Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode level: %w", err)
+	}
+	savePath := e.SavePath()
+	if err := utils.SaveData(savePath, data); err != nil {
+		return err
+	}
+	e.status = "saved " + savePath
+	return nil
+}
+
+// Draw draws the map through the editor camera together with the editor overlays.
+func (e *Editor) Draw(screen *ebiten.Image) {
+	screenWidth, screenHeight := screen.Bounds().Dx(), screen.Bounds().Dy()
+	e.camera.setView(screenWidth, screenHeight)
+	width, height := int(math.Ceil(e.camera.viewWidth)), int(math.Ceil(e.camera.viewHeight))
+	if e.canvas == nil || e.canvas.Bounds().Dx() != width || e.canvas.Bounds().Dy() != height {
+		if e.canvas != nil {
+			e.canvas.Deallocate()
+		}
+		e.canvas = ebiten.NewImage(width, height)
+	}
+
+	e.canvas.Fill(color.RGBA{40, 40, 48, 255})
+	e.gameMap.Draw(e.canvas, e.camera)
+	e.gameMap.DrawForeground(e.canvas, e.camera)
+	e.drawGrid(e.canvas)
+	e.drawOverlays(e.canvas)
+
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(e.camera.Zoom(), e.camera.Zoom())
+	screen.DrawImage(e.canvas, opts)
+
+	e.drawStatus(screen)
+}
+
+func (e *Editor) drawGrid(canvas *ebiten.Image) {
+	if !e.snap {
+		return
+	}
+	offsetX, offsetY := e.camera.GetOffset()
+	width, height := float32(canvas.Bounds().Dx()), float32(canvas.Bounds().Dy())
+	gridColor := color.RGBA{255, 255, 255, 24}
+	for x := math.Floor(offsetX/e.gridSize) * e.gridSize; x < offsetX+float64(width); x += e.gridSize {
+		vector.StrokeLine(canvas, float32(x-offsetX), 0, float32(x-offsetX), height, 1, gridColor, false)
+	}
+	for y := math.Floor(offsetY/e.gridSize) * e.gridSize; y < offsetY+float64(height); y += e.gridSize {
+		vector.StrokeLine(canvas, 0, float32(y-offsetY), width, float32(y-offsetY), 1, gridColor, false)
+	}
+}
+
+func (e *Editor) drawOverlays(canvas *ebiten.Image) {
+	offsetX, offsetY := e.camera.GetOffset()
+	strokeRect := func(rect interfaces.Rect, clr color.Color, width float32) {
+		vector.StrokeRect(canvas, float32(rect.Position.X-offsetX), float32(rect.Position.Y-offsetY),
+			float32(rect.Size.X), float32(rect.Size.Y), width, clr, false)
+	}
+
+	for _, trigger := range e.level.Triggers {
+		vector.DrawFilledRect(canvas, float32(trigger.Body.Position.X-offsetX), float32(trigger.Body.Position.Y-offsetY),
+			float32(trigger.Body.Size.X), float32(trigger.Body.Size.Y), color.RGBA{0, 120, 255, 60}, false)
+		strokeRect(trigger.Body, color.RGBA{0, 120, 255, 200}, 1)
+		ebitenutil.DebugPrintAt(canvas, trigger.Name, int(trigger.Body.Position.X-offsetX)+2, int(trigger.Body.Position.Y-offsetY)+2)
+	}
+	for _, hazard := range e.level.Hazards {
+		vector.DrawFilledRect(canvas, float32(hazard.Body.Position.X-offsetX), float32(hazard.Body.Position.Y-offsetY),
+			float32(hazard.Body.Size.X), float32(hazard.Body.Size.Y), color.RGBA{255, 40, 0, 60}, false)
+		strokeRect(hazard.Body, color.RGBA{255, 40, 0, 200}, 1)
+		ebitenutil.DebugPrintAt(canvas, hazard.Name, int(hazard.Body.Position.X-offsetX)+2, int(hazard.Body.Position.Y-offsetY)+2)
+	}
+	for _, checkpoint := range e.level.Checkpoints {
+		strokeRect(checkpoint.Body, color.RGBA{50, 205, 50, 200}, 1)
+		ebitenutil.DebugPrintAt(canvas, checkpoint.Name, int(checkpoint.Body.Position.X-offsetX)+2, int(checkpoint.Body.Position.Y-offsetY)+2)
+	}
+	for i := range e.level.SpawnPoints {
+		rect, _ := e.rectOf(selection{kind: kindSpawn, index: i})
+		strokeRect(rect, color.RGBA{0, 255, 0, 255}, 2)
+		ebitenutil.DebugPrintAt(canvas, e.level.SpawnPoints[i].Name, int(rect.Position.X-offsetX), int(rect.Position.Y-offsetY)-16)
+	}
+
+	// Show how far moving obstacles and platforms travel.
+	type mover struct {
+		rb       *physics.RigidBody
+		movement gameMap.Movement
+	}
+	var movers []mover
+	for _, obstacle := range e.level.Obstacles {
+		movers = append(movers, mover{obstacle.RigidBody, obstacle.Movement})
+	}
+	for _, platform := range e.level.Platforms {
+		movers = append(movers, mover{platform.RigidBody, platform.Movement})
+	}
+	for _, m := range movers {
+		rb := m.rb
+		centerX, centerY := rb.Position.X+rb.Size.X/2-offsetX, rb.Position.Y+rb.Size.Y/2-offsetY
+		distance := m.movement.Distance
+		pathColor := color.RGBA{255, 255, 0, 200}
+		switch m.movement.Type {
+		case "horizontal":
+			vector.StrokeLine(canvas, float32(centerX-distance), float32(centerY), float32(centerX+distance), float32(centerY), 1, pathColor, false)
+		case "vertical":
+			vector.StrokeLine(canvas, float32(centerX), float32(centerY-distance), float32(centerX), float32(centerY+distance), 1, pathColor, false)
+		}
+	}
+
+	if e.selected != nil {
+		rect, _ := e.rectOf(*e.selected)
+		strokeRect(rect, color.White, 2)
+		if e.selected.kind != kindSpawn {
+			handle := handleSize / e.camera.Zoom()
+			vector.DrawFilledRect(canvas, float32(rect.Position.X+rect.Size.X-handle-offsetX), float32(rect.Position.Y+rect.Size.Y-handle-offsetY),
+				float32(handle), float32(handle), color.White, false)
+		}
+	}
+	if e.draft != nil {
+		strokeRect(*e.draft, color.RGBA{255, 255, 255, 160}, 1)
+	}
+}
+
+func (e *Editor) drawStatus(screen *ebiten.Image) {
+	snap := "off"
+	if e.snap {
+		snap = fmt.Sprintf("%.0f", e.gridSize)
+	}
+	lines := []string{
+		fmt.Sprintf("EDITOR  tool: %s  grid: %s  zoom: %.2f  file: %s", toolNames[e.tool], snap, e.camera.Zoom(), e.SavePath()),
+		"F2 play  V/P/O/I/T/C/H tools  G snap  Shift+G grid  Del delete  N type/name  Ctrl+S save",
+	}
+	if e.selected != nil && e.selected.kind == kindObstacle {
+		obstacle := e.level.Obstacles[e.selected.index]
+		lines = append(lines, fmt.Sprintf("obstacle %s  movement: %q (M)  distance: %.0f ([ ])  speed: %.0f (; ')",
+			obstacle.Type, obstacle.Movement.Type, obstacle.Movement.Distance, math.Abs(obstacle.Movement.Speed)))
+	}
+	if e.selected != nil && e.selected.kind == kindPlatform {
+		platform := e.level.Platforms[e.selected.index]
+		line := fmt.Sprintf("platform %s (K)", platform.Kind)
+		if platform.Kind == gameMap.PlatformMoving {
+			line += fmt.Sprintf("  distance: %.0f ([ ])  speed: %.0f (; ')", platform.Movement.Distance, math.Abs(platform.Movement.Speed))
+		}
+		lines = append(lines, line)
+	}
+	if e.selected != nil && e.selected.kind == kindItem {
+		lines = append(lines, fmt.Sprintf("item %q", e.level.Items[e.selected.index].Name))
+	}
+	if e.status != "" {
+		lines = append(lines, e.status)
+	}
+	ebitenutil.DebugPrint(screen, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,176 @@
+package formation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/internal/utils"
+)
+
+// goalkeeper is the role of the one player every formation has in goal, as
+// named by the soccer AI.
+const goalkeeper = "goalkeeper"
+
+// Spot is where a player of some role lines up. Positions are fractions of
+// the field: X runs from the goal line of the team (0) to the goal line of
+// the other team (1) and Y from the top (0) to the bottom (1) of the field.
+type Spot struct {
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Role string  `json:"role"`
+}
+
+// Formation is a named line-up of a team, e.g. "4-4-2".
+type Formation struct {
+	Name    string `json:"name"`
+	Players []Spot `json:"players"`
+}
+
+// Placement is where the center of a player of some role goes on the field.
+type Placement struct {
+	Position interfaces.Vector2D
+	Role     string
+}
+
+// Place returns where the players of the formation line up on field, for a
+// team defending the goal on the left, or on the right when mirrored.
+func (f Formation) Place(field interfaces.Rect, mirrored bool) []Placement {
+	placements := make([]Placement, len(f.Players))
+	for i, spot := range f.Players {
+		x := spot.X
+		if mirrored {
+			x = 1 - x
+		}
+		placements[i] = Placement{
+			Position: interfaces.Vector2D{
+				X: field.Position.X + x*field.Size.X,
+				Y: field.Position.Y + spot.Y*field.Size.Y,
+			},
+			Role: spot.Role,
+		}
+	}
+	return placements
+}
+
+// validate checks that the formation has a goalkeeper and every player is
+// on the field.
+func (f Formation) validate() error {
+	if f.Name == "" {
+		return errors.New("formation without a name")
+	}
+	keepers := 0
+	for i, spot := range f.Players {
+		if spot.Role == "" {
+			return fmt.Errorf("formation %s: player %d has no role", f.Name, i+1)
+		}
+		if spot.X < 0 || spot.X > 1 || spot.Y < 0 || spot.Y > 1 {
+			return fmt.Errorf("formation %s: player %d is off the field", f.Name, i+1)
+		}
+		if spot.Role == goalkeeper {
+			keepers++
+		}
+	}
+	if keepers != 1 {
+		return fmt.Errorf("formation %s: has %d goalkeepers instead of 1", f.Name, keepers)
+	}
+	return nil
+}
+
+// List is the formations teams pick from.
+type List struct {
+	Default    string      `json:"default"`
+	Formations []Formation `json:"formations"`
+}
+
+// Parse reads a list of formations from JSON, checking every formation.
+func Parse(data []byte) (*List, error) {
+	var list List
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse formations: %w", err)
+	}
+	if len(list.Formations) == 0 {
+		return nil, errors.New("no formations")
+	}
+	names := make(map[string]bool)
+	for _, formation := range list.Formations {
+		if err := formation.validate(); err != nil {
+			return nil, err
+		}
+		if names[formation.Name] {
+			return nil, fmt.Errorf("formation %s is defined twice", formation.Name)
+		}
+		names[formation.Name] = true
+	}
+	if list.Default != "" && !names[list.Default] {
+		return nil, fmt.Errorf("default formation %s is not defined", list.Default)
+	}
+	return &list, nil
+}
+
+// Load reads a list of formations from a JSON file.
+func Load(path string) (*List, error) {
+	var list *List
+	err := utils.LoadData(path, func(data []byte) error {
+		var err error
+		list, err = Parse(data)
+		return err
+	})
+	if err == nil && list == nil {
+		err = errors.New("no formations")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load formations %s: %w", path, err)
+	}
+	return list, nil
+}
+
+// Find returns the formation with the given name.
+func (l *List) Find(name string) (Formation, bool) {
+	for _, formation := range l.Formations {
+		if formation.Name == name {
+			return formation, true
+		}
+	}
+	return Formation{}, false
+}
+
+// Get returns the formation with the given name, or the default one when
+// there is none.
+func (l *List) Get(name string) Formation {
+	if formation, ok := l.Find(name); ok {
+		return formation
+	}
+	if formation, ok := l.Find(l.Default); ok {
+		return formation
+	}
+	return l.Formations[0]
+}
+
+// Next returns the formation after the one with the given name, going back
+// to the first after the last.
+func (l *List) Next(name string) Formation {
+	for i, formation := range l.Formations {
+		if formation.Name == name {
+			return l.Formations[(i+1)%len(l.Formations)]
+		}
+	}
+	return l.Get("")
+}
+
+// Fallback returns the formations used when none can be loaded: a 3-3-2.
+func Fallback() *List {
+	return &List{
+		Default: "3-3-2",
+		Formations: []Formation{{
+			Name: "3-3-2",
+			Players: []Spot{
+				{X: 0.06, Y: 0.5, Role: goalkeeper},
+				{X: 0.2, Y: 0.25, Role: "defender"}, {X: 0.2, Y: 0.5, Role: "defender"}, {X: 0.2, Y: 0.75, Role: "defender"},
+				{X: 0.38, Y: 0.25, Role: "midfielder"}, {X: 0.38, Y: 0.5, Role: "midfielder"}, {X: 0.38, Y: 0.75, Role: "midfielder"},
+				{X: 0.46, Y: 0.35, Role: "striker"}, {X: 0.46, Y: 0.65, Role: "striker"},
+			},
+		}},
+	}
+}
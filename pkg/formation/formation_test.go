@@ -0,0 +1,93 @@
+package formation
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFormation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Formation Suite")
+}
+
+var _ = Describe("Formation", func() {
+	field := interfaces.Rect{Position: interfaces.Vector2D{X: 100, Y: 50}, Size: interfaces.Vector2D{X: 800, Y: 400}}
+	formation := Formation{
+		Name: "1-1",
+		Players: []Spot{
+			{X: 0.05, Y: 0.5, Role: "goalkeeper"},
+			{X: 0.25, Y: 0.25, Role: "defender"},
+		},
+	}
+
+	It("should place the players on the field", func() {
+		Expect(formation.Place(field, false)).To(Equal([]Placement{
+			{Position: interfaces.Vector2D{X: 140, Y: 250}, Role: "goalkeeper"},
+			{Position: interfaces.Vector2D{X: 300, Y: 150}, Role: "defender"},
+		}))
+	})
+
+	It("should mirror the players of the other team", func() {
+		Expect(formation.Place(field, true)).To(Equal([]Placement{
+			{Position: interfaces.Vector2D{X: 860, Y: 250}, Role: "goalkeeper"},
+			{Position: interfaces.Vector2D{X: 700, Y: 150}, Role: "defender"},
+		}))
+	})
+})
+
+var _ = Describe("List", func() {
+	const formations = `{
+		"default": "2-1",
+		"formations": [
+			{"name": "1-2", "players": [{"x": 0.05, "y": 0.5, "role": "goalkeeper"}, {"x": 0.2, "y": 0.5, "role": "defender"}]},
+			{"name": "2-1", "players": [{"x": 0.05, "y": 0.5, "role": "goalkeeper"}, {"x": 0.4, "y": 0.5, "role": "striker"}]}
+		]
+	}`
+
+	It("should pick formations by name, or the default one", func() {
+		list, err := Parse([]byte(formations))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Get("1-2").Name).To(Equal("1-2"))
+		Expect(list.Get("").Name).To(Equal("2-1"))
+		Expect(list.Get("9-9-9").Name).To(Equal("2-1"))
+	})
+
+	It("should cycle through the formations", func() {
+		list, err := Parse([]byte(formations))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Next("1-2").Name).To(Equal("2-1"))
+		Expect(list.Next("2-1").Name).To(Equal("1-2"))
+	})
+
+	It("should reject formations that cannot be played", func() {
+		for _, data := range []string{
+			`{"formations": []}`,
+			`{"formations": [{"name": "0-1", "players": [{"x": 0.4, "y": 0.5, "role": "striker"}]}]}`,
+			`{"formations": [{"name": "1-0", "players": [{"x": 1.2, "y": 0.5, "role": "goalkeeper"}]}]}`,
+			`{"formations": [{"name": "1-0", "players": [{"x": 0.1, "y": 0.5}]}]}`,
+			`{"default": "4-4-2", "formations": [{"name": "1-0", "players": [{"x": 0.1, "y": 0.5, "role": "goalkeeper"}]}]}`,
+			`{"formations": [{"name": "1-0", "players": [{"x": 0.1, "y": 0.5, "role": "goalkeeper"}]}, {"name": "1-0", "players": [{"x": 0.1, "y": 0.5, "role": "goalkeeper"}]}]}`,
+		} {
+			_, err := Parse([]byte(data))
+			Expect(err).To(HaveOccurred(), data)
+		}
+	})
+
+	It("should fall back to a formation that can be played", func() {
+		list := Fallback()
+		Expect(list.Formations).To(HaveLen(1))
+		Expect(list.Formations[0].validate()).To(Succeed())
+	})
+
+	It("should load the formations of the game", func() {
+		list, err := Load("../../assets/game/formations.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Get("").Name).To(Equal("4-4-2"))
+		formation, ok := list.Find("3-5-2")
+		Expect(ok).To(BeTrue())
+		Expect(formation.Players).To(HaveLen(11))
+	})
+})
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+)
+
+// careerMode plays the platformer levels one after the other, from the level
+// list or endless mode.
+type careerMode struct {
+	game *Game
+}
+
+func newCareerMode(g *Game) interfaces.GameMode {
+	return &careerMode{game: g}
+}
+
+func (c *careerMode) Name() string {
+	return ModeCareer
+}
+
+// Setup starts the level named by the "level" setting, or the first level of
+// the level list.
+func (c *careerMode) Setup() error {
+	name := c.game.stringSetting("level")
+	if name == "" {
+		if err := c.game.loadLevels(); err != nil {
+			return err
+		}
+		first, _ := c.game.levels.First()
+		name = first.Name
+	}
+	return c.game.startLevel(name)
+}
+
+// Hold keeps the level still while the player respawns at a checkpoint.
+func (c *careerMode) Hold(deltaTime float64) bool {
+	c.game.syncCheckpoints()
+	c.game.checkpoints.Update(deltaTime, c.game.playerRect())
+	return c.game.checkpoints.Respawning()
+}
+
+// Update moves the enemies and spawns new ones, carries out what the pet does,
+// hurts the player with what it touches, runs the logic of the level and hands
+// off to the next level once the player reaches the exit.
+func (c *careerMode) Update(deltaTime float64) error {
+	c.game.enemies.Update(deltaTime)
+	c.game.updateSpawners(deltaTime)
+	c.game.updatePet()
+	c.game.updateDamage()
+	c.game.updateLogic(deltaTime)
+	c.game.updateLevel(deltaTime)
+	return nil
+}
+
+// Draw draws the checkpoints, the enemies, their navigation when it is shown
+// and the exit of the level.
+func (c *careerMode) Draw(screen *ebiten.Image, camera interfaces.Camera) {
+	c.game.checkpoints.Draw(screen, camera)
+	c.game.drawEnemies(screen, camera)
+	c.game.drawNavigation(screen, camera)
+	if current := c.game.progress.Level(); current != nil {
+		current.Draw(screen, camera)
+	}
+}
+
+func (c *careerMode) Rules() string {
+	return "Career - Reach the Exit of Every Level!"
+}
+
+// Teardown clears the level and its enemies and stops tracking it, along
+// with its checkpoints and any respawn under way.
+func (c *careerMode) Teardown() {
+	c.game.checkpoints.Reset(nil, c.game.playerRect(), math.Inf(1))
+	c.game.clearEnemies()
+	if levelMap, ok := c.game.GameMap.(*gameMap.Map); ok {
+		levelMap.Reset()
+	}
+	c.game.progress.Start(nil)
+	c.game.levelReady = false
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/checkpoint"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// causeDamage is the cause of death of a player that ran out of hit points.
+const causeDamage = "damage"
+
+// updateDamage hurts the player with the harmful obstacles, enemies and
+// hazards it touches, and starts the respawn once it runs out of hit points.
+func (g *Game) updateDamage() {
+	levelMap, ok := g.GameMap.(*gameMap.Map)
+	if !ok || g.Player.GetHealth().Dead() {
+		return
+	}
+	// The physics engine pushes the player out of obstacles, so grow the
+	// player by a pixel to find the ones it stands against.
+	player := g.playerRect()
+	player.Position.X--
+	player.Position.Y--
+	player.Size.X += 2
+	player.Size.Y += 2
+
+	for _, obstacle := range levelMap.Obstacles {
+		body := physics.BodyRect(obstacle.RigidBody)
+		if obstacle.Damage != nil && touches(player, body) {
+			g.Player.Damage(interfaces.Hit{Damage: *obstacle.Damage, Source: "obstacle:" + obstacle.Type, From: center(body)})
+		}
+	}
+	for enemy, damage := range g.enemyDamage {
+		body := physics.BodyRect(enemy.Body)
+		if touches(player, body) {
+			g.Player.Damage(interfaces.Hit{Damage: damage, Source: "enemy:" + enemy.Behavior, From: center(body)})
+		}
+	}
+	for _, hazard := range levelMap.Hazards {
+		if touches(player, hazard.Body) {
+			g.Player.Damage(interfaces.Hit{Damage: hazard.Damage, Source: "hazard:" + hazard.Name, From: center(hazard.Body)})
+		}
+	}
+	if g.Player.GetHealth().Dead() {
+		g.checkpoints.Kill(causeDamage)
+	}
+}
+
+// playerDied takes a life from a player that fell out of the level. Once the
+// lives run out the player starts over from the beginning of the level.
+func (g *Game) playerDied(cause string) {
+	health := g.Player.GetHealth()
+	if cause == checkpoint.CauseFall {
+		health.Kill()
+	}
+	if health.GameOver() {
+		g.checkpoints.Forget()
+	}
+}
+
+func touches(a, b interfaces.Rect) bool {
+	return a.Position.X < b.Position.X+b.Size.X && b.Position.X < a.Position.X+a.Size.X &&
+		a.Position.Y < b.Position.Y+b.Size.Y && b.Position.Y < a.Position.Y+a.Size.Y
+}
+
+func center(rect interfaces.Rect) interfaces.Vector2D {
+	return interfaces.Vector2D{X: rect.Position.X + rect.Size.X/2, Y: rect.Position.Y + rect.Size.Y/2}
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/ai"
+	"github.com/joaorufino/gopher-game/pkg/behavior"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/level"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// defaultEnemySize is the size of enemies placed without one, e.g. through
+// AddEnemy.
+var defaultEnemySize = interfaces.Vector2D{X: 32, Y: 32}
+
+// enemyColors are the colors enemies are drawn with, by behavior.
+var enemyColors = map[string]color.RGBA{
+	ai.BehaviorPatrol: {160, 32, 240, 255},
+	ai.BehaviorChase:  {220, 20, 60, 255},
+	ai.BehaviorFlee:   {255, 165, 0, 255},
+	ai.BehaviorGuard:  {105, 105, 105, 255},
+	ai.BehaviorJump:   {50, 205, 50, 255},
+}
+
+// enemySenses tells the enemies where the player and the platforms are.
+type enemySenses struct {
+	game *Game
+}
+
+// Target returns the area of the player while it is alive.
+func (s enemySenses) Target() (interfaces.Rect, bool) {
+	if s.game.Player.GetHealth().Dead() {
+		return interfaces.Rect{}, false
+	}
+	return s.game.playerRect(), true
+}
+
+// Platforms returns the platforms of the level and the generated ones.
+func (s enemySenses) Platforms() []interfaces.Rect {
+	var platforms []interfaces.Rect
+	for _, platform := range s.game.GameMap.GetPlatforms() {
+		if platform, ok := platform.(gameMap.Platform); ok {
+			platforms = append(platforms, physics.BodyRect(platform.RigidBody))
+		}
+	}
+	return platforms
+}
+
+// setupEnemies makes every enemy behavior available to AddEnemy; levels
+// place their own enemies when they start.
+func (g *Game) setupEnemies() {
+	g.enemies = ai.NewManager()
+	g.enemyDamage = map[*ai.Enemy]interfaces.Damage{}
+	g.offscreen = map[*ai.Enemy]float64{}
+	for _, behavior := range ai.EnemyBehaviors {
+		behavior := behavior
+		g.enemies.RegisterBehavior(behavior, func(position interfaces.Vector2D) (interfaces.AIAgent, error) {
+			enemy, err := g.newEnemy(gameMap.Enemy{
+				Behavior: behavior,
+				Body:     interfaces.Rect{Position: topLeft(position, defaultEnemySize), Size: defaultEnemySize},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return enemy, nil
+		})
+	}
+	g.enemies.SetDefaultBehavior(ai.BehaviorPatrol)
+	g.enemyNodes = ai.EnemyNodes()
+	g.setupNavigation()
+}
+
+// enemyTree builds the named behavior tree for an enemy, loading the trees
+// the first time one is needed. The "aiDebug" setting logs the active path
+// of the tree every frame.
+func (g *Game) enemyTree(name string) (*behavior.Tree, error) {
+	if g.behaviors == nil {
+		behaviors, err := behavior.Load(BehaviorsFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := behaviors.Validate(g.enemyNodes); err != nil {
+			return nil, err
+		}
+		g.behaviors = behaviors
+	}
+	tree, err := g.behaviors.New(name, g.enemyNodes)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := g.Settings.Get("aiDebug")
+	tree.Debug, _ = value.(bool)
+	return tree, nil
+}
+
+// newEnemy puts the body of an enemy of the level into the physics engine.
+// An enemy following a behavior tree looks like a patrolling one unless it
+// has a behavior.
+func (g *Game) newEnemy(spec gameMap.Enemy) (*ai.Enemy, error) {
+	if spec.Behavior == "" && spec.Tree != "" {
+		spec.Behavior = ai.BehaviorPatrol
+	}
+	size := spec.Body.Size
+	if size.X <= 0 || size.Y <= 0 {
+		size = defaultEnemySize
+	}
+	body := physics.NewRigidBody(spec.Body.Position, size, 1, false, "enemy:"+spec.Behavior)
+	config := ai.EnemyConfig{
+		Speed:        spec.Speed,
+		Radius:       spec.Radius,
+		JumpVelocity: spec.JumpVelocity,
+		Gravity:      g.engineGravity(),
+		Points:       spec.Points,
+	}
+	enemy, err := ai.NewEnemy(body, spec.Behavior, config, enemySenses{game: g})
+	if err != nil {
+		return nil, err
+	}
+	if spec.Tree != "" {
+		tree, err := g.enemyTree(spec.Tree)
+		if err != nil {
+			return nil, err
+		}
+		enemy.SetTree(tree)
+	}
+	enemy.SetGraph(g.navigation)
+	g.PhysicsEngine.AddRigidBody(body)
+	if spec.Damage != nil {
+		g.enemyDamage[enemy] = *spec.Damage
+	}
+	return enemy, nil
+}
+
+// engineGravity returns the gravity of the physics engine.
+func (g *Game) engineGravity() float64 {
+	if engine, ok := g.PhysicsEngine.(*physics.PhysicsEngine); ok {
+		return engine.Gravity().Y
+	}
+	return 0
+}
+
+// spawnEnemies replaces the enemies and the spawners with those of the
+// level, and lets the level know about the enemies.
+func (g *Game) spawnEnemies(data *gameMap.LevelData, current *level.Level) {
+	g.clearEnemies()
+	g.setupSpawners(data)
+	if data == nil {
+		return
+	}
+	for _, spec := range data.Enemies {
+		enemy, err := g.newEnemy(spec)
+		if err == nil {
+			err = g.enemies.Add(enemy)
+		}
+		if err != nil {
+			log.Printf("could not place %s enemy: %v", spec.Behavior, err)
+			continue
+		}
+		if current != nil {
+			current.AddEnemy(enemy)
+		}
+	}
+}
+
+// clearEnemies takes every enemy out of the physics engine.
+func (g *Game) clearEnemies() {
+	for _, enemy := range g.enemyList() {
+		g.PhysicsEngine.RemoveRigidBody(enemy.Body)
+	}
+	g.enemies.Clear()
+	g.enemyDamage = map[*ai.Enemy]interfaces.Damage{}
+	g.offscreen = map[*ai.Enemy]float64{}
+}
+
+// defeatEnemy takes an enemy out of the level, counting it toward the wave
+// of its spawner.
+func (g *Game) defeatEnemy(enemy *ai.Enemy, by string) {
+	g.removeEnemy(enemy)
+	for _, s := range g.spawners {
+		s.Remove(enemy, true)
+	}
+	g.EventManager.Dispatch(interfaces.Event{
+		Type:    interfaces.EventEnemyDefeated,
+		Payload: map[string]interface{}{"behavior": enemy.Behavior, "by": by},
+	})
+}
+
+// removeEnemy takes an enemy out of the physics engine, the AI and the
+// level.
+func (g *Game) removeEnemy(enemy *ai.Enemy) {
+	g.PhysicsEngine.RemoveRigidBody(enemy.Body)
+	delete(g.enemyDamage, enemy)
+	delete(g.offscreen, enemy)
+	g.enemies.Remove(enemy)
+	if current := g.progress.Level(); current != nil {
+		current.RemoveEnemy(enemy)
+	}
+}
+
+// enemyList returns the enemies the AI moves.
+func (g *Game) enemyList() []*ai.Enemy {
+	var enemies []*ai.Enemy
+	for _, agent := range g.enemies.Agents() {
+		if enemy, ok := agent.(*ai.Enemy); ok {
+			enemies = append(enemies, enemy)
+		}
+	}
+	return enemies
+}
+
+// drawEnemies draws every enemy in the color of its behavior.
+func (g *Game) drawEnemies(screen *ebiten.Image, camera interfaces.Camera) {
+	offsetX, offsetY := camera.GetOffset()
+	for _, enemy := range g.enemyList() {
+		cl, ok := enemyColors[enemy.Behavior]
+		if !ok {
+			cl = color.RGBA{255, 0, 255, 255}
+		}
+		vector.DrawFilledRect(screen,
+			float32(enemy.Body.Position.X-offsetX),
+			float32(enemy.Body.Position.Y-offsetY),
+			float32(enemy.Body.Size.X),
+			float32(enemy.Body.Size.Y),
+			cl,
+			true)
+	}
+}
@@ -7,16 +7,24 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/joaorufino/gopher-game/internal/interfaces"
 	"github.com/joaorufino/gopher-game/pkg/abilities"
 	"github.com/joaorufino/gopher-game/pkg/achievements"
 	"github.com/joaorufino/gopher-game/pkg/actions"
+	"github.com/joaorufino/gopher-game/pkg/ai"
+	"github.com/joaorufino/gopher-game/pkg/behavior"
 	"github.com/joaorufino/gopher-game/pkg/chapterintro"
+	"github.com/joaorufino/gopher-game/pkg/checkpoint"
+	"github.com/joaorufino/gopher-game/pkg/editor"
 	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/hotreload"
 	"github.com/joaorufino/gopher-game/pkg/hud"
+	"github.com/joaorufino/gopher-game/pkg/level"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
 	"github.com/joaorufino/gopher-game/pkg/pet"
 	"github.com/joaorufino/gopher-game/pkg/physics"
-	"github.com/joaorufino/gopher-game/pkg/score"
+	"github.com/joaorufino/gopher-game/pkg/stats"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/fx"
 )
@@ -37,6 +45,8 @@ type Params struct {
 	PhysicsEngine   interfaces.PhysicsEngine
 	EventManager    interfaces.EventManager
 	InputHandler    interfaces.InputHandler
+	EndlessLevels   *gameMap.EndlessLevels
+	PlayerFactory   PlayerFactory `optional:"true"`
 }
 
 // Game represents the main game structure.
@@ -57,9 +67,33 @@ type Game struct {
 	AbilitiesManager   interfaces.AbilitiesManager
 	AchievementManager interfaces.AchievementManager
 	chapterIntro       *chapterintro.ChapterIntro
-	ScoreManager       *score.ScoreManager
 	HUD                *hud.HUD
-	matchTimer         float64 // Timer for soccer match in seconds
+	Editor             *editor.Editor
+	reloader           *hotreload.Reloader
+	checkpoints        *checkpoint.Manager
+	checkpointLevel    *gameMap.LevelData
+	checkpointsReady   bool
+	startPosition      interfaces.Vector2D
+	progress           *level.Progress
+	levels             *level.List
+	endless            *gameMap.EndlessLevels
+	levelName          string
+	levelData          *gameMap.LevelData
+	levelReady         bool
+	mode               interfaces.GameMode
+	playerFactory      PlayerFactory
+	localPlayers       []*localPlayer
+	matchStats         *stats.Collector
+	enemies            *ai.Manager
+	enemyDamage        map[*ai.Enemy]interfaces.Damage
+	spawners           []*levelSpawner
+	offscreen          map[*ai.Enemy]float64 // Seconds spawned enemies have been off screen
+	behaviors          *behavior.Library
+	enemyNodes         *behavior.Registry
+	navigation         *navigation.Graph
+	showNavigation     bool
+	petNavigation      *navigation.Graph
+	petItem            *physics.RigidBody // The item the pet carries
 }
 
 // NewGame creates a new Game instance using dependency injection.
@@ -74,10 +108,8 @@ func NewGame(params Params) *Game {
 		ebiten.SetFullscreen(true)
 	}
 
-	// Initialize the player and pet
+	// Initialize the player; the pet follows once the game is set up
 	player := params.Player
-	petConfig := &pet.Configuration{ImageScale: 0.1, RunVelocity: 50, JumpVelocity: 20}
-	petInstance := pet.NewPet(player.GetPosition().X, player.GetPosition().Y, params.ResourceManager, petConfig, params.PhysicsEngine, player)
 
 	achievementConfig := achievements.Config{
 		ScreenWidth:            800,
@@ -94,40 +126,39 @@ func NewGame(params Params) *Game {
 	actions.RegisterBasicActions(actionManager)
 	achievementManager := achievements.NewAchievementManager(achievementConfig, params.EventManager)
 	abilitiesManager := abilities.NewAbilitiesManager(actionManager.GetActions(), params.EventManager)
-	// Load abilities from a JSON file
-	err = abilitiesManager.LoadAbilities("game/abilities.json")
-	if err != nil {
+	// Load abilities from a JSON file; with hot reload a broken file is
+	// reported in game until it is fixed
+	reloader := newReloader(params.Settings)
+	err = abilitiesManager.LoadAbilities(AbilitiesFile)
+	if reloader != nil {
+		reloader.Report(AbilitiesFile, err)
+	} else if err != nil {
 		log.Fatalf("Failed to load abilities: %v", err)
 	}
-	chapterIntro := chapterintro.NewChapterIntro("Soccer Match - Score Goals to Win!", interfaces.Vector2D{X: 100, Y: 400}, params.PhysicsEngine)
 
-	// Configure the PlatformGenerator (now used for soccer players)
+	// Configure the PlatformGenerator
 	platformGenConfig := gameMap.PlatformGeneratorConfig{
-		MinPlatformDistance: 100,
+		MinPlatformDistance: 50,
 		MaxPlatformDistance: 150,
-		PlatformWidth:       30, // Smaller for player representations
-		PlatformHeight:      30, // Square for player representations
+		PlatformWidth:       100,
+		PlatformHeight:      20,
 		ScreenWidth:         float64(params.ScreenWidth),
 		ScreenHeight:        float64(params.ScreenHeight),
+		Seed:                gameMap.GeneratorSeed(params.Settings),
 	}
 	platformGenerator := gameMap.NewPlatformGenerator(platformGenConfig, params.PhysicsEngine)
 
-	// Initialize the game map (soccer field) with the PlatformGenerator
+	// Initialize the game map with the PlatformGenerator; the game mode
+	// fills it
 	gameMapInstance := gameMap.NewMap(params.EventManager, params.ResourceManager, params.PhysicsEngine, platformGenerator)
 
-	// Create score manager for soccer game
-	scoreManager := score.NewScoreManager()
-	scoreManager.SetTeamName(0, "Blue Team")
-	scoreManager.SetTeamName(1, "Red Team")
-	scoreManager.StartMatch(90) // 90 second match
-
-	// Create HUD to display scores
+	// Create the HUD; modes with a score hand it over
 	// Note: This part assumes we have font loading - if not, this can be adjusted
-	hud := hud.NewHUD(scoreManager, nil, params.ScreenWidth, params.ScreenHeight)
+	hud := hud.NewHUD(nil, nil, params.ScreenWidth, params.ScreenHeight)
+	hud.SetHealth(player.GetHealth())
 
 	game := &Game{
 		Player:             player,
-		Pet:                petInstance,
 		Background:         params.Background,
 		ScreenWidth:        params.ScreenWidth,
 		ScreenHeight:       params.ScreenHeight,
@@ -141,12 +172,21 @@ func NewGame(params Params) *Game {
 		InputHandler:       params.InputHandler,
 		AbilitiesManager:   abilitiesManager,
 		AchievementManager: achievementManager,
-		chapterIntro:       chapterIntro,
-		ScoreManager:       scoreManager,
 		HUD:                hud,
+		Editor:             editor.NewEditor(editor.Config{}, gameMapInstance, params.ItemManager),
+		reloader:           reloader,
+		startPosition:      player.GetPosition(),
+		endless:            params.EndlessLevels,
+		playerFactory:      params.PlayerFactory,
 	}
 
 	game.registerEventHandlers()
+	game.registerReloadHandlers()
+	game.setupCheckpoints()
+	game.setupLevels()
+	game.setupEnemies()
+	game.setupPet()
+	game.setupMode()
 
 	return game
 }
@@ -170,12 +210,63 @@ func (g *Game) registerEventHandlers() {
 	g.EventManager.RegisterHandler(interfaces.EventItemEquipped, func(event interfaces.Event) {
 		logrus.Info("Item equipped:", event.Payload)
 	})
+	g.EventManager.RegisterHandler(interfaces.EventCheckpointActivated, func(event interfaces.Event) {
+		logrus.Info("Checkpoint activated:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventPlayerDied, func(event interfaces.Event) {
+		logrus.Info("Player died:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventDamageTaken, func(event interfaces.Event) {
+		logrus.Info("Damage taken:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventGameOver, func(event interfaces.Event) {
+		logrus.Info("Game over")
+	})
+	g.EventManager.RegisterHandler(interfaces.EventLevelComplete, func(event interfaces.Event) {
+		logrus.Info("Level complete:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventEnemyDefeated, func(event interfaces.Event) {
+		logrus.Info("Enemy defeated:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventWaveStarted, func(event interfaces.Event) {
+		logrus.Info("Wave started:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventWaveCleared, func(event interfaces.Event) {
+		logrus.Info("Wave cleared:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventWavesComplete, func(event interfaces.Event) {
+		logrus.Info("Waves complete:", event.Payload)
+	})
+	g.EventManager.RegisterHandler(interfaces.EventPetLevelUp, func(event interfaces.Event) {
+		logrus.Info("Pet leveled up:", event.Payload)
+	})
 }
 
 // Update updates the game state.
 func (g *Game) Update() error {
 	deltaTime := 1.0 / 60.0 // Fixed time step for consistent physics
 
+	// F2 switches between playing and editing the level
+	if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		g.Editor.Toggle(g.Camera)
+		// The checkpoints and the exit may have been edited
+		g.checkpointsReady = false
+		g.levelReady = false
+	}
+	if g.Editor.IsActive() {
+		return g.Editor.Update()
+	}
+	// F3 shows where the enemies can go
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		g.showNavigation = !g.showNavigation
+	}
+	g.updateHotReload()
+
+	// Everything waits while the mode holds the world still
+	if g.mode.Hold(deltaTime) {
+		return nil
+	}
+
 	g.chapterIntro.Update(deltaTime)
 	// Update the input handler
 	if err := g.InputHandler.Update(); err != nil {
@@ -185,58 +276,24 @@ func (g *Game) Update() error {
 	if err := g.Player.Update(deltaTime); err != nil {
 		return err
 	}
+	if err := g.updatePlayers(deltaTime); err != nil {
+		return err
+	}
 	if err := g.Pet.Update(deltaTime); err != nil {
 		return err
 	}
 	g.GameMap.Update(deltaTime)
 
-	// Update camera to follow the player
-	g.Camera.Follow(g.Player.GetPosition().X, g.Player.GetPosition().Y)
+	// Update camera to follow the players
+	g.followPlayers()
 
 	g.PhysicsEngine.Update(deltaTime)
 	g.AchievementManager.Update()
 	g.AbilitiesManager.Update(deltaTime)
 
-	// Update match timer and score for soccer game
-	if g.ScoreManager.IsMatchActive() {
-		g.matchTimer += deltaTime
-		// Update every second
-		if g.matchTimer >= 1.0 {
-			g.ScoreManager.UpdateMatchTime(1)
-			g.matchTimer = 0
-		}
-
-		// Check for goal scoring - detect if ball enters goal areas
-		for _, item := range g.GameMap.GetItems() {
-			// Need to cast the RigidBody interface to the concrete type
-			if rb, ok := item.RigidBody.(*physics.RigidBody); ok {
-				// Check if item is in left goal area
-				if rb.Position.X <= 20 &&
-					rb.Position.Y >= 225 &&
-					rb.Position.Y <= 375 {
-					// Score for right team (away/red)
-					g.ScoreManager.AddGoal(1)
-					// Reset ball position
-					rb.Position.X = 400
-					rb.Position.Y = 300
-					rb.Velocity.X = 0
-					rb.Velocity.Y = 0
-				}
-
-				// Check if item is in right goal area
-				if rb.Position.X >= 780 &&
-					rb.Position.Y >= 225 &&
-					rb.Position.Y <= 375 {
-					// Score for left team (home/blue)
-					g.ScoreManager.AddGoal(0)
-					// Reset ball position
-					rb.Position.X = 400
-					rb.Position.Y = 300
-					rb.Velocity.X = 0
-					rb.Velocity.Y = 0
-				}
-			}
-		}
+	// The mode applies its rules to the world as it is after this frame
+	if err := g.mode.Update(deltaTime); err != nil {
+		return err
 	}
 
 	return nil
@@ -245,23 +302,33 @@ func (g *Game) Update() error {
 // Draw draws the game on the screen.
 func (g *Game) Draw(screen *ebiten.Image) {
 	screen.Clear()
+	if g.Editor.IsActive() {
+		g.Editor.Draw(screen)
+		g.drawReloadErrors(screen)
+		return
+	}
 	options := &ebiten.DrawImageOptions{}
 	g.Camera.Apply(options)
 	g.GameMap.Draw(screen, g.Camera)
+	g.mode.Draw(screen, g.Camera)
 	g.chapterIntro.Draw(screen, g.Camera)
 	g.AchievementManager.Draw(screen)
 
 	if err := g.Player.Draw(screen, g.Camera); err != nil {
 		log.Printf("could not draw player %v", err)
 	}
+	g.drawPlayers(screen)
 	if err := g.Pet.Draw(screen, g.Camera); err != nil {
 		log.Printf("could not draw pet %v", err)
 	}
+	g.GameMap.DrawForeground(screen, g.Camera)
 
 	// Draw the HUD with score information
 	if g.HUD != nil {
 		g.HUD.Draw(screen)
 	}
+	g.checkpoints.DrawTransition(screen)
+	g.drawReloadErrors(screen)
 }
 
 // Layout sets the screen layout dimensions.
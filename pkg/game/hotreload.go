@@ -0,0 +1,86 @@
+package game
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/hotreload"
+)
+
+// Game data files, relative to the assets directory.
+const (
+	ItemsFile      = "game/items.json"
+	AbilitiesFile  = "game/abilities.json"
+	LevelsFile     = "game/levels.json"
+	FormationsFile = "game/formations.json"
+	TeamsFile      = "game/teams.json"
+	BehaviorsFile  = "game/behaviors.json"
+)
+
+// hotReloadInterval is how often watched files are checked for changes.
+const hotReloadInterval = time.Second
+
+// newReloader returns a reloader when the "hotReload" setting is on, or nil.
+func newReloader(settings interfaces.Settings) *hotreload.Reloader {
+	value, err := settings.Get("hotReload")
+	if enabled, ok := value.(bool); err != nil || !ok || !enabled {
+		return nil
+	}
+	watcher := hotreload.NewWatcher(hotReloadInterval)
+	watcher.Start()
+	return hotreload.NewReloader(watcher)
+}
+
+// registerReloadHandlers watches the game data files.
+func (g *Game) registerReloadHandlers() {
+	if g.reloader == nil {
+		return
+	}
+	g.reloader.Handle(ItemsFile, g.reloadItems)
+	g.reloader.Handle(AbilitiesFile, g.reloadAbilities)
+}
+
+// updateHotReload applies the changes found since the last frame. The level
+// on the map is watched as well, whichever it is at the moment.
+func (g *Game) updateHotReload() {
+	if g.reloader == nil {
+		return
+	}
+	if levelMap, ok := g.GameMap.(*gameMap.Map); ok && levelMap.LevelPath() != "" {
+		g.reloader.Handle(levelMap.LevelPath(), g.reloadLevel)
+	}
+	g.reloader.Update()
+}
+
+// drawReloadErrors shows the files that failed to reload.
+func (g *Game) drawReloadErrors(screen *ebiten.Image) {
+	if g.reloader != nil {
+		g.reloader.Draw(screen)
+	}
+}
+
+func (g *Game) reloadItems(path string) error {
+	if err := g.ItemManager.LoadItems(path); err != nil {
+		return err
+	}
+	if levelMap, ok := g.GameMap.(*gameMap.Map); ok {
+		levelMap.ResolveItems()
+	}
+	return nil
+}
+
+func (g *Game) reloadAbilities(path string) error {
+	return g.AbilitiesManager.LoadAbilities(path)
+}
+
+// reloadLevel replaces the level on the map if it is still the one at path.
+// The player is not part of the map, so it stays where it was.
+func (g *Game) reloadLevel(path string) error {
+	levelMap, ok := g.GameMap.(*gameMap.Map)
+	if !ok || levelMap.LevelPath() != path {
+		return nil
+	}
+	return levelMap.LoadLevel(path)
+}
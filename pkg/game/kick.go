@@ -0,0 +1,136 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/kick"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	"github.com/joaorufino/gopher-game/pkg/player"
+)
+
+// The trajectory hint shows where the ball goes over the next kickHintTime
+// seconds, in kickHintPoints dots.
+const (
+	kickHintTime   = 0.6
+	kickHintPoints = 12
+)
+
+// playerKick lets a player charge kicks by holding the kick button, aim
+// them with the direction keys or the mouse, and kick the nearest ball within
+// reach on release. Only the first player aims with the mouse.
+type playerKick struct {
+	game    *Game
+	index   int
+	team    string
+	player  interfaces.Player
+	input   interfaces.InputHandler
+	charger *kick.Charger
+	aim     interfaces.Vector2D
+	mouseX  int
+	mouseY  int
+}
+
+// newPlayerKick lets the player with the given index, controlled by input,
+// kick for team.
+func newPlayerKick(g *Game, index int, team string, p interfaces.Player, input interfaces.InputHandler) *playerKick {
+	k := &playerKick{game: g, index: index, team: team, player: p, input: input, charger: kick.NewCharger(kick.DefaultConfig()), aim: interfaces.Vector2D{X: 1}}
+	k.mouseX, k.mouseY = input.GetMousePosition()
+	if team == gameMap.TeamRed {
+		// The red team attacks to the left
+		k.aim.X = -1
+	}
+	return k
+}
+
+// update aims and charges the kick, kicking one of balls once released.
+func (k *playerKick) update(deltaTime float64, balls []*physics.RigidBody) {
+	k.updateAim()
+	power, kicked := k.charger.Update(deltaTime, k.input.IsKickPressed())
+	if !kicked {
+		return
+	}
+	ball, ok := k.ballInReach(balls)
+	if !ok {
+		return
+	}
+	ball.Velocity = kick.Velocity(k.aim, power)
+	k.game.EventManager.Dispatch(kick.Event(k.team, player.Identifier(k.index), kick.KindKick, bodyCenter(ball), ball.Velocity))
+}
+
+// updateAim points the kick along the direction keys held, or at the cursor
+// when the mouse moves.
+func (k *playerKick) updateAim() {
+	input := k.input
+	var keys interfaces.Vector2D
+	if input.IsLeftPressed() {
+		keys.X--
+	}
+	if input.IsRightPressed() {
+		keys.X++
+	}
+	if input.IsUpPressed() {
+		keys.Y--
+	}
+	if input.IsDownPressed() {
+		keys.Y++
+	}
+	if keys != (interfaces.Vector2D{}) {
+		k.aim = keys
+		return
+	}
+
+	x, y := input.GetMousePosition()
+	if k.index > 0 || (x == k.mouseX && y == k.mouseY) {
+		return
+	}
+	k.mouseX, k.mouseY = x, y
+	offsetX, offsetY := k.game.Camera.GetOffset()
+	from := rectCenter(k.rect())
+	if aim := (interfaces.Vector2D{X: float64(x) + offsetX - from.X, Y: float64(y) + offsetY - from.Y}); aim != (interfaces.Vector2D{}) {
+		k.aim = aim
+	}
+}
+
+// ballInReach returns the ball within reach closest to the player.
+func (k *playerKick) ballInReach(balls []*physics.RigidBody) (*physics.RigidBody, bool) {
+	rects := make([]interfaces.Rect, len(balls))
+	for i, ball := range balls {
+		rects[i] = physics.BodyRect(ball)
+	}
+	nearest, ok := kick.Nearest(k.rect(), rects, k.charger.Reach())
+	if !ok {
+		return nil, false
+	}
+	return balls[nearest], true
+}
+
+// draw shows the power meter and, from the ball about to be kicked, the path
+// it would take.
+func (k *playerKick) draw(screen *ebiten.Image, camera interfaces.Camera, balls []*physics.RigidBody) {
+	if !k.charger.Charging() {
+		return
+	}
+	var path []interfaces.Vector2D
+	if ball, ok := k.ballInReach(balls); ok {
+		gravity := 0.0
+		if engine, ok := k.game.PhysicsEngine.(*physics.PhysicsEngine); ok {
+			gravity = physics.EffectiveGravity(engine.Gravity().Y)
+		}
+		path = kick.Trajectory(bodyCenter(ball), kick.Velocity(k.aim, k.charger.Power()), gravity, kickHintTime, kickHintPoints)
+	}
+	k.charger.Draw(screen, camera, k.rect(), path)
+}
+
+// rect returns the area the kicking player covers.
+func (k *playerKick) rect() interfaces.Rect {
+	return interfaces.Rect{Position: k.player.GetPosition(), Size: k.player.GetSize()}
+}
+
+func bodyCenter(body *physics.RigidBody) interfaces.Vector2D {
+	return rectCenter(physics.BodyRect(body))
+}
+
+func rectCenter(rect interfaces.Rect) interfaces.Vector2D {
+	return interfaces.Vector2D{X: rect.Position.X + rect.Size.X/2, Y: rect.Position.Y + rect.Size.Y/2}
+}
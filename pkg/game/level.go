@@ -0,0 +1,160 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/level"
+)
+
+// endlessLevel is the "level" setting that starts endless mode.
+const endlessLevel = "endless"
+
+// setupLevels tracks the progress through the levels the career mode plays.
+func (g *Game) setupLevels() {
+	g.progress = level.NewProgress(g.EventManager)
+}
+
+// loadLevels loads the level list unless it is loaded already.
+func (g *Game) loadLevels() error {
+	if g.levels != nil {
+		return nil
+	}
+	levels, err := level.LoadList(LevelsFile)
+	if err != nil {
+		return err
+	}
+	g.levels = levels
+	return nil
+}
+
+// startLevel loads the level with the given name and puts the player at its
+// start.
+func (g *Game) startLevel(name string) error {
+	levelMap, ok := g.GameMap.(*gameMap.Map)
+	if !ok {
+		return fmt.Errorf("the map cannot load levels")
+	}
+	if name == endlessLevel {
+		if err := g.endless.GenerateNextLevel(); err != nil {
+			return err
+		}
+		levelMap.ApplyLevel(g.endless.Level())
+		g.enterLevel(fmt.Sprintf("Endless %d", g.endless.Number()))
+		return nil
+	}
+
+	if err := g.loadLevels(); err != nil {
+		return err
+	}
+	info, ok := g.levels.Find(name)
+	if !ok {
+		return fmt.Errorf("level %q is not listed in %s", name, LevelsFile)
+	}
+	if info.File == "" {
+		return fmt.Errorf("level %q has no file", name)
+	}
+	if err := levelMap.LoadLevel(info.File); err != nil {
+		return err
+	}
+	g.enterLevel(name)
+	return nil
+}
+
+// startLevelFile loads the level at levelPath, named after the level list
+// entry of the file if there is one.
+func (g *Game) startLevelFile(levelPath string) error {
+	levelMap, ok := g.GameMap.(*gameMap.Map)
+	if !ok {
+		return fmt.Errorf("the map cannot load levels")
+	}
+	if err := levelMap.LoadLevel(levelPath); err != nil {
+		return err
+	}
+	name := levelPath
+	if g.levels != nil {
+		for _, info := range g.levels.Levels {
+			if info.File == levelPath {
+				name = info.Name
+			}
+		}
+	}
+	g.enterLevel(name)
+	return nil
+}
+
+// enterLevel starts tracking the level just put on the map and moves the
+// player to its start.
+func (g *Game) enterLevel(name string) {
+	g.levelName = name
+	g.levelReady = false
+	g.syncLevel()
+	g.respawnPlayer(interfaces.Rect{Position: g.progress.Level().GetStartVector2D()})
+}
+
+// syncLevel starts tracking the level on the map, links its platforms for
+// the enemies to find their way and places them, whenever it changes.
+func (g *Game) syncLevel() {
+	levelMap, ok := g.GameMap.(*gameMap.Map)
+	if !ok {
+		return
+	}
+	data := levelMap.Level()
+	if g.levelReady && data == g.levelData {
+		return
+	}
+	g.levelReady = true
+	g.levelData = data
+	g.rebuildNavigation()
+	if data == nil {
+		g.progress.Start(nil)
+		g.spawnEnemies(nil, nil)
+		return
+	}
+	start, ok := data.Start()
+	if !ok {
+		start = g.startPosition
+	}
+	exit, _ := data.Exit()
+	current := level.NewLevel(g.levelName, start, exit.Body)
+	g.progress.Start(current)
+	g.spawnEnemies(data, current)
+}
+
+// updateLevel times the level and hands off to the next one once the player
+// reaches the exit: the level file named by the exit, or else the next level
+// of endless mode or of the level list.
+func (g *Game) updateLevel(deltaTime float64) {
+	g.syncLevel()
+	if !g.progress.Update(deltaTime, g.playerRect()) {
+		return
+	}
+
+	var err error
+	exit, _ := g.levelData.Exit()
+	if next, ok := exit.Properties["next"].(string); ok && next != "" {
+		levelMap := g.GameMap.(*gameMap.Map)
+		// Like the images of a level, the next file is relative to the level.
+		err = g.startLevelFile(path.Join(path.Dir(levelMap.LevelPath()), next))
+	} else if g.endless.Number() > 0 {
+		err = g.startLevel(endlessLevel)
+	} else if info, ok := g.nextListed(); ok {
+		err = g.startLevel(info.Name)
+	} else {
+		log.Printf("completed the last level %q", g.levelName)
+	}
+	if err != nil {
+		log.Printf("could not start the level after %q: %v", g.levelName, err)
+	}
+}
+
+// nextListed returns the level after the current one in the level list.
+func (g *Game) nextListed() (level.Info, bool) {
+	if g.levels == nil {
+		return level.Info{}, false
+	}
+	return g.levels.Next(g.levelName)
+}
@@ -0,0 +1,18 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+)
+
+// interactKey flips the switch the player stands at.
+const interactKey = ebiten.KeyE
+
+// updateLogic drives the switches, plates, doors and other logic objects of
+// the level on the map.
+func (g *Game) updateLogic(deltaTime float64) {
+	if levelMap, ok := g.GameMap.(*gameMap.Map); ok {
+		levelMap.UpdateLogic(deltaTime, g.playerRect(), inpututil.IsKeyJustPressed(interactKey))
+	}
+}
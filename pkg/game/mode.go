@@ -0,0 +1,87 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/chapterintro"
+)
+
+// Names of the game modes, as given to the "mode" setting.
+const (
+	ModeSoccer     = "soccer"
+	ModeCareer     = "career"
+	ModeTournament = "tournament"
+)
+
+// modes creates the game modes by name. A new mode only needs an entry here.
+var modes = map[string]func(g *Game) interfaces.GameMode{
+	ModeSoccer:     newSoccerMode,
+	ModeCareer:     newCareerMode,
+	ModeTournament: newTournamentMode,
+}
+
+// ModeNames returns the names of the game modes, e.g. for a menu.
+func ModeNames() []string {
+	names := make([]string, 0, len(modes))
+	for name := range modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setupMode starts the mode named by the "mode" setting. Without the setting
+// the game plays the career when a level is asked for and soccer otherwise.
+func (g *Game) setupMode() {
+	name := ModeSoccer
+	if g.stringSetting("level") != "" {
+		name = ModeCareer
+	}
+	if mode := g.stringSetting("mode"); mode != "" {
+		name = mode
+	}
+	if err := g.SetMode(name); err != nil {
+		log.Printf("could not start mode %q, playing %s: %v", name, ModeSoccer, err)
+		if err := g.SetMode(ModeSoccer); err != nil {
+			log.Fatalf("Failed to start %s: %v", ModeSoccer, err)
+		}
+	}
+}
+
+// stringSetting returns the setting with the given key, or "" when it is not
+// set or not a string.
+func (g *Game) stringSetting(key string) string {
+	value, _ := g.Settings.Get(key)
+	text, _ := value.(string)
+	return text
+}
+
+// SetMode tears the current mode down and starts the one with the given name.
+func (g *Game) SetMode(name string) error {
+	newMode, ok := modes[name]
+	if !ok {
+		return fmt.Errorf("unknown game mode %q", name)
+	}
+	if g.mode != nil {
+		g.mode.Teardown()
+	}
+	if g.chapterIntro != nil {
+		g.chapterIntro.Close()
+	}
+	g.mode = newMode(g)
+	if err := g.mode.Setup(); err != nil {
+		return err
+	}
+	g.chapterIntro = chapterintro.NewChapterIntro(g.mode.Rules(), interfaces.Vector2D{X: 100, Y: 400}, g.PhysicsEngine)
+	// The map changed under the checkpoints
+	g.checkpointsReady = false
+	return nil
+}
+
+// Mode returns the mode being played.
+func (g *Game) Mode() interfaces.GameMode {
+	return g.mode
+}
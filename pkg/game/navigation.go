@@ -0,0 +1,85 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/ai"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// linkColors are the colors links are drawn with, by kind.
+var linkColors = map[navigation.LinkKind]color.RGBA{
+	navigation.LinkWalk: {50, 205, 50, 255},
+	navigation.LinkJump: {255, 215, 0, 255},
+	navigation.LinkFall: {0, 191, 255, 255},
+}
+
+// Colors of the tops of the platforms and of the way each enemy is
+// following.
+var (
+	nodeColor = color.RGBA{128, 128, 128, 255}
+	planColor = color.RGBA{255, 255, 255, 255}
+)
+
+// setupNavigation creates the graph enemies find their way with. It is built
+// for enemies of the default size and jump.
+func (g *Game) setupNavigation() {
+	config := ai.EnemyConfig{Gravity: g.engineGravity()}
+	g.navigation = navigation.NewGraph(config.Navigation(defaultEnemySize))
+}
+
+// rebuildNavigation links the platforms of the map, for the enemies and for
+// the pet.
+func (g *Game) rebuildNavigation() {
+	platforms := enemySenses{game: g}.Platforms()
+	g.navigation.Rebuild(platforms)
+	g.petNavigation.Rebuild(platforms)
+}
+
+// drawNavigation draws the graph and the way each enemy and the pet are
+// following, while F3 shows them: the tops of the platforms, the links
+// between them from takeoff to landing and the plans, with a cross where
+// they jump.
+func (g *Game) drawNavigation(screen *ebiten.Image, camera interfaces.Camera) {
+	if !g.showNavigation {
+		return
+	}
+	offsetX, offsetY := camera.GetOffset()
+	line := func(from, to interfaces.Vector2D, cl color.RGBA) {
+		vector.StrokeLine(screen,
+			float32(from.X-offsetX), float32(from.Y-offsetY),
+			float32(to.X-offsetX), float32(to.Y-offsetY),
+			1, cl, true)
+	}
+	for _, platform := range g.navigation.Platforms() {
+		top := interfaces.Vector2D{X: platform.Position.X + platform.Size.X, Y: platform.Position.Y}
+		line(platform.Position, top, nodeColor)
+	}
+	for _, link := range g.navigation.Links() {
+		line(link.Takeoff, link.Landing, linkColors[link.Kind])
+	}
+	drawPlan := func(body *physics.RigidBody, plan *navigation.Plan) {
+		if plan == nil {
+			return
+		}
+		from := interfaces.Vector2D{X: body.Position.X + body.Size.X/2, Y: body.Position.Y + body.Size.Y}
+		for _, step := range plan.Steps {
+			if step.Kind == navigation.StepJump {
+				line(from, step.At, planColor)
+				line(interfaces.Vector2D{X: step.At.X - 4, Y: step.At.Y - 4}, interfaces.Vector2D{X: step.At.X + 4, Y: step.At.Y + 4}, planColor)
+				line(interfaces.Vector2D{X: step.At.X - 4, Y: step.At.Y + 4}, interfaces.Vector2D{X: step.At.X + 4, Y: step.At.Y - 4}, planColor)
+				from = step.At
+			}
+			line(from, step.To, planColor)
+			from = step.To
+		}
+	}
+	for _, enemy := range g.enemyList() {
+		drawPlan(enemy.Body, enemy.Plan())
+	}
+	drawPlan(g.Pet.RigidBody, g.Pet.Plan())
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
+	"github.com/joaorufino/gopher-game/pkg/pet"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	"github.com/joaorufino/gopher-game/pkg/player"
+)
+
+// Keys the player commands the pet with.
+const (
+	petStayKey   = ebiten.KeyQ // Stay here, or follow again
+	petFetchKey  = ebiten.KeyF // Fetch the nearest item
+	petGoToKey   = ebiten.KeyG // Go to the cursor
+	petAttackKey = ebiten.KeyR // Attack the nearest enemy
+)
+
+// petSenses tells the pet where the platforms, obstacles, items, enemies and
+// hazards are.
+type petSenses struct {
+	game *Game
+}
+
+// Platforms returns the platforms of the level and the generated ones.
+func (s petSenses) Platforms() []interfaces.Rect {
+	return enemySenses{game: s.game}.Platforms()
+}
+
+// Obstacles returns the solid obstacles of the level.
+func (s petSenses) Obstacles() []interfaces.Rect {
+	levelMap, ok := s.game.GameMap.(*gameMap.Map)
+	if !ok {
+		return nil
+	}
+	var obstacles []interfaces.Rect
+	for _, obstacle := range levelMap.Obstacles {
+		if obstacle.RigidBody != nil && obstacle.RigidBody.IsCollidable {
+			obstacles = append(obstacles, physics.BodyRect(obstacle.RigidBody))
+		}
+	}
+	return obstacles
+}
+
+// Items asks the physics engine for the items that can be picked up within
+// radius of point. The item the pet carries is out of the engine.
+func (s petSenses) Items(point interfaces.Vector2D, radius float64) []interfaces.Rect {
+	area := interfaces.Rect{
+		Position: interfaces.Vector2D{X: point.X - radius, Y: point.Y - radius},
+		Size:     interfaces.Vector2D{X: 2 * radius, Y: 2 * radius},
+	}
+	var items []interfaces.Rect
+	for _, found := range s.game.PhysicsEngine.QueryArea(area) {
+		body, ok := found.(*physics.RigidBody)
+		if !ok || !body.IsPickable {
+			continue
+		}
+		rect := physics.BodyRect(body)
+		if middle := center(rect); math.Hypot(middle.X-point.X, middle.Y-point.Y) <= radius {
+			items = append(items, rect)
+		}
+	}
+	return items
+}
+
+// Enemies returns the enemies the AI moves.
+func (s petSenses) Enemies() []interfaces.Rect {
+	var enemies []interfaces.Rect
+	for _, enemy := range s.game.enemyList() {
+		enemies = append(enemies, physics.BodyRect(enemy.Body))
+	}
+	return enemies
+}
+
+// Hazards returns the hazards and the harmful obstacles of the level.
+func (s petSenses) Hazards() []interfaces.Rect {
+	levelMap, ok := s.game.GameMap.(*gameMap.Map)
+	if !ok {
+		return nil
+	}
+	var hazards []interfaces.Rect
+	for _, obstacle := range levelMap.Obstacles {
+		if obstacle.RigidBody != nil && obstacle.Damage != nil {
+			hazards = append(hazards, physics.BodyRect(obstacle.RigidBody))
+		}
+	}
+	for _, hazard := range levelMap.Hazards {
+		hazards = append(hazards, hazard.Body)
+	}
+	return hazards
+}
+
+// setupPet puts the pet next to the player, with a navigation graph of its
+// own as it is smaller than the enemies and jumps differently. The pet grows
+// into the abilities of the ArgoCD Pet item, and gains them all at once when
+// the player picks the item up.
+func (g *Game) setupPet() {
+	position := g.Player.GetPosition()
+	config := &pet.Configuration{ImageScale: 0.1, RunVelocity: 100, JumpVelocity: 80, Gravity: g.engineGravity()}
+	if item, err := g.ItemManager.GetItem(pet.ItemName); err == nil {
+		config.Abilities = item.GetAbilities()
+	} else {
+		log.Printf("pet takes the default abilities: %v", err)
+	}
+	g.Pet = pet.NewPet(position.X, position.Y, g.ResourceManager, config, g.PhysicsEngine, g.Player, petSenses{game: g})
+	g.petNavigation = navigation.NewGraph(config.Navigation(g.Pet.RigidBody.Size))
+	g.Pet.SetGraph(g.petNavigation)
+
+	growth := g.Pet.Growth()
+	g.EventManager.RegisterHandler(interfaces.EventItemEquipped, func(event interfaces.Event) {
+		payload, ok := event.Payload.(map[string]interface{})
+		if ok && payload["itemName"] == pet.ItemName && payload["picker"] == player.Identifier(0) {
+			growth.UnlockAll()
+		}
+	})
+}
+
+// updatePet passes the commands of the player on to the pet and carries out
+// what it did this frame: picking items up, handing them to the player and
+// biting enemies. It announces when the pet levels up.
+func (g *Game) updatePet() {
+	g.commandPet()
+
+	action := g.Pet.Action()
+	switch action.Kind {
+	case pet.ActionPick:
+		g.pickUpForPet(action.Target)
+	case pet.ActionDeliver:
+		g.deliverPetItem()
+	case pet.ActionBite:
+		for _, enemy := range g.enemyList() {
+			if physics.BodyRect(enemy.Body) == action.Target {
+				g.defeatEnemy(enemy, "pet")
+				break
+			}
+		}
+	}
+	if g.Pet.LeveledUp() {
+		growth := g.Pet.Growth()
+		g.EventManager.Dispatch(interfaces.Event{
+			Type:    interfaces.EventPetLevelUp,
+			Payload: map[string]interface{}{"level": growth.Level(), "abilities": growth.Unlocked()},
+		})
+	}
+	g.carryPetItem()
+}
+
+// commandPet gives the pet the command of the key just pressed.
+func (g *Game) commandPet() {
+	var command pet.Command
+	switch {
+	case inpututil.IsKeyJustPressed(petStayKey):
+		command.Kind = pet.CommandStay
+		if g.Pet.State() == pet.StateStay {
+			command.Kind = pet.CommandFollow
+		}
+	case inpututil.IsKeyJustPressed(petFetchKey):
+		command.Kind = pet.CommandFetch
+	case inpututil.IsKeyJustPressed(petGoToKey):
+		x, y := g.InputHandler.GetMousePosition()
+		offsetX, offsetY := g.Camera.GetOffset()
+		command = pet.Command{Kind: pet.CommandGoTo, Point: interfaces.Vector2D{X: float64(x) + offsetX, Y: float64(y) + offsetY}}
+	case inpututil.IsKeyJustPressed(petAttackKey):
+		command.Kind = pet.CommandAttack
+	default:
+		return
+	}
+	if !g.Pet.Command(command) {
+		log.Printf("pet has nothing to %s", command.Kind)
+	}
+}
+
+// pickUpForPet takes the item at area out of the physics engine, so nobody
+// else picks it up while the pet carries it.
+func (g *Game) pickUpForPet(area interfaces.Rect) {
+	for _, item := range g.GameMap.GetItems() {
+		body, ok := item.RigidBody.(*physics.RigidBody)
+		if ok && physics.BodyRect(body) == area {
+			g.PhysicsEngine.RemoveRigidBody(body)
+			g.petItem = body
+			return
+		}
+	}
+}
+
+// deliverPetItem hands the item the pet carries to the player, as if the
+// player had picked it up.
+func (g *Game) deliverPetItem() {
+	if g.petItem == nil {
+		return
+	}
+	g.EventManager.Dispatch(interfaces.Event{
+		Type:     interfaces.EventItemEquipped,
+		Priority: 1,
+		Payload: map[string]interface{}{
+			"itemName": g.petItem.GetIdentifier(),
+			"picker":   player.Identifier(0),
+		},
+	})
+	g.petItem = nil
+}
+
+// carryPetItem holds the item the pet carries over its head. An item the pet
+// dropped, because it was told to do something else, goes back into the
+// physics engine where the pet is; an item gone from the map, e.g. with the
+// level, is forgotten.
+func (g *Game) carryPetItem() {
+	if g.petItem == nil {
+		return
+	}
+	onMap := false
+	for _, item := range g.GameMap.GetItems() {
+		onMap = onMap || item.RigidBody == g.petItem
+	}
+	if !onMap {
+		g.petItem = nil
+		if g.Pet.Carrying() {
+			g.Pet.Command(pet.Command{Kind: pet.CommandFollow})
+		}
+		return
+	}
+	body := g.Pet.RigidBody
+	above := interfaces.Vector2D{
+		X: body.Position.X + body.Size.X/2 - g.petItem.Size.X/2,
+		Y: body.Position.Y - g.petItem.Size.Y,
+	}
+	if g.Pet.Carrying() {
+		g.petItem.Teleport(above)
+		return
+	}
+	g.petItem.Teleport(interfaces.Vector2D{X: above.X, Y: body.Position.Y + body.Size.Y - g.petItem.Size.Y})
+	g.PhysicsEngine.AddRigidBody(g.petItem)
+	g.petItem = nil
+}
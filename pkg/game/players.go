@@ -0,0 +1,181 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/input"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	"github.com/joaorufino/gopher-game/pkg/player"
+)
+
+// PlayerFactory creates the player with the given index, counted from 0,
+// standing at position, and the input handler that controls it. Players after
+// the first share the screen with it.
+type PlayerFactory func(index int, position interfaces.Vector2D) (interfaces.Player, interfaces.InputHandler)
+
+// localPlayer is a player sharing the screen with the first one, with an
+// input handler of its own.
+type localPlayer struct {
+	player interfaces.Player
+	input  interfaces.InputHandler
+	active bool
+}
+
+// localPlayerCount returns how many players share the screen, from the
+// "players" setting.
+func (g *Game) localPlayerCount() int {
+	count, err := strconv.Atoi(g.stringSetting("players"))
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
+
+// joinPlayer brings the player with the given index, from 1, into the game
+// standing at position. The players created once are kept for the next time
+// they join, as their input handlers stay registered.
+func (g *Game) joinPlayer(index int, position interfaces.Vector2D) (interfaces.Player, interfaces.InputHandler, error) {
+	if index < 1 {
+		return nil, nil, fmt.Errorf("player %d is not a local player", index+1)
+	}
+	if g.playerFactory == nil {
+		return nil, nil, fmt.Errorf("the game cannot create more players")
+	}
+	for len(g.localPlayers) < index {
+		created, handler := g.playerFactory(len(g.localPlayers)+1, position)
+		g.localPlayers = append(g.localPlayers, &localPlayer{player: created, input: handler, active: true})
+	}
+	local := g.localPlayers[index-1]
+	if !local.active {
+		if body, ok := playerBody(local.player); ok {
+			g.PhysicsEngine.AddRigidBody(body)
+		}
+		local.active = true
+	}
+	local.player.GetHealth().Revive()
+	local.player.SetPosition(position)
+
+	// The first player gives up the keys of the others
+	if handler, ok := g.InputHandler.(*input.InputHandler); ok {
+		handler.SetScheme(input.PlayerOneScheme())
+	}
+	return local.player, local.input, nil
+}
+
+// leavePlayers takes every player but the first out of the game.
+func (g *Game) leavePlayers() {
+	for _, local := range g.localPlayers {
+		if !local.active {
+			continue
+		}
+		if body, ok := playerBody(local.player); ok {
+			g.PhysicsEngine.RemoveRigidBody(body)
+		}
+		local.active = false
+	}
+	if handler, ok := g.InputHandler.(*input.InputHandler); ok {
+		handler.SetScheme(input.DefaultScheme())
+	}
+}
+
+// updatePlayers reads the input of the players sharing the screen and moves
+// them.
+func (g *Game) updatePlayers(deltaTime float64) error {
+	for _, local := range g.localPlayers {
+		if !local.active {
+			continue
+		}
+		if err := local.input.Update(); err != nil {
+			return err
+		}
+		if err := local.player.Update(deltaTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawPlayers draws the players sharing the screen.
+func (g *Game) drawPlayers(screen *ebiten.Image) {
+	for i, local := range g.localPlayers {
+		if !local.active {
+			continue
+		}
+		if err := local.player.Draw(screen, g.Camera); err != nil {
+			log.Printf("could not draw player %d %v", i+2, err)
+		}
+	}
+}
+
+// followPlayers points the camera at the middle of the box around all the
+// players in the game. Players sharing the screen cannot walk out of it: they
+// are held at its edges.
+func (g *Game) followPlayers() {
+	players := []interfaces.Player{g.Player}
+	for _, local := range g.localPlayers {
+		if local.active {
+			players = append(players, local.player)
+		}
+	}
+	low := g.Player.GetPosition()
+	high := low
+	for _, p := range players {
+		position, size := p.GetPosition(), p.GetSize()
+		low.X = math.Min(low.X, position.X)
+		low.Y = math.Min(low.Y, position.Y)
+		high.X = math.Max(high.X, position.X+size.X)
+		high.Y = math.Max(high.Y, position.Y+size.Y)
+	}
+	center := interfaces.Vector2D{X: (low.X + high.X) / 2, Y: (low.Y + high.Y) / 2}
+	g.Camera.Follow(center.X, center.Y)
+
+	if len(players) < 2 {
+		return
+	}
+	view := interfaces.Rect{
+		Position: interfaces.Vector2D{X: center.X - float64(g.ScreenWidth)/2, Y: center.Y - float64(g.ScreenHeight)/2},
+		Size:     interfaces.Vector2D{X: float64(g.ScreenWidth), Y: float64(g.ScreenHeight)},
+	}
+	for _, p := range players {
+		if body, ok := playerBody(p); ok {
+			keepInView(body, view)
+		}
+	}
+}
+
+// keepInView moves a body back inside view and stops it moving further out.
+func keepInView(body *physics.RigidBody, view interfaces.Rect) {
+	right := view.Position.X + view.Size.X - body.Size.X
+	bottom := view.Position.Y + view.Size.Y - body.Size.Y
+	switch {
+	case body.Position.X < view.Position.X:
+		body.Position.X = view.Position.X
+		body.Velocity.X = math.Max(0, body.Velocity.X)
+	case body.Position.X > right:
+		body.Position.X = right
+		body.Velocity.X = math.Min(0, body.Velocity.X)
+	}
+	switch {
+	case body.Position.Y < view.Position.Y:
+		body.Position.Y = view.Position.Y
+		body.Velocity.Y = math.Max(0, body.Velocity.Y)
+	case body.Position.Y > bottom:
+		body.Position.Y = bottom
+		body.Velocity.Y = math.Min(0, body.Velocity.Y)
+	}
+}
+
+// playerBody returns the rigid body of a player, to take it in and out of
+// the physics engine.
+func playerBody(p interfaces.Player) (*physics.RigidBody, bool) {
+	local, ok := p.(*player.Player)
+	if !ok {
+		return nil, false
+	}
+	return local.RigidBody, true
+}
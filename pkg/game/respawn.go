@@ -0,0 +1,98 @@
+package game
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/checkpoint"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// setupCheckpoints creates the checkpoint manager. Pushed containers go back
+// to where they were when the active checkpoint was reached.
+func (g *Game) setupCheckpoints() {
+	g.checkpoints = checkpoint.NewManager(checkpoint.Config{}, g.EventManager, g.respawnPlayer)
+	g.checkpoints.Track(g.savePushables)
+	g.checkpoints.OnDeath(g.playerDied)
+}
+
+// syncCheckpoints hands the checkpoints of the level on the map to the
+// checkpoint manager whenever the level changes.
+func (g *Game) syncCheckpoints() {
+	levelMap, _ := g.GameMap.(*gameMap.Map)
+	var level *gameMap.LevelData
+	if levelMap != nil {
+		level = levelMap.Level()
+	}
+	if g.checkpointsReady && level == g.checkpointLevel {
+		return
+	}
+	g.checkpointsReady = true
+	g.checkpointLevel = level
+
+	start := interfaces.Rect{Position: g.startPosition, Size: g.Player.GetSize()}
+	fallY := math.Inf(1)
+	var points []checkpoint.Point
+	if level != nil {
+		if position, ok := level.Start(); ok {
+			start = interfaces.Rect{Position: position}
+		}
+		if limit, ok := level.FallLimit(); ok {
+			fallY = limit
+		}
+		for _, cp := range levelMap.Checkpoints {
+			points = append(points, checkpoint.Point{Name: cp.Name, Area: cp.Body})
+		}
+	}
+	// Landing on the engine floor means the player fell out of the world.
+	if engine, ok := g.PhysicsEngine.(*physics.PhysicsEngine); ok {
+		fallY = math.Min(fallY, engine.FloorY())
+	}
+	g.checkpoints.Reset(points, start, fallY)
+}
+
+// playerRect returns the area the player covers.
+func (g *Game) playerRect() interfaces.Rect {
+	return interfaces.Rect{Position: g.Player.GetPosition(), Size: g.Player.GetSize()}
+}
+
+// respawnPlayer stands the player in the middle of the bottom of area, with
+// the pet next to it, back at full health.
+func (g *Game) respawnPlayer(area interfaces.Rect) {
+	g.Player.GetHealth().Revive()
+	size := g.Player.GetSize()
+	position := interfaces.Vector2D{
+		X: area.Position.X + area.Size.X/2 - size.X/2,
+		Y: area.Position.Y + area.Size.Y - size.Y,
+	}
+	g.Player.SetPosition(position)
+	if g.Pet != nil {
+		petSize := g.Pet.RigidBody.GetSize()
+		g.Pet.SetPosition(interfaces.Vector2D{X: position.X - petSize.X, Y: position.Y + size.Y - petSize.Y})
+	}
+	g.Camera.Follow(position.X, position.Y)
+}
+
+// savePushables remembers where the pushable obstacles of the level are.
+func (g *Game) savePushables() func() {
+	levelMap, ok := g.GameMap.(*gameMap.Map)
+	if !ok {
+		return func() {}
+	}
+	type saved struct {
+		body     *physics.RigidBody
+		position interfaces.Vector2D
+	}
+	var bodies []saved
+	for _, obstacle := range levelMap.Obstacles {
+		if obstacle.RigidBody.IsPushable {
+			bodies = append(bodies, saved{obstacle.RigidBody, obstacle.RigidBody.Position})
+		}
+	}
+	return func() {
+		for _, b := range bodies {
+			b.body.Teleport(b.position)
+		}
+	}
+}
@@ -0,0 +1,462 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/ai"
+	"github.com/joaorufino/gopher-game/pkg/formation"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/player"
+	"github.com/joaorufino/gopher-game/pkg/score"
+	"github.com/joaorufino/gopher-game/pkg/stats"
+)
+
+// Penalties are taken penaltyDistance in front of the goal, and a penalty
+// that is not in after penaltyTime seconds is missed.
+const (
+	penaltyDistance = 120.0
+	penaltyTime     = 4.0
+)
+
+// teams are the teams of the field in the order of the score manager: blue
+// plays at home.
+var teams = [2]string{gameMap.TeamBlue, gameMap.TeamRed}
+
+// soccerMode plays a soccer match between the blue and the red team on a
+// field set up on the map. The players of both teams are AI agents; the
+// "difficulty" setting picks how well they play. The player joins the blue
+// team and kicks the ball with the kick button. With the "players" setting
+// at 2 a second player joins on the keyboard or a gamepad, on the team named
+// by the "player2Team" setting, red unless set.
+//
+// The score manager runs the phases of the match: the mode lines both teams
+// up for every kickoff, only lets them play while the ball is in play, and
+// sets every penalty of a shootout up.
+//
+// Both teams line up in formations from FormationsFile, picked with the
+// "blueFormation" and "redFormation" settings and changed with 1 and 2
+// before kickoff.
+//
+// The stats collector follows the match from the kick and phase events; at
+// full time the HUD shows its summary and the match goes into the history.
+type soccerMode struct {
+	game         *Game
+	levelMap     *gameMap.Map
+	scoreManager *score.ScoreManager
+	aiManager    *ai.Manager
+	players      []*ai.SoccerPlayer
+	humans       []*playerKick // The players at the keyboard, first one first
+	stats        *stats.Collector
+	formations   *formation.List
+	lineUps      [2]formation.Formation // The formation of each team
+	history      *stats.History
+	phase        score.Phase
+	startHeld    bool
+	penaltyLeft  float64 // Seconds left to score the penalty being taken
+}
+
+func newSoccerMode(g *Game) interfaces.GameMode {
+	return &soccerMode{game: g}
+}
+
+func (s *soccerMode) Name() string {
+	return ModeSoccer
+}
+
+// Setup sets the field up and waits for the match to start.
+func (s *soccerMode) Setup() error {
+	levelMap, ok := s.game.GameMap.(*gameMap.Map)
+	if !ok {
+		return fmt.Errorf("the map cannot hold a soccer field")
+	}
+	s.levelMap = levelMap
+	s.setupFormations()
+	levelMap.SetupSoccerField(s.lineUps[0], s.lineUps[1])
+	if err := s.setupTeams(); err != nil {
+		return err
+	}
+
+	s.scoreManager = score.NewScoreManager()
+	s.scoreManager.SetTeamName(0, "Blue Team")
+	s.scoreManager.SetTeamName(1, "Red Team")
+	s.scoreManager.SetEventManager(s.game.EventManager)
+	s.phase = s.scoreManager.Phase()
+	s.game.HUD.SetScore(s.scoreManager)
+	s.setupHumans()
+	s.setupStats()
+
+	s.lineUp()
+	return nil
+}
+
+// setupTeams hands the players of both teams on the field to the AI.
+func (s *soccerMode) setupTeams() error {
+	ball, ok := s.levelMap.Ball()
+	if !ok {
+		return fmt.Errorf("the soccer field has no ball")
+	}
+	difficulty := ai.DifficultyNormal
+	if name := s.game.stringSetting("difficulty"); name != "" {
+		var err error
+		if difficulty, err = ai.ParseDifficulty(name); err != nil {
+			log.Printf("playing on normal difficulty: %v", err)
+		}
+	}
+
+	field := interfaces.Rect{Size: interfaces.Vector2D{X: gameMap.FieldWidth, Y: gameMap.FieldHeight}}
+	pitch := ai.NewPitch(field, ball, s.game.EventManager, time.Now().UnixNano())
+	pitch.SetGoal(gameMap.TeamBlue, s.levelMap.Goal(gameMap.TeamBlue))
+	pitch.SetGoal(gameMap.TeamRed, s.levelMap.Goal(gameMap.TeamRed))
+
+	s.aiManager = ai.NewManager()
+	s.players = nil
+	for _, obstacle := range s.levelMap.Obstacles {
+		team, ok := obstacle.Properties["team"].(string)
+		if !ok {
+			continue
+		}
+		player, err := pitch.AddPlayer(obstacle.RigidBody, team, obstacle.Type, difficulty)
+		if err != nil {
+			return err
+		}
+		if err := s.aiManager.Add(player); err != nil {
+			return err
+		}
+		s.players = append(s.players, player)
+	}
+	return nil
+}
+
+// setupFormations loads the formations and picks those of the settings,
+// or the default one.
+func (s *soccerMode) setupFormations() {
+	formations, err := formation.Load(FormationsFile)
+	if err != nil {
+		log.Printf("playing the fallback formations: %v", err)
+		formations = formation.Fallback()
+	}
+	s.formations = formations
+	for i, team := range teams {
+		s.lineUps[i] = formations.Get(s.game.stringSetting(team + "Formation"))
+	}
+}
+
+// changeFormation lines the team with the given index up in the next
+// formation, handing its new players to the AI.
+func (s *soccerMode) changeFormation(teamIndex int) {
+	s.lineUps[teamIndex] = s.formations.Next(s.lineUps[teamIndex].Name)
+	s.aiManager.Clear()
+	s.levelMap.SetupTeam(teams[teamIndex], s.lineUps[teamIndex])
+	if err := s.setupTeams(); err != nil {
+		log.Printf("could not line up in %s: %v", s.lineUps[teamIndex].Name, err)
+	}
+	s.lineUp()
+}
+
+// setupHumans puts the first player in the blue team and brings the other
+// players at the keyboard in. The second player plays for the team named by
+// the "player2Team" setting; any more alternate between the teams.
+func (s *soccerMode) setupHumans() {
+	s.humans = []*playerKick{newPlayerKick(s.game, 0, gameMap.TeamBlue, s.game.Player, s.game.InputHandler)}
+	for index := 1; index < s.game.localPlayerCount(); index++ {
+		team := teams[index%2]
+		if name := s.game.stringSetting("player2Team"); index == 1 && name != "" {
+			if name != gameMap.TeamBlue && name != gameMap.TeamRed {
+				log.Printf("player 2 plays for the %s team: no team %q", team, name)
+			} else {
+				team = name
+			}
+		}
+		position := s.kickoffPosition(index, team, s.game.Player.GetSize())
+		local, input, err := s.game.joinPlayer(index, position)
+		if err != nil {
+			log.Printf("player %d cannot join: %v", index+1, err)
+			return
+		}
+		s.humans = append(s.humans, newPlayerKick(s.game, index, team, local, input))
+	}
+}
+
+// setupStats follows the match and loads the history of the matches played.
+// The game keeps one collector for every match, as the event manager cannot
+// drop the handlers of a collector once registered.
+func (s *soccerMode) setupStats() {
+	if s.game.matchStats == nil {
+		s.game.matchStats = stats.NewCollector(teams[0], teams[1])
+		s.game.matchStats.RegisterHandlers(s.game.EventManager)
+	}
+	s.stats = s.game.matchStats
+	s.stats.Reset()
+	for _, team := range teams {
+		s.stats.SetGoal(team, s.levelMap.Goal(team))
+	}
+
+	history, err := stats.LoadHistory()
+	if err != nil {
+		log.Printf("starting a new match history: %v", err)
+		history = &stats.History{}
+	}
+	s.history = history
+}
+
+// Hold never holds the match still.
+func (s *soccerMode) Hold(deltaTime float64) bool {
+	return false
+}
+
+// Update starts a match when the jump button of any player is pressed before
+// kickoff or after full time, runs the clock and lets everyone play while the
+// ball is in play or a penalty is being taken.
+func (s *soccerMode) Update(deltaTime float64) error {
+	startHeld := s.jumpHeld()
+	if !s.scoreManager.IsMatchActive() {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.Key1):
+			s.changeFormation(0)
+		case inpututil.IsKeyJustPressed(ebiten.Key2):
+			s.changeFormation(1)
+		case startHeld && !s.startHeld:
+			s.game.HUD.SetSummary(nil)
+			s.scoreManager.Start()
+		}
+	}
+	s.startHeld = startHeld
+
+	s.scoreManager.Update(deltaTime)
+	if phase := s.scoreManager.Phase(); phase != s.phase {
+		s.phase = phase
+		s.enterPhase(phase)
+	}
+
+	switch {
+	case s.scoreManager.InPlay():
+		s.play(deltaTime)
+		s.track(deltaTime)
+		if team, ok := s.scored(); ok {
+			s.scoreManager.AddGoal(team)
+		}
+	case s.phase == score.PhasePenalties:
+		s.play(deltaTime)
+		s.updatePenalty(deltaTime)
+	}
+	return nil
+}
+
+// jumpHeld reports whether any player at the keyboard holds the jump button.
+func (s *soccerMode) jumpHeld() bool {
+	for _, human := range s.humans {
+		if human.input.IsJumpPressed() {
+			return true
+		}
+	}
+	return false
+}
+
+// play lets the AI and the players move and kick.
+func (s *soccerMode) play(deltaTime float64) {
+	s.aiManager.Update(deltaTime)
+	for _, human := range s.humans {
+		human.update(deltaTime, s.levelMap.Balls())
+	}
+}
+
+// enterPhase sets the field up for the phase the match just entered.
+func (s *soccerMode) enterPhase(phase score.Phase) {
+	switch phase {
+	case score.PhaseKickoff:
+		s.lineUp()
+	case score.PhasePenalties:
+		s.setupPenalty()
+	case score.PhaseFullTime:
+		s.finish()
+	}
+}
+
+// track tells the stats collector who has the ball and where everyone ran.
+func (s *soccerMode) track(deltaTime float64) {
+	s.stats.Update(deltaTime)
+	for _, agent := range s.players {
+		s.stats.Track(agent.Team, agent.Body.Identifier, bodyCenter(agent.Body))
+	}
+	for _, human := range s.humans {
+		s.stats.Track(human.team, player.Identifier(human.index), rectCenter(human.rect()))
+	}
+}
+
+// finish shows the summary of the match and adds it to the history.
+func (s *soccerMode) finish() {
+	// The last kicks and goals may still be on their way to the collector
+	s.game.EventManager.Wait()
+	summary := s.stats.Summary()
+	s.game.HUD.SetSummary(&summary)
+	s.history.Add(time.Now(), summary)
+	if err := s.history.Save(); err != nil {
+		log.Printf("could not save the match history: %v", err)
+	}
+}
+
+// lineUp puts everyone back in formation with the ball on the center spot.
+func (s *soccerMode) lineUp() {
+	for _, player := range s.players {
+		player.SetPosition(topLeft(player.Home(), player.Body.Size))
+		if err := player.OnEnter(); err != nil {
+			log.Printf("could not line %s up: %v", player.Body.Identifier, err)
+		}
+	}
+	s.levelMap.ResetBall()
+	for _, human := range s.humans {
+		s.place(human, s.kickoffPosition(human.index, human.team, human.player.GetSize()))
+	}
+}
+
+// kickoffPosition returns where the player at the keyboard with the given
+// index, team and size lines up: where the first player starts, mirrored into
+// the red half for the red team and to the other side of the field for the
+// players after the first.
+func (s *soccerMode) kickoffPosition(index int, team string, size interfaces.Vector2D) interfaces.Vector2D {
+	position := s.game.startPosition
+	if team == gameMap.TeamRed {
+		position.X = gameMap.FieldWidth - position.X - size.X
+	}
+	if index > 0 {
+		position.Y = gameMap.FieldHeight - position.Y - size.Y
+	}
+	return position
+}
+
+// place stands a player at the keyboard at position; the first one brings
+// the pet along.
+func (s *soccerMode) place(human *playerKick, position interfaces.Vector2D) {
+	if human.index == 0 {
+		s.game.respawnPlayer(interfaces.Rect{Position: position, Size: human.player.GetSize()})
+		return
+	}
+	human.player.SetPosition(position)
+}
+
+// scored returns the team that got the ball into the goal of the other.
+func (s *soccerMode) scored() (int, bool) {
+	ball, ok := s.levelMap.Ball()
+	if !ok {
+		return 0, false
+	}
+	center := bodyCenter(ball)
+	for i, team := range teams {
+		if inside(center, s.levelMap.Goal(team)) {
+			return 1 - i, true
+		}
+	}
+	return 0, false
+}
+
+// setupPenalty puts the ball on the penalty spot in front of the goal of the
+// team not taking the penalty. Only its goalkeeper and the taker play: a
+// player at the keyboard of the team if there is one, a striker otherwise.
+func (s *soccerMode) setupPenalty() {
+	taker := s.scoreManager.PenaltyTaker()
+	goal := s.levelMap.Goal(teams[1-taker])
+	// Step from the goal toward the middle of the field
+	step := penaltyDistance
+	if goal.Position.X > gameMap.FieldWidth/2 {
+		step = -penaltyDistance
+	}
+	spot := interfaces.Vector2D{X: rectCenter(goal).X + step, Y: rectCenter(goal).Y}
+	s.penaltyLeft = penaltyTime
+
+	var human *playerKick
+	for _, candidate := range s.humans {
+		s.place(candidate, s.kickoffPosition(candidate.index, candidate.team, candidate.player.GetSize()))
+		if human == nil && candidate.team == teams[taker] {
+			human = candidate
+		}
+	}
+	var shooter *ai.SoccerPlayer
+	for _, player := range s.players {
+		player.SetPosition(topLeft(player.Home(), player.Body.Size))
+		if shooter == nil && human == nil && player.Team == teams[taker] && player.Role == ai.RoleStriker {
+			shooter = player
+		}
+	}
+	for _, player := range s.players {
+		var err error
+		if player == shooter || (player.Team == teams[1-taker] && player.Role == ai.RoleGoalkeeper) {
+			err = player.OnEnter()
+		} else {
+			err = player.OnExit()
+		}
+		if err != nil {
+			log.Printf("could not set %s up for the penalty: %v", player.Body.Identifier, err)
+		}
+	}
+
+	if ball, ok := s.levelMap.Ball(); ok {
+		ball.Teleport(topLeft(spot, ball.Size))
+	}
+	// The taker stands behind the ball
+	behind := interfaces.Vector2D{X: spot.X + step/3, Y: spot.Y}
+	if human != nil {
+		s.place(human, topLeft(behind, human.player.GetSize()))
+	} else if shooter != nil {
+		shooter.SetPosition(topLeft(behind, shooter.Body.Size))
+	}
+}
+
+// updatePenalty records the penalty once it is in or its time is up, and sets
+// the next one up until the shootout is decided.
+func (s *soccerMode) updatePenalty(deltaTime float64) {
+	s.penaltyLeft -= deltaTime
+	_, in := s.scored()
+	if !in && s.penaltyLeft > 0 {
+		return
+	}
+	s.scoreManager.TakePenalty(in)
+	if s.scoreManager.Phase() == score.PhasePenalties {
+		s.setupPenalty()
+	}
+}
+
+// Draw shows the kicks being charged, and the formations before kickoff;
+// the map draws the field and the HUD the score.
+func (s *soccerMode) Draw(screen *ebiten.Image, camera interfaces.Camera) {
+	for _, human := range s.humans {
+		human.draw(screen, camera, s.levelMap.Balls())
+	}
+	if !s.scoreManager.IsMatchActive() && s.game.HUD.Summary == nil {
+		line := fmt.Sprintf("Blue %s (1)   Red %s (2)", s.lineUps[0].Name, s.lineUps[1].Name)
+		ebitenutil.DebugPrintAt(screen, line, (s.game.ScreenWidth-len(line)*6)/2, 60)
+	}
+}
+
+func (s *soccerMode) Rules() string {
+	return "Soccer Match - Score Goals to Win!"
+}
+
+// Teardown takes the players out, clears the field and hides the score.
+func (s *soccerMode) Teardown() {
+	s.game.leavePlayers()
+	if s.aiManager != nil {
+		s.aiManager.Clear()
+	}
+	if s.levelMap != nil {
+		s.levelMap.Reset()
+	}
+	s.game.HUD.SetScore(nil)
+	s.game.HUD.SetSummary(nil)
+}
+
+// topLeft returns where a body of size goes to be centered on center.
+func topLeft(center, size interfaces.Vector2D) interfaces.Vector2D {
+	return interfaces.Vector2D{X: center.X - size.X/2, Y: center.Y - size.Y/2}
+}
+
+// inside reports whether point lies within area.
+func inside(point interfaces.Vector2D, area interfaces.Rect) bool {
+	return point.X >= area.Position.X && point.X <= area.Position.X+area.Size.X &&
+		point.Y >= area.Position.Y && point.Y <= area.Position.Y+area.Size.Y
+}
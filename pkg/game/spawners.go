@@ -0,0 +1,151 @@
+package game
+
+import (
+	"log"
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/ai"
+	"github.com/joaorufino/gopher-game/pkg/gameMap"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	"github.com/joaorufino/gopher-game/pkg/spawner"
+)
+
+const (
+	// despawnDelay is how many seconds a spawned enemy stays off screen
+	// before it is taken away, for its spawner to spawn it again.
+	despawnDelay = 3.0
+	// despawnMargin is how far past the edges of the screen a spawned enemy
+	// still counts as on screen.
+	despawnMargin = 200.0
+)
+
+// levelSpawner spawns the enemies of a spawner of the level.
+type levelSpawner struct {
+	*spawner.Spawner
+	enemy gameMap.Enemy
+}
+
+// spawnSenses tells the spawners which triggers the player is in and which
+// logic objects are on.
+type spawnSenses struct {
+	game *Game
+}
+
+// InTrigger reports whether the player is in the trigger of the level so
+// named.
+func (s spawnSenses) InTrigger(name string) bool {
+	if s.game.levelData == nil {
+		return false
+	}
+	player := s.game.playerRect()
+	for _, trigger := range s.game.levelData.Triggers {
+		if trigger.Name == name && touches(player, trigger.Body) {
+			return true
+		}
+	}
+	return false
+}
+
+// LogicOn reports whether the logic object of the level with the id is on.
+func (s spawnSenses) LogicOn(id string) bool {
+	levelMap, ok := s.game.GameMap.(*gameMap.Map)
+	return ok && levelMap.LogicOn(id)
+}
+
+// setupSpawners replaces the spawners with those of the level.
+func (g *Game) setupSpawners(data *gameMap.LevelData) {
+	g.spawners = nil
+	if data == nil {
+		return
+	}
+	for i, spec := range data.Spawners {
+		config := spec.Spawner()
+		if config.Size.X <= 0 || config.Size.Y <= 0 {
+			config.Size = defaultEnemySize
+		}
+		config.Seed = int64(i + 1)
+		g.spawners = append(g.spawners, &levelSpawner{Spawner: spawner.New(config, spawnSenses{game: g}), enemy: spec.Enemy})
+	}
+}
+
+// updateSpawners brings in the enemies of the spawners and announces their
+// waves, then cleans up after the spawned enemies.
+func (g *Game) updateSpawners(deltaTime float64) {
+	for _, s := range g.spawners {
+		positions, events := s.Update(deltaTime)
+		for _, position := range positions {
+			spec := s.enemy
+			spec.Body.Position = position
+			enemy, err := g.newEnemy(spec)
+			if err == nil {
+				err = g.enemies.Add(enemy)
+			}
+			if err != nil {
+				log.Printf("spawner %s could not spawn a %s enemy: %v", s.Name(), spec.Behavior, err)
+				continue
+			}
+			if current := g.progress.Level(); current != nil {
+				current.AddEnemy(enemy)
+			}
+			s.Add(enemy)
+		}
+		for _, event := range events {
+			g.EventManager.Dispatch(event)
+		}
+	}
+	g.cleanUpSpawned(deltaTime)
+}
+
+// cleanUpSpawned defeats the spawned enemies that fell out of the level, and
+// takes away those that stayed off screen for a while.
+func (g *Game) cleanUpSpawned(deltaTime float64) {
+	if len(g.spawners) == 0 {
+		return
+	}
+	fallY := math.Inf(1)
+	if g.levelData != nil {
+		if limit, ok := g.levelData.FallLimit(); ok {
+			fallY = limit
+		}
+	}
+	if engine, ok := g.PhysicsEngine.(*physics.PhysicsEngine); ok {
+		fallY = math.Min(fallY, engine.FloorY())
+	}
+	offsetX, offsetY := g.Camera.GetOffset()
+	view := interfaces.Rect{
+		Position: interfaces.Vector2D{X: offsetX - despawnMargin, Y: offsetY - despawnMargin},
+		Size:     interfaces.Vector2D{X: float64(g.ScreenWidth) + 2*despawnMargin, Y: float64(g.ScreenHeight) + 2*despawnMargin},
+	}
+
+	for _, enemy := range g.enemyList() {
+		owner := g.spawnerOf(enemy)
+		if owner == nil {
+			continue
+		}
+		body := physics.BodyRect(enemy.Body)
+		switch {
+		case body.Position.Y+body.Size.Y >= fallY:
+			g.defeatEnemy(enemy, "fall")
+		case touches(view, body):
+			delete(g.offscreen, enemy)
+		default:
+			g.offscreen[enemy] += deltaTime
+			if g.offscreen[enemy] >= despawnDelay {
+				g.removeEnemy(enemy)
+				owner.Remove(enemy, false)
+			}
+		}
+	}
+}
+
+// spawnerOf returns the spawner that spawned the enemy, or nil for an enemy
+// placed by the level.
+func (g *Game) spawnerOf(enemy *ai.Enemy) *levelSpawner {
+	for _, s := range g.spawners {
+		if s.Owns(enemy) {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/score"
+	"github.com/joaorufino/gopher-game/pkg/tournament"
+)
+
+// The tournament panel is tournamentWidth pixels wide, below the formations
+// shown before kickoff.
+const (
+	tournamentWidth = 360
+	tournamentTop   = 80
+	tournamentLine  = 16
+)
+
+// tournamentMode plays the fixtures of a tournament between the teams of
+// TeamsFile as soccer matches, and simulates those between AI teams. The
+// players always play on the blue side for the team named by the
+// "tournamentTeam" setting, the first one unless set; the "tournament"
+// setting picks a league or a knockout, a league unless set.
+//
+// The tournament is stored after every fixture, so it carries on where it
+// was left in the next session; a new one starts once it is over.
+type tournamentMode struct {
+	*soccerMode
+	tournament *tournament.Tournament
+	fixture    int // The next fixture of the players, or -1 when they have none
+	rng        *rand.Rand
+}
+
+func newTournamentMode(g *Game) interfaces.GameMode {
+	return &tournamentMode{
+		soccerMode: &soccerMode{game: g},
+		fixture:    -1,
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (t *tournamentMode) Name() string {
+	return ModeTournament
+}
+
+// Setup sets the field up and carries on with the stored tournament, or
+// starts a new one.
+func (t *tournamentMode) Setup() error {
+	if err := t.soccerMode.Setup(); err != nil {
+		return err
+	}
+	stored, err := tournament.Load()
+	if err != nil {
+		log.Printf("starting a new tournament: %v", err)
+	}
+	if stored == nil {
+		if stored, err = t.newTournament(); err != nil {
+			return err
+		}
+	}
+	t.tournament = stored
+	t.advance()
+	t.showFixture()
+	return nil
+}
+
+// newTournament schedules a tournament in the format of the settings
+// between the teams of TeamsFile.
+func (t *tournamentMode) newTournament() (*tournament.Tournament, error) {
+	teams, err := tournament.LoadTeams(TeamsFile)
+	if err != nil {
+		return nil, err
+	}
+	format := tournament.FormatLeague
+	if name := t.game.stringSetting("tournament"); name != "" {
+		if format, err = tournament.ParseFormat(name); err != nil {
+			log.Printf("playing a league: %v", err)
+			format = tournament.FormatLeague
+		}
+	}
+	human := 0
+	if name := t.game.stringSetting("tournamentTeam"); name != "" {
+		human = -1
+		for i, team := range teams {
+			if team.Name == name {
+				human = i
+			}
+		}
+		if human < 0 {
+			log.Printf("playing for the %s: no team %q", teams[0].Name, name)
+			human = 0
+		}
+	}
+	teams[human].Human = true
+
+	name := "Gopher League"
+	if format == tournament.FormatKnockout {
+		name = "Gopher Cup"
+	}
+	return tournament.New(name, format, teams)
+}
+
+// advance simulates the fixtures the players do not play up to their next
+// one, and stores the tournament.
+func (t *tournamentMode) advance() {
+	t.fixture = -1
+	if next, ok := t.tournament.Advance(t.rng); ok {
+		t.fixture = next
+	}
+	if err := t.tournament.Save(); err != nil {
+		log.Printf("could not save the tournament: %v", err)
+	}
+}
+
+// showFixture names the teams of the next fixture on the score and sets the
+// rules up: a knockout fixture goes to extra time and penalties.
+func (t *tournamentMode) showFixture() {
+	if t.fixture < 0 {
+		return
+	}
+	players, opponents, _ := t.sides(t.tournament.Fixtures[t.fixture])
+	t.scoreManager.SetTeamName(0, players)
+	t.scoreManager.SetTeamName(1, opponents)
+	rules := score.DefaultRules()
+	if t.tournament.AllowsDraws() {
+		rules.ExtraTimeLength = 0
+		rules.Penalties = false
+	}
+	t.scoreManager.SetRules(rules)
+}
+
+// sides returns the team the players play for and the one they face in a
+// fixture, and whether the players play at home.
+func (t *tournamentMode) sides(fixture tournament.Fixture) (players, opponents string, home bool) {
+	if team, _ := t.tournament.Team(fixture.Home); team.Human {
+		return fixture.Home, fixture.Away, true
+	}
+	return fixture.Away, fixture.Home, false
+}
+
+// Update plays the fixture like a soccer match and records its result at
+// full time. The first press of jump after full time puts the summary away
+// to show the next fixture; once the tournament is over it starts a new one.
+func (t *tournamentMode) Update(deltaTime float64) error {
+	if !t.scoreManager.IsMatchActive() && t.jumpHeld() && !t.startHeld {
+		switch {
+		case t.game.HUD.Summary != nil:
+			t.game.HUD.SetSummary(nil)
+			t.showFixture()
+			t.startHeld = true
+		case t.fixture < 0:
+			t.restart()
+			t.startHeld = true
+		}
+	}
+
+	previous := t.phase
+	if err := t.soccerMode.Update(deltaTime); err != nil {
+		return err
+	}
+	if t.phase == score.PhaseFullTime && previous != score.PhaseFullTime && t.fixture >= 0 {
+		t.record()
+	}
+	return nil
+}
+
+// record gives the fixture the result of the match just played and moves on
+// to the next fixture of the players.
+func (t *tournamentMode) record() {
+	var goals, penalties [2]int
+	for i := range goals {
+		goals[i] = t.scoreManager.GetScore(i)
+		penalties[i], _ = t.scoreManager.GetPenalties(i)
+	}
+	if _, _, home := t.sides(t.tournament.Fixtures[t.fixture]); !home {
+		goals[0], goals[1] = goals[1], goals[0]
+		penalties[0], penalties[1] = penalties[1], penalties[0]
+	}
+	result := tournament.Result{
+		HomeGoals:     goals[0],
+		AwayGoals:     goals[1],
+		HomePenalties: penalties[0],
+		AwayPenalties: penalties[1],
+	}
+	if err := t.tournament.Record(t.fixture, result); err != nil {
+		log.Printf("could not record the match: %v", err)
+		return
+	}
+	t.advance()
+}
+
+// restart starts a new tournament after the last one is over.
+func (t *tournamentMode) restart() {
+	next, err := t.newTournament()
+	if err != nil {
+		log.Printf("could not start a new tournament: %v", err)
+		return
+	}
+	t.tournament = next
+	t.advance()
+	t.showFixture()
+}
+
+// Draw shows the next fixture with the table or the bracket before kickoff.
+func (t *tournamentMode) Draw(screen *ebiten.Image, camera interfaces.Camera) {
+	t.soccerMode.Draw(screen, camera)
+	if t.scoreManager.IsMatchActive() || t.game.HUD.Summary != nil {
+		return
+	}
+	lines := t.standings()
+	x := (t.game.ScreenWidth - tournamentWidth) / 2
+	height := len(lines)*tournamentLine + 20
+	vector.DrawFilledRect(screen, float32(x), tournamentTop, tournamentWidth, float32(height), color.RGBA{0, 0, 0, 200}, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x+10, tournamentTop+10+i*tournamentLine)
+	}
+}
+
+// standings returns the lines of the tournament panel: the next fixture of
+// the players or the champion, then the table of a league or the rounds of
+// a knockout.
+func (t *tournamentMode) standings() []string {
+	lines := []string{t.tournament.Name}
+	if t.fixture >= 0 {
+		fixture := t.tournament.Fixtures[t.fixture]
+		players, opponents, home := t.sides(fixture)
+		where := "away"
+		if home {
+			where = "home"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s vs %s (%s)", t.tournament.RoundName(fixture.Round), players, opponents, where))
+	} else if champion, ok := t.tournament.Champion(); ok {
+		lines = append(lines, fmt.Sprintf("Champion: %s", champion), "Press Space for a new tournament")
+	}
+	lines = append(lines, "")
+
+	if t.tournament.Format == tournament.FormatKnockout {
+		for round := 1; round <= t.tournament.Rounds(); round++ {
+			fixtures := t.tournament.Round(round)
+			if len(fixtures) > 0 {
+				lines = append(lines, t.tournament.RoundName(round))
+			}
+			for _, fixture := range fixtures {
+				lines = append(lines, "  "+fixtureLine(fixture))
+			}
+		}
+		return lines
+	}
+	lines = append(lines, fmt.Sprintf("   %-12s %3s %3s %3s %3s %4s %4s", "Team", "P", "W", "D", "L", "GD", "Pts"))
+	for i, standing := range t.tournament.Table() {
+		lines = append(lines, fmt.Sprintf("%2d %-12s %3d %3d %3d %3d %+4d %4d", i+1, standing.Team,
+			standing.Played, standing.Won, standing.Drawn, standing.Lost, standing.GoalDifference(), standing.Points))
+	}
+	return lines
+}
+
+// fixtureLine shows a fixture with its score once played, and the shootout
+// that settled it.
+func fixtureLine(fixture tournament.Fixture) string {
+	result := fixture.Result
+	if result == nil {
+		return fmt.Sprintf("%s vs %s", fixture.Home, fixture.Away)
+	}
+	line := fmt.Sprintf("%s %d-%d %s", fixture.Home, result.HomeGoals, result.AwayGoals, fixture.Away)
+	if result.HomeGoals == result.AwayGoals && result.HomePenalties != result.AwayPenalties {
+		line += fmt.Sprintf(" (%d-%d pens)", result.HomePenalties, result.AwayPenalties)
+	}
+	return line
+}
+
+func (t *tournamentMode) Rules() string {
+	return "Tournament - Win the League or the Cup!"
+}
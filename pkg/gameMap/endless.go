@@ -0,0 +1,76 @@
+package gameMap
+
+import (
+	"fmt"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// exitHeight is the height of the exit on top of the last platform of an
+// endless level.
+const exitHeight = 64
+
+// EndlessLevels implements interfaces.LevelGenerator for endless mode. Every
+// level climbs another stretch of generated platforms, starting on the
+// platform the previous one ended on, so the difficulty keeps rising.
+type EndlessLevels struct {
+	generator *PlatformGenerator
+	height    float64
+	number    int
+	level     *LevelData
+}
+
+// NewEndlessLevels creates endless levels climbing height each. The generator
+// should have no physics engine: the map registers the platforms of a level
+// when it is applied.
+func NewEndlessLevels(generator *PlatformGenerator, height float64) *EndlessLevels {
+	return &EndlessLevels{generator: generator, height: height}
+}
+
+// GenerateNextLevel generates the platforms of the next level, with a start
+// point on the first one and an exit on the last one.
+func (e *EndlessLevels) GenerateNextLevel() error {
+	if e.height <= 0 {
+		return fmt.Errorf("endless levels need a positive height, got %.0f", e.height)
+	}
+	from := len(e.generator.platforms)
+	if from > 0 {
+		from--
+	}
+	number := e.number + 1
+	e.generator.GenerateUpTo(e.generator.config.ScreenHeight - float64(number)*e.height)
+	platforms := append([]Platform(nil), e.generator.platforms[from:]...)
+	if len(platforms) == 0 {
+		return fmt.Errorf("endless level %d has no platforms", number)
+	}
+	e.number = number
+
+	first := platforms[0].RigidBody
+	last := platforms[len(platforms)-1].RigidBody
+	e.level = &LevelData{
+		Platforms: platforms,
+		SpawnPoints: []SpawnPoint{{
+			Name:     "start",
+			Position: interfaces.Vector2D{X: first.Position.X + first.Size.X/2, Y: first.Position.Y},
+		}},
+		Triggers: []Trigger{{
+			Name: fmt.Sprintf("exit%d", e.number),
+			Type: TriggerExit,
+			Body: interfaces.Rect{
+				Position: interfaces.Vector2D{X: last.Position.X, Y: last.Position.Y - exitHeight},
+				Size:     interfaces.Vector2D{X: last.Size.X, Y: exitHeight},
+			},
+		}},
+	}
+	return nil
+}
+
+// Level returns the level generated last, or nil before the first one.
+func (e *EndlessLevels) Level() *LevelData {
+	return e.level
+}
+
+// Number returns how many levels were generated so far.
+func (e *EndlessLevels) Number() int {
+	return e.number
+}
@@ -0,0 +1,269 @@
+package gameMap
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// reachMargin is the share of the jump reach and height the generator uses,
+// so platforms stay reachable without a perfect jump.
+const reachMargin = 0.85
+
+// PlatformKindWeight is an entry of a weighted table of platform kinds.
+type PlatformKindWeight struct {
+	Kind   PlatformKind
+	Weight float64
+}
+
+var (
+	// DefaultEasyKinds is the platform mix at the bottom of a generated level.
+	DefaultEasyKinds = []PlatformKindWeight{{PlatformStatic, 1}}
+	// DefaultHardKinds is the platform mix once the difficulty peaks.
+	DefaultHardKinds = []PlatformKindWeight{
+		{PlatformStatic, 0.4},
+		{PlatformMoving, 0.25},
+		{PlatformCrumbling, 0.2},
+		{PlatformOneWay, 0.15},
+	}
+)
+
+// PlatformGeneratorConfig holds configuration for generating platforms.
+type PlatformGeneratorConfig struct {
+	MinPlatformDistance float64
+	MaxPlatformDistance float64
+	PlatformWidth       float64
+	PlatformHeight      float64
+	ScreenWidth         float64
+	ScreenHeight        float64
+	// Seed drives all random choices: the same seed always produces the same platforms.
+	Seed int64
+	// JumpArc is the player's jump. Every platform is placed within reach of
+	// the previous one; a zero arc disables the check.
+	JumpArc physics.JumpArc
+	// MinPlatformWidth is the width platforms shrink to as the difficulty peaks.
+	MinPlatformWidth float64
+	// DifficultyHeight is how far the player climbs before the difficulty peaks.
+	DifficultyHeight float64
+	// EasyKinds and HardKinds are the platform mixes at the lowest and highest
+	// difficulty; the weights in between are interpolated.
+	EasyKinds []PlatformKindWeight
+	HardKinds []PlatformKindWeight
+}
+
+// PlatformGenerator generates platforms dynamically.
+type PlatformGenerator struct {
+	config        PlatformGeneratorConfig
+	rng           *rand.Rand
+	platforms     []Platform
+	lastPlatformY float64
+	last          *interfaces.Rect
+	physicsEngine interfaces.PhysicsEngine
+}
+
+func NewPlatformGenerator(config PlatformGeneratorConfig, physicsEngine interfaces.PhysicsEngine) *PlatformGenerator {
+	if config.MinPlatformWidth == 0 {
+		config.MinPlatformWidth = config.PlatformWidth
+	}
+	if config.DifficultyHeight == 0 {
+		config.DifficultyHeight = 5000
+	}
+	if len(config.EasyKinds) == 0 && len(config.HardKinds) == 0 {
+		config.EasyKinds = DefaultEasyKinds
+		config.HardKinds = DefaultHardKinds
+	}
+	return &PlatformGenerator{
+		config:        config,
+		rng:           rand.New(rand.NewSource(config.Seed)),
+		platforms:     []Platform{},
+		lastPlatformY: config.ScreenHeight,
+		physicsEngine: physicsEngine,
+	}
+}
+
+// GeneratorSeed returns the "seed" setting, or a seed from the clock when none
+// is set. The seed is logged so a generated level can be reproduced.
+func GeneratorSeed(settings interfaces.Settings) int64 {
+	var seed int64
+	value, err := settings.Get("seed")
+	switch v := value.(type) {
+	case int:
+		seed = int64(v)
+	case int64:
+		seed = v
+	case float64:
+		seed = int64(v)
+	default:
+		if err == nil {
+			log.Printf("ignoring seed setting of type %T", value)
+		}
+		seed = time.Now().UnixNano()
+	}
+	log.Printf("platform generator seed: %d", seed)
+	return seed
+}
+
+// GenerateUpTo adds platforms until the highest one is above top.
+func (pg *PlatformGenerator) GenerateUpTo(top float64) {
+	for pg.last == nil || pg.lastPlatformY > top {
+		pg.addPlatform()
+	}
+}
+
+// Reset removes every platform and restarts the random sequence, so the
+// generator produces the same platforms again.
+func (pg *PlatformGenerator) Reset() {
+	pg.rng = rand.New(rand.NewSource(pg.config.Seed))
+	pg.platforms = []Platform{}
+	pg.lastPlatformY = pg.config.ScreenHeight
+	pg.last = nil
+}
+
+// difficulty returns how hard the level is at the given height, from 0 to 1.
+func (pg *PlatformGenerator) difficulty(y float64) float64 {
+	return clamp((pg.config.ScreenHeight-y)/pg.config.DifficultyHeight, 0, 1)
+}
+
+// addPlatform places the next platform above the previous one, within the
+// player's jump.
+func (pg *PlatformGenerator) addPlatform() {
+	cfg := pg.config
+	var rect interfaces.Rect
+	kind := PlatformStatic
+
+	if pg.last == nil {
+		// The first platform is a wide, safe starting point.
+		rect = interfaces.Rect{
+			Position: interfaces.Vector2D{X: 0, Y: cfg.ScreenHeight - cfg.PlatformHeight},
+			Size:     interfaces.Vector2D{X: cfg.ScreenWidth, Y: cfg.PlatformHeight},
+		}
+	} else {
+		prev := *pg.last
+		d := pg.difficulty(prev.Position.Y)
+		width := lerp(cfg.PlatformWidth, cfg.MinPlatformWidth, d)
+
+		// Platforms get further apart as the level gets harder.
+		rise := lerp(cfg.MinPlatformDistance, cfg.MaxPlatformDistance, d)
+		rise *= physics.MulAdd(0.3, pg.rng.Float64(), 0.85)
+		maxGap := cfg.ScreenWidth
+		if cfg.JumpArc.Gravity > 0 {
+			rise = math.Min(rise, cfg.JumpArc.MaxHeight()*reachMargin)
+			reach, _ := cfg.JumpArc.Reach(-rise)
+			maxGap = reach * reachMargin
+		}
+		gap := pg.rng.Float64() * maxGap * lerp(0.4, 1, d)
+
+		// Jump left or right, turning around at the screen edges.
+		left := pg.rng.Float64() < 0.5
+		x := placeNextTo(prev, width, gap, left)
+		if x < 0 || x+width > cfg.ScreenWidth {
+			x = placeNextTo(prev, width, gap, !left)
+		}
+		if x < 0 || x+width > cfg.ScreenWidth {
+			// Neither side fits, e.g. above a wide platform: pick any spot within reach.
+			low := math.Max(0, prev.Position.X-gap-width)
+			high := math.Max(low, math.Min(cfg.ScreenWidth-width, prev.Position.X+prev.Size.X+gap))
+			x = lerp(low, high, pg.rng.Float64())
+		}
+
+		rect = interfaces.Rect{
+			Position: interfaces.Vector2D{X: x, Y: prev.Position.Y - rise},
+			Size:     interfaces.Vector2D{X: width, Y: cfg.PlatformHeight},
+		}
+		if cfg.JumpArc.Gravity > 0 && !cfg.JumpArc.CanReach(prev, rect) {
+			// Never leave an unreachable platform: fall back to one right above.
+			rect.Position.X = prev.Position.X
+		}
+		kind = pg.pickKind(d)
+	}
+
+	platform := Platform{
+		RigidBody: physics.NewRigidBody(rect.Position, rect.Size, 1, true, "platform"),
+		Kind:      kind,
+		Movement:  Movement{InitialPosX: rect.Position.X, InitialPosY: rect.Position.Y},
+	}
+	switch kind {
+	case PlatformMoving:
+		d := pg.difficulty(rect.Position.Y)
+		room := math.Min(rect.Position.X, cfg.ScreenWidth-rect.Position.X-rect.Size.X)
+		platform.Movement.Type = "horizontal"
+		platform.Movement.Distance = math.Max(0, math.Min(lerp(30, 80, d), room))
+		platform.Movement.Speed = lerp(30, 80, d)
+	case PlatformOneWay:
+		platform.RigidBody.IsOneWay = true
+	}
+
+	pg.platforms = append(pg.platforms, platform)
+	pg.last = &rect
+	pg.lastPlatformY = rect.Position.Y
+	// Without a physics engine the generator only builds level data.
+	if pg.physicsEngine != nil {
+		pg.physicsEngine.AddRigidBody(platform.RigidBody)
+	}
+}
+
+// pickKind chooses a platform kind from the tables weighted for difficulty d.
+func (pg *PlatformGenerator) pickKind(d float64) PlatformKind {
+	// Collect the kinds in table order so the choice does not depend on map iteration.
+	var kinds []PlatformKind
+	weights := make(map[PlatformKind]float64)
+	for _, entry := range pg.config.EasyKinds {
+		if _, seen := weights[entry.Kind]; !seen {
+			kinds = append(kinds, entry.Kind)
+		}
+		weights[entry.Kind] = physics.MulAdd(entry.Weight, 1-d, weights[entry.Kind])
+	}
+	for _, entry := range pg.config.HardKinds {
+		if _, seen := weights[entry.Kind]; !seen {
+			kinds = append(kinds, entry.Kind)
+		}
+		weights[entry.Kind] = physics.MulAdd(entry.Weight, d, weights[entry.Kind])
+	}
+
+	total := 0.0
+	for _, kind := range kinds {
+		total += weights[kind]
+	}
+	if total <= 0 {
+		return PlatformStatic
+	}
+	r := pg.rng.Float64() * total
+	for _, kind := range kinds {
+		r -= weights[kind]
+		if r < 0 {
+			return kind
+		}
+	}
+	return kinds[len(kinds)-1]
+}
+
+func (pg *PlatformGenerator) GetPlatforms() []Platform {
+	return pg.platforms
+}
+
+// Config returns the configuration of the generator, with defaults filled in.
+func (pg *PlatformGenerator) Config() PlatformGeneratorConfig {
+	return pg.config
+}
+
+// placeNextTo returns the x of a platform of the given width, gap away from
+// prev on its left or right.
+func placeNextTo(prev interfaces.Rect, width, gap float64, left bool) float64 {
+	if left {
+		return prev.Position.X - gap - width
+	}
+	return prev.Position.X + prev.Size.X + gap
+}
+
+// lerp interpolates between a and b.
+func lerp(a, b, t float64) float64 {
+	return physics.MulAdd(b-a, t, a)
+}
+
+func clamp(value, min, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
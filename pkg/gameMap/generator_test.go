@@ -0,0 +1,115 @@
+package gameMap
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGameMap(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GameMap Suite")
+}
+
+// bodyCollector is a physics engine that only records the bodies added to it.
+type bodyCollector struct {
+	interfaces.PhysicsEngine
+	bodies []interfaces.RigidBody
+}
+
+func (c *bodyCollector) AddRigidBody(rb interfaces.RigidBody) {
+	c.bodies = append(c.bodies, rb)
+}
+
+func generatePlatforms(seed int64) []Platform {
+	generator := NewPlatformGenerator(PlatformGeneratorConfig{
+		MinPlatformDistance: 50,
+		MaxPlatformDistance: 150,
+		PlatformWidth:       100,
+		PlatformHeight:      20,
+		ScreenWidth:         800,
+		ScreenHeight:        600,
+		Seed:                seed,
+		JumpArc:             physics.NewJumpArc(9.8, 100, 200),
+		MinPlatformWidth:    60,
+		DifficultyHeight:    2000,
+	}, &bodyCollector{})
+	generator.GenerateUpTo(-3000)
+	return generator.GetPlatforms()
+}
+
+type platformSummary struct {
+	Position interfaces.Vector2D
+	Size     interfaces.Vector2D
+	Kind     PlatformKind
+	Movement Movement
+}
+
+func summarize(platforms []Platform) []platformSummary {
+	var summary []platformSummary
+	for _, platform := range platforms {
+		summary = append(summary, platformSummary{platform.RigidBody.Position, platform.RigidBody.Size, platform.Kind, platform.Movement})
+	}
+	return summary
+}
+
+var _ = Describe("PlatformGenerator", func() {
+	It("should produce the same platforms for the same seed", func() {
+		Expect(summarize(generatePlatforms(42))).To(Equal(summarize(generatePlatforms(42))))
+		Expect(summarize(generatePlatforms(42))).NotTo(Equal(summarize(generatePlatforms(7))))
+	})
+
+	It("should keep every platform reachable from the previous one", func() {
+		arc := physics.NewJumpArc(9.8, 100, 200)
+		platforms := generatePlatforms(42)
+		Expect(len(platforms)).To(BeNumerically(">", 20))
+		for i := 1; i < len(platforms); i++ {
+			from := physics.BodyRect(platforms[i-1].RigidBody)
+			to := physics.BodyRect(platforms[i].RigidBody)
+			Expect(arc.CanReach(from, to)).To(BeTrue(), "platform %d is out of reach", i)
+		}
+	})
+
+	It("should introduce harder platform kinds as the level rises", func() {
+		platforms := generatePlatforms(42)
+		Expect(platforms[1].Kind).To(Equal(PlatformStatic))
+		kinds := map[PlatformKind]bool{}
+		for _, platform := range platforms {
+			kinds[platform.Kind] = true
+		}
+		Expect(len(kinds)).To(BeNumerically(">", 1))
+	})
+})
+
+var _ = Describe("EndlessLevels", func() {
+	It("should start every level where the previous one ended", func() {
+		endless := NewEndlessLevels(NewPlatformGenerator(PlatformGeneratorConfig{
+			MinPlatformDistance: 50,
+			MaxPlatformDistance: 150,
+			PlatformWidth:       100,
+			PlatformHeight:      20,
+			ScreenWidth:         800,
+			ScreenHeight:        600,
+			Seed:                42,
+			JumpArc:             physics.NewJumpArc(9.8, 100, 200),
+		}, nil), 1000)
+
+		Expect(endless.GenerateNextLevel()).To(Succeed())
+		first := endless.Level()
+		exit, ok := first.Exit()
+		Expect(ok).To(BeTrue())
+		Expect(exit.Body.Position.Y).To(BeNumerically("<", -400))
+
+		Expect(endless.GenerateNextLevel()).To(Succeed())
+		second := endless.Level()
+		last := first.Platforms[len(first.Platforms)-1].RigidBody
+		Expect(second.Platforms[0].RigidBody).To(BeIdenticalTo(last))
+		start, ok := second.Start()
+		Expect(ok).To(BeTrue())
+		Expect(start.Y).To(Equal(last.Position.Y))
+		Expect(endless.Number()).To(Equal(2))
+	})
+})
@@ -0,0 +1,174 @@
+package gameMap
+
+import (
+	"log"
+
+	"github.com/joaorufino/gopher-game/pkg/levelfile"
+)
+
+// The level file types live in package levelfile, which does not depend on
+// ebiten.
+type (
+	Movement      = levelfile.Movement
+	Obstacle      = levelfile.Obstacle
+	PlatformKind  = levelfile.PlatformKind
+	Platform      = levelfile.Platform
+	ItemOnMap     = levelfile.ItemOnMap
+	SpawnPoint    = levelfile.SpawnPoint
+	Trigger       = levelfile.Trigger
+	Checkpoint    = levelfile.Checkpoint
+	Hazard        = levelfile.Hazard
+	Enemy         = levelfile.Enemy
+	EnemySpawner  = levelfile.EnemySpawner
+	LogicObject   = levelfile.LogicObject
+	Tileset       = levelfile.Tileset
+	TileLayer     = levelfile.TileLayer
+	ParallaxLayer = levelfile.ParallaxLayer
+	LevelData     = levelfile.LevelData
+)
+
+const (
+	PlatformStatic    = levelfile.PlatformStatic
+	PlatformMoving    = levelfile.PlatformMoving
+	PlatformCrumbling = levelfile.PlatformCrumbling
+	PlatformOneWay    = levelfile.PlatformOneWay
+
+	// TriggerExit is the type of the trigger that completes a level.
+	TriggerExit = levelfile.TriggerExit
+)
+
+// LoadLevelData reads a level from disk, as levelfile.Load does.
+func LoadLevelData(levelPath string) (*LevelData, error) {
+	return levelfile.Load(levelPath)
+}
+
+// LoadLevel reads the level at levelPath and replaces the map contents with it.
+func (m *Map) LoadLevel(levelPath string) error {
+	level, err := LoadLevelData(levelPath)
+	if err != nil {
+		return err
+	}
+	m.ApplyLevel(level)
+	m.levelPath = levelPath
+	return nil
+}
+
+// ApplyLevel replaces everything on the map with the contents of level and
+// registers the new bodies with the physics engine.
+func (m *Map) ApplyLevel(level *LevelData) {
+	// Re-applying the current level, e.g. after editing it, keeps its path.
+	levelPath := ""
+	if level == m.level {
+		levelPath = m.levelPath
+	}
+	m.Reset()
+
+	// The map works on copies so play (picking items up, moving obstacles)
+	// never changes the level data itself.
+	m.level = level
+	m.levelPath = levelPath
+	m.Platforms = append([]Platform(nil), level.Platforms...)
+	m.Obstacles = append([]Obstacle(nil), level.Obstacles...)
+	m.Items = append([]ItemOnMap(nil), level.Items...)
+	m.SpawnPoints = level.SpawnPoints
+	m.Triggers = level.Triggers
+	m.Checkpoints = level.Checkpoints
+	m.Hazards = level.Hazards
+	m.Enemies = level.Enemies
+	m.Logic = level.Logic
+	m.TileLayers = level.TileLayers
+	m.Tilesets = level.Tilesets
+	m.Background = level.Background
+
+	for _, platform := range m.Platforms {
+		m.physicsEngine.AddRigidBody(platform.RigidBody)
+	}
+	for _, obstacle := range m.Obstacles {
+		m.physicsEngine.AddRigidBody(obstacle.RigidBody)
+	}
+	m.ResolveItems()
+	for _, item := range m.Items {
+		m.physicsEngine.AddRigidBody(item.RigidBody)
+	}
+	m.buildTileMap()
+	m.buildLogic(level)
+	m.loadLayers(level)
+}
+
+// ResolveItems looks the items on the map up again by name, e.g. after the
+// item catalogue was reloaded.
+func (m *Map) ResolveItems() {
+	for i := range m.Items {
+		item := &m.Items[i]
+		if resolved, err := m.resourceManager.GetItem(item.Name); err == nil {
+			item.Item = resolved
+		} else {
+			log.Printf("level item %q: %v", item.Name, err)
+		}
+	}
+}
+
+// Reset removes every body the map owns from the physics engine and empties the map.
+func (m *Map) Reset() {
+	for _, platform := range m.platformGenerator.GetPlatforms() {
+		m.physicsEngine.RemoveRigidBody(platform.RigidBody)
+	}
+	m.platformGenerator.Reset()
+	for _, platform := range m.Platforms {
+		m.physicsEngine.RemoveRigidBody(platform.RigidBody)
+	}
+	for _, obstacle := range m.Obstacles {
+		m.physicsEngine.RemoveRigidBody(obstacle.RigidBody)
+	}
+	for _, item := range m.Items {
+		m.physicsEngine.RemoveRigidBody(item.RigidBody)
+	}
+	for _, wall := range m.walls {
+		m.physicsEngine.RemoveRigidBody(wall)
+	}
+	for _, body := range m.logicBodies {
+		m.physicsEngine.RemoveRigidBody(body)
+	}
+
+	m.field = false
+	m.level = nil
+	m.levelPath = ""
+	m.Platforms = nil
+	m.Obstacles = nil
+	m.Items = nil
+	m.SpawnPoints = nil
+	m.Triggers = nil
+	m.Checkpoints = nil
+	m.Hazards = nil
+	m.Enemies = nil
+	m.Logic = nil
+	m.circuit = nil
+	m.logicBodies = nil
+	m.spawned = nil
+	m.TileLayers = nil
+	m.Tilesets = nil
+	m.walls = nil
+	m.Invalidate()
+	m.tileMap = nil
+	m.staticPlatforms = nil
+}
+
+// Level returns the data of the level currently loaded, or nil.
+func (m *Map) Level() *LevelData {
+	return m.level
+}
+
+// LevelPath returns the file the current level was loaded from, if any.
+func (m *Map) LevelPath() string {
+	return m.levelPath
+}
+
+// GetSpawnPoint returns the spawn point with the given name.
+func (m *Map) GetSpawnPoint(name string) (SpawnPoint, bool) {
+	for _, spawn := range m.SpawnPoints {
+		if spawn.Name == name {
+			return spawn, true
+		}
+	}
+	return SpawnPoint{}, false
+}
@@ -0,0 +1,183 @@
+package gameMap
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/logic"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// isBarrier reports whether logic objects of a kind block the way while off.
+func isBarrier(kind logic.Kind) bool {
+	return kind == logic.KindDoor || kind == logic.KindTimedGate
+}
+
+// buildLogic wires the logic objects of the level together and closes the
+// doors that start closed.
+func (m *Map) buildLogic(level *LevelData) {
+	if len(level.Logic) == 0 {
+		return
+	}
+	circuit, err := level.Circuit()
+	if err != nil {
+		log.Printf("level logic: %v", err)
+		return
+	}
+	m.circuit = circuit
+	m.logicBodies = map[string]*physics.RigidBody{}
+	m.spawned = map[string][]*physics.RigidBody{}
+	for _, object := range m.Logic {
+		if !isBarrier(object.Kind) || object.Body == nil {
+			continue
+		}
+		body := physics.NewRigidBody(object.Body.Position, object.Body.Size, 1, true, string(object.Kind))
+		m.logicBodies[object.ID] = body
+		if !circuit.On(object.ID) {
+			m.physicsEngine.AddRigidBody(body)
+		}
+	}
+}
+
+// UpdateLogic presses the plates something rests on, flips the switches the
+// player interacts with and acts on the objects that changed.
+func (m *Map) UpdateLogic(deltaTime float64, player interfaces.Rect, interact bool) {
+	if m.circuit == nil {
+		return
+	}
+	for _, object := range m.Logic {
+		if object.Body == nil {
+			continue
+		}
+		switch object.Kind {
+		case logic.KindSwitch:
+			if interact && overlaps(player, *object.Body) {
+				m.circuit.Flip(object.ID)
+			}
+		case logic.KindPlate:
+			m.circuit.Set(object.ID, m.isPressed(*object.Body))
+		}
+	}
+
+	changed := map[string]bool{}
+	for _, id := range m.circuit.Update(deltaTime) {
+		changed[id] = true
+	}
+	for _, object := range m.Logic {
+		if !changed[object.ID] {
+			continue
+		}
+		on := m.circuit.On(object.ID)
+		if body, ok := m.logicBodies[object.ID]; ok {
+			if on {
+				m.physicsEngine.RemoveRigidBody(body)
+			} else {
+				m.physicsEngine.AddRigidBody(body)
+			}
+		}
+		if object.Kind == logic.KindSpawner && on {
+			m.spawn(object)
+		}
+		m.eventManager.Dispatch(interfaces.Event{
+			Type:     interfaces.EventLogicChanged,
+			Priority: 1,
+			Payload:  map[string]interface{}{"id": object.ID, "kind": object.Kind, "on": on},
+		})
+	}
+}
+
+// LogicOn reports whether the logic object with the id is on.
+func (m *Map) LogicOn(id string) bool {
+	return m.circuit != nil && m.circuit.On(id)
+}
+
+// isPressed reports whether a moving or pushable body rests on the area.
+func (m *Map) isPressed(area interfaces.Rect) bool {
+	// Bodies stand on top of plates, so reach a pixel above them.
+	area.Position.Y--
+	area.Size.Y++
+	for _, rb := range m.physicsEngine.GetRigidBodies() {
+		body, ok := rb.(*physics.RigidBody)
+		if !ok || (body.IsStatic && !body.IsPushable) {
+			continue
+		}
+		if overlaps(area, physics.BodyRect(body)) {
+			return true
+		}
+	}
+	return false
+}
+
+// spawn creates a pushable obstacle in the area of a spawner, removing the
+// oldest one it spawned once there are more than its limit.
+func (m *Map) spawn(object LogicObject) {
+	if object.Body == nil || object.Spawn == "" {
+		return
+	}
+	body := physics.NewRigidBody(object.Body.Position, object.Body.Size, 1, true, object.Spawn)
+	body.IsPushable = true
+	m.Obstacles = append(m.Obstacles, Obstacle{
+		Type:      object.Spawn,
+		RigidBody: body,
+		Movement:  Movement{InitialPosX: body.Position.X, InitialPosY: body.Position.Y},
+	})
+	m.physicsEngine.AddRigidBody(body)
+
+	limit := object.Limit
+	if limit <= 0 {
+		limit = 1
+	}
+	m.spawned[object.ID] = append(m.spawned[object.ID], body)
+	for len(m.spawned[object.ID]) > limit {
+		m.removeObstacle(m.spawned[object.ID][0])
+		m.spawned[object.ID] = m.spawned[object.ID][1:]
+	}
+}
+
+// removeObstacle takes the obstacle with the given body off the map.
+func (m *Map) removeObstacle(body *physics.RigidBody) {
+	for i, obstacle := range m.Obstacles {
+		if obstacle.RigidBody == body {
+			m.Obstacles = append(m.Obstacles[:i], m.Obstacles[i+1:]...)
+			break
+		}
+	}
+	m.physicsEngine.RemoveRigidBody(body)
+}
+
+// drawLogic draws the logic objects that have an area: closed doors are
+// solid, switches and plates light up green when on.
+func (m *Map) drawLogic(screen *ebiten.Image, offsetX, offsetY float64) {
+	for _, object := range m.Logic {
+		if object.Body == nil {
+			continue
+		}
+		x, y := float32(object.Body.Position.X-offsetX), float32(object.Body.Position.Y-offsetY)
+		w, h := float32(object.Body.Size.X), float32(object.Body.Size.Y)
+		on := m.circuit != nil && m.circuit.On(object.ID)
+		state := color.RGBA{200, 50, 50, 255}
+		if on {
+			state = color.RGBA{50, 205, 50, 255}
+		}
+		switch object.Kind {
+		case logic.KindDoor, logic.KindTimedGate:
+			if on {
+				vector.StrokeRect(screen, x, y, w, h, 1, color.RGBA{139, 90, 43, 160}, false)
+			} else {
+				vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{139, 90, 43, 255}, false)
+			}
+		case logic.KindSwitch, logic.KindPlate:
+			vector.DrawFilledRect(screen, x, y, w, h, state, false)
+		case logic.KindSpawner:
+			vector.StrokeRect(screen, x, y, w, h, 1, color.RGBA{160, 32, 240, 200}, false)
+		}
+	}
+}
+
+func overlaps(a, b interfaces.Rect) bool {
+	return a.Position.X < b.Position.X+b.Size.X && b.Position.X < a.Position.X+a.Size.X &&
+		a.Position.Y < b.Position.Y+b.Size.Y && b.Position.Y < a.Position.Y+a.Size.Y
+}
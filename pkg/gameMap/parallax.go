@@ -0,0 +1,103 @@
+package gameMap
+
+import (
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/levelfile"
+)
+
+// parallaxLayer is a layer ready to draw.
+type parallaxLayer struct {
+	ParallaxLayer
+	image *ebiten.Image
+	tint  ebiten.ColorScale
+}
+
+// ParseTint parses a "#rrggbb" or "#aarrggbb" color, the format Tiled uses,
+// into the color scale to draw with. An empty string is no tint.
+func ParseTint(value string) (ebiten.ColorScale, error) {
+	var scale ebiten.ColorScale
+	tint, err := levelfile.ParseTint(value)
+	if err != nil {
+		return scale, err
+	}
+	scale.ScaleWithColor(tint)
+	return scale, nil
+}
+
+// loadLayers prepares the parallax layers of a level. A level with only a
+// background gets it as a single layer that moves with the level.
+func (m *Map) loadLayers(level *LevelData) {
+	layers := level.Layers
+	if len(layers) == 0 && level.Background != "" {
+		layers = []ParallaxLayer{{Image: level.Background, ScrollX: 1, ScrollY: 1}}
+	}
+
+	m.layers = nil
+	for _, layer := range layers {
+		image, err := m.resourceManager.LoadImage(layer.Image)
+		if err != nil {
+			log.Printf("failed to load layer image %s: %v", layer.Image, err)
+			continue
+		}
+		tint, err := ParseTint(layer.Tint)
+		if err != nil {
+			log.Printf("layer %s: %v", layer.Image, err)
+		}
+		m.layers = append(m.layers, parallaxLayer{ParallaxLayer: layer, image: image, tint: tint})
+	}
+}
+
+// drawLayers draws the background or the foreground layers in the order the
+// level declares them.
+func (m *Map) drawLayers(screen *ebiten.Image, offsetX, offsetY float64, foreground bool) {
+	screenWidth, screenHeight := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+	for _, layer := range m.layers {
+		if layer.Foreground != foreground {
+			continue
+		}
+		width, height := float64(layer.image.Bounds().Dx()), float64(layer.image.Bounds().Dy())
+		if width == 0 || height == 0 {
+			continue
+		}
+		x := layer.Offset.X + layer.AutoScroll.X*m.elapsed - offsetX*layer.ScrollX
+		y := layer.Offset.Y + layer.AutoScroll.Y*m.elapsed - offsetY*layer.ScrollY
+
+		// Repeating layers start one tile before the screen edge and cover it.
+		startX, endX := x, x+width
+		if layer.RepeatX {
+			startX = math.Mod(x, width)
+			if startX > 0 {
+				startX -= width
+			}
+			endX = screenWidth
+		}
+		startY, endY := y, y+height
+		if layer.RepeatY {
+			startY = math.Mod(y, height)
+			if startY > 0 {
+				startY -= height
+			}
+			endY = screenHeight
+		}
+
+		for tileY := startY; tileY < endY; tileY += height {
+			for tileX := startX; tileX < endX; tileX += width {
+				opts := &ebiten.DrawImageOptions{}
+				opts.GeoM.Translate(tileX, tileY)
+				opts.ColorScale = layer.tint
+				screen.DrawImage(layer.image, opts)
+			}
+		}
+	}
+}
+
+// DrawForeground draws the foreground layers; call it after everything that
+// should appear behind them.
+func (m *Map) DrawForeground(screen *ebiten.Image, camera interfaces.Camera) {
+	offsetX, offsetY := camera.GetOffset()
+	m.drawLayers(screen, offsetX, offsetY, true)
+}
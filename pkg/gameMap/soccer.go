@@ -0,0 +1,247 @@
+package gameMap
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/formation"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// Soccer field dimensions. The goals sit in the middle of the left and right
+// sides of the field.
+const (
+	FieldWidth    = 800.0
+	FieldHeight   = 600.0
+	GoalDepth     = 20.0
+	GoalHeight    = 150.0
+	wallThickness = 20.0
+	playerSize    = 30.0
+)
+
+// BallName is the name of the soccer ball among the items of the map.
+const BallName = "soccer_ball"
+
+// Teams of a soccer match. Blue defends the left goal and red the right one.
+const (
+	TeamBlue = "blue"
+	TeamRed  = "red"
+)
+
+// SetupSoccerField replaces everything on the map with a soccer field: the
+// ball, both teams lined up in their formations and walls around the field
+// except at the goals.
+func (m *Map) SetupSoccerField(blue, red formation.Formation) {
+	m.Reset()
+	m.field = true
+
+	ball := physics.NewRigidBody(m.fieldCenter(), interfaces.Vector2D{X: 20, Y: 20}, 0.5, false, BallName)
+	ball.SetCanPick(true)
+	// Make the ball move more naturally - lower mass and friction
+	ball.Mass = 0.2
+	ball.Friction = 0.98
+	m.physicsEngine.AddRigidBody(ball)
+	m.Items = append(m.Items, ItemOnMap{Name: BallName, RigidBody: ball, Item: &SoccerBall{Name: BallName}})
+
+	m.SetupTeam(TeamBlue, blue)
+	m.SetupTeam(TeamRed, red)
+	m.addBoundaryWalls()
+}
+
+// Ball returns the body of the soccer ball, if the field is set up.
+func (m *Map) Ball() (*physics.RigidBody, bool) {
+	balls := m.Balls()
+	if len(balls) == 0 {
+		return nil, false
+	}
+	return balls[0], true
+}
+
+// Balls returns the bodies of every soccer ball on the map.
+func (m *Map) Balls() []*physics.RigidBody {
+	var balls []*physics.RigidBody
+	for _, item := range m.Items {
+		if _, ok := item.Item.(*SoccerBall); ok {
+			balls = append(balls, item.RigidBody)
+		}
+	}
+	return balls
+}
+
+// ResetBall puts the ball back on the center spot.
+func (m *Map) ResetBall() {
+	if ball, ok := m.Ball(); ok {
+		ball.Teleport(m.fieldCenter())
+	}
+}
+
+// Goal returns the goal the given team defends.
+func (m *Map) Goal(team string) interfaces.Rect {
+	x := 0.0
+	if team == TeamRed {
+		x = FieldWidth - GoalDepth
+	}
+	return interfaces.Rect{
+		Position: interfaces.Vector2D{X: x, Y: FieldHeight/2 - GoalHeight/2},
+		Size:     interfaces.Vector2D{X: GoalDepth, Y: GoalHeight},
+	}
+}
+
+func (m *Map) fieldCenter() interfaces.Vector2D {
+	return interfaces.Vector2D{X: FieldWidth / 2, Y: FieldHeight / 2}
+}
+
+// SetupTeam lines the players of a team up in a formation as obstacles,
+// replacing the ones it had. They are named after their team and shirt
+// number, e.g. "blue_9", and their role is the type of the obstacle. The red
+// team defends the goal on the right, so its formation is mirrored.
+func (m *Map) SetupTeam(team string, lineUp formation.Formation) {
+	obstacles := m.Obstacles[:0]
+	for _, obstacle := range m.Obstacles {
+		if obstacle.Properties["team"] == team {
+			m.physicsEngine.RemoveRigidBody(obstacle.RigidBody)
+			continue
+		}
+		obstacles = append(obstacles, obstacle)
+	}
+	m.Obstacles = obstacles
+
+	field := interfaces.Rect{Size: interfaces.Vector2D{X: FieldWidth, Y: FieldHeight}}
+	for i, placement := range lineUp.Place(field, team == TeamRed) {
+		obstacle := Obstacle{
+			Type: placement.Role,
+			RigidBody: physics.NewRigidBody(
+				interfaces.Vector2D{X: placement.Position.X - playerSize/2, Y: placement.Position.Y - playerSize/2},
+				interfaces.Vector2D{X: playerSize, Y: playerSize},
+				1, true, fmt.Sprintf("%s_%d", team, i+1),
+			),
+			Properties: map[string]interface{}{"team": team},
+		}
+		m.Obstacles = append(m.Obstacles, obstacle)
+		m.physicsEngine.AddRigidBody(obstacle.RigidBody)
+	}
+}
+
+// addBoundaryWalls keeps the players and the ball inside the field, leaving
+// the goals open.
+func (m *Map) addBoundaryWalls() {
+	sideHeight := FieldHeight/2 - GoalHeight/2
+	walls := []struct {
+		name       string
+		x, y, w, h float64
+	}{
+		{"wall_top", 0, -wallThickness, FieldWidth, wallThickness},
+		{"wall_bottom", 0, FieldHeight, FieldWidth, wallThickness},
+		{"wall_left_top", -wallThickness, 0, wallThickness, sideHeight},
+		{"wall_left_bottom", -wallThickness, FieldHeight - sideHeight, wallThickness, sideHeight},
+		{"wall_right_top", FieldWidth, 0, wallThickness, sideHeight},
+		{"wall_right_bottom", FieldWidth, FieldHeight - sideHeight, wallThickness, sideHeight},
+	}
+	for _, wall := range walls {
+		body := physics.NewRigidBody(interfaces.Vector2D{X: wall.x, Y: wall.y}, interfaces.Vector2D{X: wall.w, Y: wall.h}, 100, true, wall.name)
+		m.physicsEngine.AddRigidBody(body)
+		m.walls = append(m.walls, body)
+	}
+}
+
+// updateBall rolls the ball back toward the center of the field when nobody
+// pushes it.
+func (m *Map) updateBall(deltaTime float64) {
+	ball, ok := m.Ball()
+	if !ok {
+		return
+	}
+	center := m.fieldCenter()
+	dirX := center.X - ball.Position.X
+	dirY := center.Y - ball.Position.Y
+	distance := math.Sqrt(dirX*dirX + dirY*dirY)
+
+	// Only apply force if the ball is not at the center and moving slowly
+	if distance > 5.0 && math.Abs(ball.Velocity.X) < 50 && math.Abs(ball.Velocity.Y) < 50 {
+		forceStrength := 10.0 * deltaTime
+		ball.ApplyForce(interfaces.Vector2D{
+			X: dirX / distance * forceStrength,
+			Y: dirY / distance * forceStrength,
+		})
+	}
+}
+
+// SoccerBall implements the interfaces.Item interface
+type SoccerBall struct {
+	Name string
+}
+
+func (sb *SoccerBall) GetName() string {
+	return sb.Name
+}
+
+func (sb *SoccerBall) GetDescription() string {
+	return "A soccer ball"
+}
+
+func (sb *SoccerBall) GetIconPath() string {
+	return "/images/icons/ball.png" // This path might not exist, handled in Draw method
+}
+
+func (sb *SoccerBall) GetImagePath() string {
+	return "/images/icons/ball.png" // This path might not exist, handled in Draw method
+}
+
+func (sb *SoccerBall) GetAbilities() []string {
+	return []string{}
+}
+
+func (sb *SoccerBall) GetResistances() map[interfaces.DamageType]float64 {
+	return nil
+}
+
+func (sb *SoccerBall) GetAppearance() interfaces.Appearance {
+	return interfaces.Appearance{
+		Type:     "ball",
+		Color:    "black and white",
+		Material: "leather",
+	}
+}
+
+func (sb *SoccerBall) GetVersion() int {
+	return 1
+}
+
+// drawField draws the soccer pitch, its lines and the goals.
+func (m *Map) drawField(screen *ebiten.Image, offsetX, offsetY float64) {
+	fieldX := -offsetX
+	fieldY := -offsetY
+	white := color.RGBA{255, 255, 255, 255}
+
+	// Green field with a white center line
+	vector.DrawFilledRect(screen, float32(fieldX), float32(fieldY), FieldWidth, FieldHeight, color.RGBA{34, 139, 34, 255}, true)
+	vector.DrawFilledRect(screen, float32(fieldX+FieldWidth/2-2), float32(fieldY), 4, FieldHeight, white, true)
+
+	// Center circle
+	centerX := float32(fieldX + FieldWidth/2)
+	centerY := float32(fieldY + FieldHeight/2)
+	radius := float32(50)
+	segments := 30
+	for i := 0; i < segments; i++ {
+		angle1 := float64(i) * 2 * math.Pi / float64(segments)
+		angle2 := float64(i+1) * 2 * math.Pi / float64(segments)
+		x1 := centerX + radius*float32(math.Cos(angle1))
+		y1 := centerY + radius*float32(math.Sin(angle1))
+		x2 := centerX + radius*float32(math.Cos(angle2))
+		y2 := centerY + radius*float32(math.Sin(angle2))
+		vector.StrokeLine(screen, x1, y1, x2, y2, 2, white, true)
+	}
+
+	// Goals
+	for _, team := range []string{TeamBlue, TeamRed} {
+		goal := m.Goal(team)
+		vector.DrawFilledRect(screen,
+			float32(goal.Position.X-offsetX), float32(goal.Position.Y-offsetY),
+			float32(goal.Size.X), float32(goal.Size.Y),
+			color.RGBA{200, 200, 200, 255}, true)
+	}
+}
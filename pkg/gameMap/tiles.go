@@ -0,0 +1,103 @@
+package gameMap
+
+import (
+	"image"
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/pkg/tilemap"
+)
+
+// isStaticPlatform reports whether a platform never changes on its own and can
+// be drawn from the chunk cache.
+func isStaticPlatform(platform Platform) bool {
+	return platform.Kind != PlatformMoving && platform.Kind != PlatformCrumbling
+}
+
+// buildTileMap prepares the chunked renderers for the tile layers and the
+// static platforms of the level.
+func (m *Map) buildTileMap() {
+	var tilesets []*tilemap.Tileset
+	for _, tileset := range m.Tilesets {
+		sheet, err := m.resourceManager.LoadImage(tileset.Image)
+		if err != nil {
+			log.Printf("failed to load tileset %s: %v", tileset.Image, err)
+			continue
+		}
+		tilesets = append(tilesets, &tilemap.Tileset{
+			FirstGID:   tileset.FirstGID,
+			Image:      sheet,
+			TileWidth:  tileset.TileWidth,
+			TileHeight: tileset.TileHeight,
+			Columns:    tileset.Columns,
+			Margin:     tileset.Margin,
+			Spacing:    tileset.Spacing,
+			Autotiles:  tileset.Autotiles,
+		})
+	}
+
+	m.tileMap = tilemap.NewTileMap(tilesets, tilemap.DefaultChunkSize)
+	for _, layer := range m.TileLayers {
+		m.tileMap.AddLayer(tilemap.LayerConfig{
+			Name:       layer.Name,
+			Width:      layer.Width,
+			Height:     layer.Height,
+			TileWidth:  layer.TileWidth,
+			TileHeight: layer.TileHeight,
+			Offset:     layer.Offset,
+			Opacity:    layer.Opacity,
+			Visible:    layer.Visible,
+			Data:       layer.Data,
+		})
+	}
+	m.staticPlatforms = tilemap.NewChunkCache(tilemap.DefaultChunkSize, m.renderStaticPlatforms)
+}
+
+// renderStaticPlatforms draws the static level platforms overlapping area.
+func (m *Map) renderStaticPlatforms(dst *ebiten.Image, area image.Rectangle) bool {
+	drawn := false
+	for _, platform := range m.Platforms {
+		if !isStaticPlatform(platform) {
+			continue
+		}
+		rb := platform.RigidBody
+		bounds := image.Rect(
+			int(math.Floor(rb.Position.X)), int(math.Floor(rb.Position.Y)),
+			int(math.Ceil(rb.Position.X+rb.Size.X)), int(math.Ceil(rb.Position.Y+rb.Size.Y)))
+		if !bounds.Overlaps(area) {
+			continue
+		}
+		vector.DrawFilledRect(dst,
+			float32(rb.Position.X-float64(area.Min.X)),
+			float32(rb.Position.Y-float64(area.Min.Y)),
+			float32(rb.Size.X),
+			float32(rb.Size.Y),
+			platformColor(platform),
+			true)
+		drawn = true
+	}
+	return drawn
+}
+
+// drawTileLayers draws the tile layers and the static platforms of the level.
+func (m *Map) drawTileLayers(screen *ebiten.Image, offsetX, offsetY float64) {
+	if m.tileMap != nil {
+		m.tileMap.Draw(screen, offsetX, offsetY)
+	}
+	if m.staticPlatforms != nil {
+		m.staticPlatforms.Draw(screen, offsetX, offsetY, ebiten.ColorScale{})
+	}
+}
+
+// Invalidate redraws the cached tile layers and static platforms, e.g. after
+// moving a platform in place.
+func (m *Map) Invalidate() {
+	if m.tileMap != nil {
+		m.tileMap.Invalidate()
+	}
+	if m.staticPlatforms != nil {
+		m.staticPlatforms.Invalidate()
+	}
+}
@@ -0,0 +1,191 @@
+// Package health keeps the hit points and lives of a character, with damage
+// types it can resist and a short invulnerability after each hit.
+package health
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// Config holds the health settings.
+type Config struct {
+	MaxHP float64
+	Lives int
+	// Invulnerability is how long, in seconds, hits are ignored after one lands.
+	Invulnerability float64
+	// BlinkInterval is how long, in seconds, the character is shown and then
+	// hidden while invulnerable.
+	BlinkInterval float64
+}
+
+// Health implements interfaces.Health.
+type Health struct {
+	config       Config
+	eventManager interfaces.EventManager
+	hp           float64
+	lives        int
+	invulnerable float64
+	resistances  map[interfaces.DamageType]float64
+}
+
+// NewHealth creates a character at full health, filling in defaults for the
+// settings left at zero.
+func NewHealth(config Config, eventManager interfaces.EventManager) *Health {
+	if config.MaxHP <= 0 {
+		config.MaxHP = 100
+	}
+	if config.Lives <= 0 {
+		config.Lives = 3
+	}
+	if config.Invulnerability <= 0 {
+		config.Invulnerability = 1
+	}
+	if config.BlinkInterval <= 0 {
+		config.BlinkInterval = 0.1
+	}
+	return &Health{
+		config:       config,
+		eventManager: eventManager,
+		hp:           config.MaxHP,
+		lives:        config.Lives,
+		resistances:  map[interfaces.DamageType]float64{},
+	}
+}
+
+// HP returns the hit points left.
+func (h *Health) HP() float64 {
+	return h.hp
+}
+
+// MaxHP returns the hit points at full health.
+func (h *Health) MaxHP() float64 {
+	return h.config.MaxHP
+}
+
+// Lives returns the lives left, including the current one.
+func (h *Health) Lives() int {
+	return h.lives
+}
+
+// Dead reports whether the character ran out of hit points.
+func (h *Health) Dead() bool {
+	return h.hp <= 0
+}
+
+// GameOver reports whether the character ran out of lives.
+func (h *Health) GameOver() bool {
+	return h.lives <= 0
+}
+
+// Invulnerable reports whether hits are ignored after a recent one.
+func (h *Health) Invulnerable() bool {
+	return h.invulnerable > 0
+}
+
+// Visible reports whether to draw the character; it blinks while invulnerable.
+func (h *Health) Visible() bool {
+	if !h.Invulnerable() {
+		return true
+	}
+	elapsed := h.config.Invulnerability - h.invulnerable
+	return int(elapsed/h.config.BlinkInterval)%2 == 0
+}
+
+// AddResistance makes the character block part of the damage of a type.
+// Resistances stack: two items blocking half of it each block three quarters.
+func (h *Health) AddResistance(damageType interfaces.DamageType, amount float64) {
+	amount = math.Max(0, math.Min(amount, 1))
+	h.resistances[damageType] = 1 - (1-h.resistances[damageType])*(1-amount)
+}
+
+// Resistance returns the fraction of the damage of a type that is blocked.
+func (h *Health) Resistance(damageType interfaces.DamageType) float64 {
+	return h.resistances[damageType]
+}
+
+// Update counts down the invulnerability.
+func (h *Health) Update(deltaTime float64) {
+	h.invulnerable = math.Max(h.invulnerable-deltaTime, 0)
+}
+
+// TakeDamage applies a hit and returns the damage actually taken, which is
+// zero while invulnerable, dead or when the damage type is fully resisted.
+func (h *Health) TakeDamage(hit interfaces.Hit) float64 {
+	if h.Dead() || h.Invulnerable() {
+		return 0
+	}
+	damageType := hit.Type
+	if damageType == "" {
+		damageType = interfaces.DamagePhysical
+	}
+	amount := math.Min(hit.Amount*(1-h.Resistance(damageType)), h.hp)
+	if amount <= 0 {
+		return 0
+	}
+	h.hp -= amount
+	h.invulnerable = h.config.Invulnerability
+	h.dispatch(interfaces.EventDamageTaken, map[string]interface{}{
+		"amount": amount,
+		"type":   damageType,
+		"source": hit.Source,
+		"hp":     h.hp,
+	})
+	if h.Dead() {
+		h.die()
+	}
+	h.changed()
+	return amount
+}
+
+// Kill takes a life no matter how many hit points are left.
+func (h *Health) Kill() {
+	if h.Dead() {
+		return
+	}
+	h.hp = 0
+	h.die()
+	h.changed()
+}
+
+// Heal gives back hit points, up to full health.
+func (h *Health) Heal(amount float64) {
+	if h.Dead() || amount <= 0 {
+		return
+	}
+	h.hp = math.Min(h.hp+amount, h.config.MaxHP)
+	h.changed()
+}
+
+// Revive restores full health after dying, briefly invulnerable. After a game
+// over all lives are given back as well.
+func (h *Health) Revive() {
+	if h.GameOver() {
+		h.lives = h.config.Lives
+	}
+	h.hp = h.config.MaxHP
+	h.invulnerable = h.config.Invulnerability
+	h.changed()
+}
+
+func (h *Health) die() {
+	h.lives--
+	if h.GameOver() {
+		h.dispatch(interfaces.EventGameOver, nil)
+	}
+}
+
+func (h *Health) changed() {
+	h.dispatch(interfaces.EventHealthChanged, map[string]interface{}{
+		"hp":    h.hp,
+		"maxHP": h.config.MaxHP,
+		"lives": h.lives,
+	})
+}
+
+func (h *Health) dispatch(eventType interfaces.EventType, payload map[string]interface{}) {
+	if h.eventManager == nil {
+		return
+	}
+	h.eventManager.Dispatch(interfaces.Event{Type: eventType, Priority: 1, Payload: payload})
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Health Suite")
+}
+
+type recordedEvents struct {
+	events []interfaces.EventType
+}
+
+func (r *recordedEvents) RegisterHandler(interfaces.EventType, interfaces.EventHandler) {}
+func (r *recordedEvents) Dispatch(event interfaces.Event)                               { r.events = append(r.events, event.Type) }
+func (r *recordedEvents) Wait()                                                         {}
+
+func hit(amount float64, damageType interfaces.DamageType) interfaces.Hit {
+	return interfaces.Hit{Damage: interfaces.Damage{Amount: amount, Type: damageType}, Source: "test"}
+}
+
+var _ = Describe("Health", func() {
+	var (
+		events *recordedEvents
+		health *Health
+	)
+
+	BeforeEach(func() {
+		events = &recordedEvents{}
+		health = NewHealth(Config{MaxHP: 50, Lives: 2, Invulnerability: 1, BlinkInterval: 0.1}, events)
+	})
+
+	It("should take damage and ignore hits while invulnerable", func() {
+		Expect(health.TakeDamage(hit(20, ""))).To(Equal(20.0))
+		Expect(health.TakeDamage(hit(20, ""))).To(Equal(0.0))
+		Expect(health.HP()).To(Equal(30.0))
+		Expect(events.events).To(Equal([]interfaces.EventType{interfaces.EventDamageTaken, interfaces.EventHealthChanged}))
+
+		health.Update(1)
+		Expect(health.Invulnerable()).To(BeFalse())
+		Expect(health.TakeDamage(hit(20, ""))).To(Equal(20.0))
+	})
+
+	It("should blink while invulnerable", func() {
+		health.TakeDamage(hit(10, ""))
+		Expect(health.Visible()).To(BeTrue())
+		health.Update(0.15)
+		Expect(health.Visible()).To(BeFalse())
+		health.Update(1)
+		Expect(health.Visible()).To(BeTrue())
+	})
+
+	It("should stack resistances of the same damage type", func() {
+		health.AddResistance(interfaces.DamageFire, 0.5)
+		health.AddResistance(interfaces.DamageFire, 0.5)
+		Expect(health.Resistance(interfaces.DamageFire)).To(Equal(0.75))
+
+		Expect(health.TakeDamage(hit(20, interfaces.DamageFire))).To(Equal(5.0))
+		health.Update(1)
+		Expect(health.TakeDamage(hit(20, interfaces.DamagePhysical))).To(Equal(20.0))
+	})
+
+	It("should not make the player invulnerable for fully resisted hits", func() {
+		health.AddResistance(interfaces.DamagePoison, 1)
+		Expect(health.TakeDamage(hit(20, interfaces.DamagePoison))).To(Equal(0.0))
+		Expect(health.Invulnerable()).To(BeFalse())
+	})
+
+	It("should lose a life when dying and give them back after a game over", func() {
+		health.TakeDamage(hit(100, ""))
+		Expect(health.Dead()).To(BeTrue())
+		Expect(health.Lives()).To(Equal(1))
+
+		health.Revive()
+		Expect(health.HP()).To(Equal(50.0))
+		health.Update(1)
+		health.Kill()
+		Expect(health.GameOver()).To(BeTrue())
+		Expect(events.events).To(ContainElement(interfaces.EventGameOver))
+
+		health.Revive()
+		Expect(health.Lives()).To(Equal(2))
+		Expect(health.Dead()).To(BeFalse())
+	})
+})
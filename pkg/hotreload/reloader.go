@@ -0,0 +1,100 @@
+package hotreload
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Handler reloads a changed file.
+type Handler func(path string) error
+
+// Reloader runs the handlers of changed files on the game loop and keeps the
+// errors of failed reloads so they can be shown in game.
+type Reloader struct {
+	watcher  *Watcher
+	handlers map[string]Handler
+	failures map[string]error
+}
+
+// NewReloader creates a reloader for the files of watcher.
+func NewReloader(watcher *Watcher) *Reloader {
+	return &Reloader{
+		watcher:  watcher,
+		handlers: make(map[string]Handler),
+		failures: make(map[string]error),
+	}
+}
+
+// Handle watches path and calls handler whenever it changes, replacing any
+// handler registered for it before.
+func (r *Reloader) Handle(path string, handler Handler) {
+	r.handlers[path] = handler
+	r.watcher.Watch(path)
+}
+
+// Report records the outcome of loading path outside the reloader, e.g. at
+// startup, so a failure shows up until the file is fixed.
+func (r *Reloader) Report(path string, err error) {
+	if err != nil {
+		log.Printf("failed to load %s: %v", path, err)
+		r.failures[path] = err
+		return
+	}
+	delete(r.failures, path)
+}
+
+// Update reloads the files that changed since the last call. It must run on
+// the game loop, as handlers change the game state.
+func (r *Reloader) Update() {
+	for {
+		select {
+		case path := <-r.watcher.Changes():
+			if handler, ok := r.handlers[path]; ok {
+				log.Printf("reloading %s", path)
+				r.Report(path, r.run(handler, path))
+			}
+		default:
+			return
+		}
+	}
+}
+
+// run calls handler, turning a panic into an error so a broken file cannot
+// take the game down.
+func (r *Reloader) run(handler Handler, path string) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return handler(path)
+}
+
+// Errors returns the failed files with their errors, sorted by path.
+func (r *Reloader) Errors() []string {
+	var errors []string
+	for path, err := range r.failures {
+		errors = append(errors, fmt.Sprintf("%s: %v", path, err))
+	}
+	sort.Strings(errors)
+	return errors
+}
+
+// Draw shows the reload errors over the top of the screen.
+func (r *Reloader) Draw(screen *ebiten.Image) {
+	errors := r.Errors()
+	if len(errors) == 0 {
+		return
+	}
+	message := "Reload failed, fix the file to retry:\n" + strings.Join(errors, "\n")
+	height := float32(16*(len(errors)+1) + 8)
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), height, color.RGBA{140, 0, 0, 220}, false)
+	ebitenutil.DebugPrintAt(screen, message, 8, 4)
+}
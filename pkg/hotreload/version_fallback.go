@@ -0,0 +1,18 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package hotreload
+
+import (
+	"fmt"
+	"os"
+)
+
+// fileVersion identifies the contents of a file by its modification time and size.
+func fileVersion(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size()), nil
+}
@@ -0,0 +1,53 @@
+//go:build js && wasm
+// +build js,wasm
+
+package hotreload
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"syscall/js"
+)
+
+// fileVersion identifies the contents of a file by a hash of the body served
+// for it. The request bypasses the browser cache so edits are seen at once.
+func fileVersion(path string) (string, error) {
+	type result struct {
+		body string
+		err  error
+	}
+	done := make(chan result, 1)
+
+	onText := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		done <- result{body: args[0].String()}
+		return nil
+	})
+	defer onText.Release()
+	onResponse := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		response := args[0]
+		if !response.Get("ok").Bool() {
+			done <- result{err: fmt.Errorf("fetching %s: status %d", path, response.Get("status").Int())}
+			return nil
+		}
+		return response.Call("text").Call("then", onText)
+	})
+	defer onResponse.Release()
+	onError := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		done <- result{err: errors.New(js.Global().Get("String").Invoke(args[0]).String())}
+		return nil
+	})
+	defer onError.Release()
+
+	js.Global().Call("fetch", path, map[string]interface{}{"cache": "no-store"}).
+		Call("then", onResponse).
+		Call("catch", onError)
+
+	fetched := <-done
+	if fetched.err != nil {
+		return "", fetched.err
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(fetched.body))
+	return fmt.Sprintf("%x", hash.Sum64()), nil
+}
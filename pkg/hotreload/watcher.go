@@ -0,0 +1,98 @@
+package hotreload
+
+import (
+	"sync"
+	"time"
+)
+
+// Watcher polls files and reports the ones whose contents changed. Polling
+// works the same natively and in the browser, where files are fetched from
+// the server.
+type Watcher struct {
+	interval time.Duration
+	mu       sync.Mutex
+	// versions maps each watched path to the last version seen; an empty
+	// version means the file has not been read yet.
+	versions map[string]string
+	changes  chan string
+	stop     chan struct{}
+}
+
+// NewWatcher creates a watcher checking its files every interval.
+func NewWatcher(interval time.Duration) *Watcher {
+	return &Watcher{
+		interval: interval,
+		versions: make(map[string]string),
+		changes:  make(chan string, 16),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Watch adds a file to the watcher; watching a file twice has no effect.
+func (w *Watcher) Watch(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, exists := w.versions[path]; !exists {
+		w.versions[path] = ""
+	}
+}
+
+// Changes returns the channel the paths of changed files are sent to.
+func (w *Watcher) Changes() <-chan string {
+	return w.changes
+}
+
+// Start polls the watched files in the background until Stop is called.
+func (w *Watcher) Start() {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.poll()
+			}
+		}
+	}()
+}
+
+// Stop ends polling.
+func (w *Watcher) Stop() {
+	close(w.stop)
+}
+
+// poll checks every watched file once.
+func (w *Watcher) poll() {
+	w.mu.Lock()
+	paths := make([]string, 0, len(w.versions))
+	for path := range w.versions {
+		paths = append(paths, path)
+	}
+	w.mu.Unlock()
+
+	for _, path := range paths {
+		// Files that cannot be read, e.g. while an editor saves them, keep
+		// their last version and are checked again on the next poll.
+		version, err := fileVersion(path)
+		if err != nil {
+			continue
+		}
+
+		w.mu.Lock()
+		previous := w.versions[path]
+		changed := previous != "" && previous != version
+		if changed {
+			select {
+			case w.changes <- path:
+			default:
+				// Nobody is reading; report the change on a later poll.
+				w.mu.Unlock()
+				continue
+			}
+		}
+		w.versions[path] = version
+		w.mu.Unlock()
+	}
+}
@@ -0,0 +1,44 @@
+package hotreload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHotreload(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hotreload Suite")
+}
+
+var _ = Describe("Watcher", func() {
+	var (
+		watcher *Watcher
+		file    string
+	)
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "items.json")
+		Expect(os.WriteFile(file, []byte("[]"), 0644)).To(Succeed())
+		watcher = NewWatcher(10 * time.Millisecond)
+		watcher.Watch(file)
+		watcher.Start()
+		DeferCleanup(watcher.Stop)
+	})
+
+	It("should not report files that did not change", func() {
+		Consistently(watcher.Changes(), 100*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("should report a file once it changes", func() {
+		// Let the watcher record the first version before changing it.
+		time.Sleep(50 * time.Millisecond)
+		Expect(os.WriteFile(file, []byte(`[{"name": "shield"}]`), 0644)).To(Succeed())
+		Eventually(watcher.Changes()).Should(Receive(Equal(file)))
+		Consistently(watcher.Changes(), 100*time.Millisecond).ShouldNot(Receive())
+	})
+})
@@ -7,7 +7,10 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
 	"github.com/joaorufino/gopher-game/pkg/score"
+	"github.com/joaorufino/gopher-game/pkg/stats"
 	"golang.org/x/image/font"
 )
 
@@ -16,8 +19,15 @@ type HUD struct {
 	Font         font.Face
 	ScreenWidth  int
 	ScreenHeight int
+	Health       interfaces.Health
+	Summary      *stats.Summary
 }
 
+const (
+	healthBarWidth  = 150
+	healthBarHeight = 12
+)
+
 func NewHUD(scoreManager *score.ScoreManager, font font.Face, screenWidth, screenHeight int) *HUD {
 	return &HUD{
 		ScoreManager: scoreManager,
@@ -27,7 +37,30 @@ func NewHUD(scoreManager *score.ScoreManager, font font.Face, screenWidth, scree
 	}
 }
 
+// SetHealth shows the given health in the bottom left corner.
+func (h *HUD) SetHealth(health interfaces.Health) {
+	h.Health = health
+}
+
+// SetScore shows the score and time of a match at the top, or hides them
+// when nil.
+func (h *HUD) SetScore(scoreManager *score.ScoreManager) {
+	h.ScoreManager = scoreManager
+}
+
 func (h *HUD) Draw(screen *ebiten.Image) {
+	h.drawHealth(screen)
+	if h.ScoreManager != nil {
+		h.drawScore(screen)
+	}
+	if h.Summary != nil {
+		h.drawSummary(screen)
+	}
+}
+
+// drawScore draws the team scores and, below them, the match time or what
+// the match is waiting for.
+func (h *HUD) drawScore(screen *ebiten.Image) {
 	// Draw team scores at the top center
 	homeTeam := h.ScoreManager.GetTeamName(0)
 	awayTeam := h.ScoreManager.GetTeamName(1)
@@ -35,40 +68,74 @@ func (h *HUD) Draw(screen *ebiten.Image) {
 	awayScore := h.ScoreManager.GetScore(1)
 
 	scoreText := fmt.Sprintf("%s %d - %d %s", homeTeam, homeScore, awayScore, awayTeam)
+	h.drawCentered(screen, scoreText, 0, color.White)
+
+	statusText, statusColor := h.matchStatus()
+	h.drawCentered(screen, statusText, 1, statusColor)
+}
 
+// matchStatus returns the line shown under the score for the phase of the
+// match.
+func (h *HUD) matchStatus() (string, color.Color) {
+	sm := h.ScoreManager
+	yellow := color.RGBA{255, 255, 0, 255}
+	timeInSeconds := sm.GetMatchTime()
+	timeText := fmt.Sprintf("%02d:%02d", timeInSeconds/60, timeInSeconds%60)
+
+	switch sm.Phase() {
+	case score.PhasePreMatch:
+		return "Press Space to Start Match", yellow
+	case score.PhaseHalftime:
+		return "Half Time", yellow
+	case score.PhaseGoal:
+		return "GOAL!", yellow
+	case score.PhaseExtraTime:
+		return "Extra Time " + timeText, color.White
+	case score.PhasePenalties:
+		homePenalties, _ := sm.GetPenalties(0)
+		awayPenalties, _ := sm.GetPenalties(1)
+		return fmt.Sprintf("Penalties %d - %d", homePenalties, awayPenalties), yellow
+	case score.PhaseFullTime:
+		if winner, ok := sm.Winner(); ok {
+			return fmt.Sprintf("%s Wins! Press Space to Play Again", sm.GetTeamName(winner)), yellow
+		}
+		return "Draw! Press Space to Play Again", yellow
+	}
+	return timeText, color.White
+}
+
+// drawCentered draws text centered on the given line at the top of the
+// screen.
+func (h *HUD) drawCentered(screen *ebiten.Image, line string, row int, clr color.Color) {
 	// If we have a font, use it for nicer rendering
 	if h.Font != nil {
-		bounds := text.BoundString(h.Font, scoreText)
+		bounds := text.BoundString(h.Font, line)
 		x := (h.ScreenWidth - bounds.Dx()) / 2
-		text.Draw(screen, scoreText, h.Font, x, 30, color.White)
+		text.Draw(screen, line, h.Font, x, 30+30*row, clr)
 	} else {
 		// Fallback to debug print
-		ebitenutil.DebugPrintAt(screen, scoreText, (h.ScreenWidth-len(scoreText)*6)/2, 20)
+		ebitenutil.DebugPrintAt(screen, line, (h.ScreenWidth-len(line)*6)/2, 20+20*row)
 	}
+}
 
-	// Draw match time if match is active
-	if h.ScoreManager.IsMatchActive() {
-		timeInSeconds := h.ScoreManager.GetMatchTime()
-		minutes := timeInSeconds / 60
-		seconds := timeInSeconds % 60
-		timeText := fmt.Sprintf("%02d:%02d", minutes, seconds)
-
-		if h.Font != nil {
-			bounds := text.BoundString(h.Font, timeText)
-			x := (h.ScreenWidth - bounds.Dx()) / 2
-			text.Draw(screen, timeText, h.Font, x, 60, color.White)
-		} else {
-			ebitenutil.DebugPrintAt(screen, timeText, (h.ScreenWidth-len(timeText)*6)/2, 40)
-		}
-	} else {
-		// Show "Match Ended" or "Press Space to Start" if no match is active
-		statusText := "Press Space to Start Match"
-		if h.Font != nil {
-			bounds := text.BoundString(h.Font, statusText)
-			x := (h.ScreenWidth - bounds.Dx()) / 2
-			text.Draw(screen, statusText, h.Font, x, 60, color.RGBA{255, 255, 0, 255})
-		} else {
-			ebitenutil.DebugPrintAt(screen, statusText, (h.ScreenWidth-len(statusText)*6)/2, 40)
-		}
+// drawHealth draws the hit points as a bar followed by the lives left.
+func (h *HUD) drawHealth(screen *ebiten.Image) {
+	if h.Health == nil {
+		return
 	}
+	x, y := float32(10), float32(h.ScreenHeight-10-healthBarHeight)
+	fill := float32(0)
+	if h.Health.MaxHP() > 0 {
+		fill = float32(h.Health.HP() / h.Health.MaxHP())
+	}
+	barColor := color.RGBA{50, 205, 50, 255}
+	if fill < 0.3 {
+		barColor = color.RGBA{220, 40, 40, 255}
+	}
+	vector.DrawFilledRect(screen, x, y, healthBarWidth, healthBarHeight, color.RGBA{40, 40, 40, 200}, false)
+	vector.DrawFilledRect(screen, x, y, healthBarWidth*fill, healthBarHeight, barColor, false)
+	vector.StrokeRect(screen, x, y, healthBarWidth, healthBarHeight, 1, color.White, false)
+
+	status := fmt.Sprintf("HP %.0f/%.0f  Lives %d", h.Health.HP(), h.Health.MaxHP(), h.Health.Lives())
+	ebitenutil.DebugPrintAt(screen, status, int(x)+healthBarWidth+8, int(y)-2)
 }
@@ -0,0 +1,92 @@
+package hud
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/pkg/stats"
+)
+
+// The summary is a panel of summaryWidth pixels in the middle of the screen,
+// listing at most summaryPlayers players.
+const (
+	summaryWidth   = 520
+	summaryPlayers = 5
+	summaryLine    = 16
+)
+
+// SetSummary shows the stats of a finished match over the field, or hides
+// them when nil.
+func (h *HUD) SetSummary(summary *stats.Summary) {
+	h.Summary = summary
+}
+
+// drawSummary draws the stats of both teams side by side, the goals in the
+// order they were scored and the players who did the most.
+func (h *HUD) drawSummary(screen *ebiten.Image) {
+	summary := h.Summary
+	lines := []string{
+		fmt.Sprintf("%-16s %12s %12s", "Full Time", summary.Teams[0].Team, summary.Teams[1].Team),
+		"",
+		summaryRow("Goals", summary.Teams[0].Score, summary.Teams[1].Score),
+		summaryRow("Possession %", int(summary.Possession(0)*100+0.5), int(summary.Possession(1)*100+0.5)),
+		summaryRow("Shots", summary.Teams[0].Shots, summary.Teams[1].Shots),
+		summaryRow("On target", summary.Teams[0].ShotsOnTarget, summary.Teams[1].ShotsOnTarget),
+		summaryRow("Passes", summary.Teams[0].Passes, summary.Teams[1].Passes),
+		summaryRow("Distance", int(summary.Teams[0].Distance), int(summary.Teams[1].Distance)),
+		"",
+	}
+	for _, goal := range summary.Goals {
+		scorer := goal.Scorer
+		if goal.OwnGoal {
+			scorer += " (own goal)"
+		}
+		lines = append(lines, fmt.Sprintf("%02d:%02d  %-5s %s", int(goal.Time)/60, int(goal.Time)%60, goal.Team, scorer))
+	}
+	if len(summary.Goals) == 0 {
+		lines = append(lines, "No goals")
+	}
+	lines = append(lines, "", fmt.Sprintf("%-20s %5s %5s %5s %6s", "Player", "Goals", "Shots", "Pass", "Run"))
+	for _, player := range topPlayers(summary.Players, summaryPlayers) {
+		lines = append(lines, fmt.Sprintf("%-20s %5d %5d %5d %6.0f", player.Player, player.Goals, player.Shots, player.Passes, player.Distance))
+	}
+	lines = append(lines, "", "Press Space to Play Again")
+
+	height := len(lines)*summaryLine + 20
+	x := (h.ScreenWidth - summaryWidth) / 2
+	y := (h.ScreenHeight - height) / 2
+	vector.DrawFilledRect(screen, float32(x), float32(y), summaryWidth, float32(height), color.RGBA{0, 0, 0, 200}, false)
+	vector.StrokeRect(screen, float32(x), float32(y), summaryWidth, float32(height), 1, color.White, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x+10, y+10+i*summaryLine)
+	}
+}
+
+// summaryRow lines up a stat of the home and the away team.
+func summaryRow(name string, home, away int) string {
+	return fmt.Sprintf("%-16s %12d %12d", name, home, away)
+}
+
+// topPlayers returns at most limit players, those who scored, shot and
+// passed the most first.
+func topPlayers(players []stats.PlayerStats, limit int) []stats.PlayerStats {
+	sorted := append([]stats.PlayerStats(nil), players...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Goals != b.Goals {
+			return a.Goals > b.Goals
+		}
+		if a.Shots != b.Shots {
+			return a.Shots > b.Shots
+		}
+		return a.Passes > b.Passes
+	})
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
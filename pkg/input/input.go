@@ -11,14 +11,73 @@ import (
 // https://github.com/hajimehoshi/ebiten/blob/main/examples/touch/main.go
 const TOUCH_THRESHOLD = 30
 
+// gamepadDeadZone is how far a stick must be pushed to count.
+const gamepadDeadZone = 0.3
+
+// Scheme binds the controls of one player to keys, the mouse and touch, and
+// a gamepad.
+type Scheme struct {
+	Jump    []ebiten.Key
+	Up      []ebiten.Key
+	Down    []ebiten.Key
+	Left    []ebiten.Key
+	Right   []ebiten.Key
+	Kick    []ebiten.Key
+	Pointer bool // Whether the mouse and touch control the player
+	Gamepad int  // Index of the gamepad controlling the player, -1 for none
+}
+
+// DefaultScheme returns the controls of a player playing alone: WASD or the
+// arrows, space to jump, K to kick, the mouse, touch and the first gamepad.
+func DefaultScheme() Scheme {
+	return Scheme{
+		Jump:    []ebiten.Key{ebiten.KeySpace},
+		Up:      []ebiten.Key{ebiten.KeyW, ebiten.KeyUp},
+		Down:    []ebiten.Key{ebiten.KeyS, ebiten.KeyDown},
+		Left:    []ebiten.Key{ebiten.KeyA, ebiten.KeyLeft},
+		Right:   []ebiten.Key{ebiten.KeyD, ebiten.KeyRight},
+		Kick:    []ebiten.Key{ebiten.KeyK},
+		Pointer: true,
+		Gamepad: 0,
+	}
+}
+
+// PlayerOneScheme returns the controls of the first of two players sharing
+// the keyboard: WASD, space to jump, K to kick and the mouse.
+func PlayerOneScheme() Scheme {
+	return Scheme{
+		Jump:    []ebiten.Key{ebiten.KeySpace},
+		Up:      []ebiten.Key{ebiten.KeyW},
+		Down:    []ebiten.Key{ebiten.KeyS},
+		Left:    []ebiten.Key{ebiten.KeyA},
+		Right:   []ebiten.Key{ebiten.KeyD},
+		Kick:    []ebiten.Key{ebiten.KeyK},
+		Pointer: true,
+		Gamepad: -1,
+	}
+}
+
+// PlayerTwoScheme returns the controls of the second of two players sharing
+// the keyboard: the arrows, right control to jump, right shift to kick and the
+// first gamepad.
+func PlayerTwoScheme() Scheme {
+	return Scheme{
+		Jump:    []ebiten.Key{ebiten.KeyControlRight},
+		Up:      []ebiten.Key{ebiten.KeyUp},
+		Down:    []ebiten.Key{ebiten.KeyDown},
+		Left:    []ebiten.Key{ebiten.KeyLeft},
+		Right:   []ebiten.Key{ebiten.KeyRight},
+		Kick:    []ebiten.Key{ebiten.KeyShiftRight},
+		Gamepad: 0,
+	}
+}
+
 // InputHandler handles input for the game.
 type InputHandler struct {
-	keyJump       ebiten.Key
-	keyUp         ebiten.Key
-	keyDown       ebiten.Key
-	keyLeft       ebiten.Key
-	keyRight      ebiten.Key
+	player        int
+	scheme        Scheme
 	mouseJump     ebiten.MouseButton
+	mouseKick     ebiten.MouseButton
 	eventManager  interfaces.EventManager
 	mouseStartX   int
 	mouseStartY   int
@@ -30,46 +89,117 @@ type InputHandler struct {
 
 // NewInputHandler creates a new InputHandler with default key bindings and an event manager.
 func NewInputHandler(eventManager interfaces.EventManager) *InputHandler {
+	return NewPlayerInputHandler(eventManager, 0, DefaultScheme())
+}
+
+// NewPlayerInputHandler creates an InputHandler for one of the players sharing
+// the screen, counted from 0. Its events are only seen by that player.
+func NewPlayerInputHandler(eventManager interfaces.EventManager, player int, scheme Scheme) *InputHandler {
 	return &InputHandler{
-		keyJump:      ebiten.KeySpace,
-		keyUp:        ebiten.KeyW,
-		keyDown:      ebiten.KeyS,
-		keyLeft:      ebiten.KeyA,
-		keyRight:     ebiten.KeyD,
+		player:       player,
+		scheme:       scheme,
 		mouseJump:    ebiten.MouseButtonLeft,
+		mouseKick:    ebiten.MouseButtonRight,
 		eventManager: eventManager,
 	}
 }
 
+// SetScheme changes the controls of the player.
+func (ih *InputHandler) SetScheme(scheme Scheme) {
+	ih.scheme = scheme
+	ih.isDragging = false
+	ih.isTouchActive = false
+}
+
 // IsJumpPressed checks if the jump key, mouse button, or touch input is pressed.
 func (ih *InputHandler) IsJumpPressed() bool {
-	return ih.isKeyPressed(ih.keyJump) || ih.isMouseButtonPressed(ih.mouseJump) || ih.isTouchJustPressed()
+	return ih.anyKeyPressed(ih.scheme.Jump) || ih.isGamepadButtonPressed(ebiten.StandardGamepadButtonRightBottom) ||
+		ih.scheme.Pointer && (ih.isMouseButtonPressed(ih.mouseJump) || ih.isTouchJustPressed())
 }
 
 // IsUpPressed checks if the up key or mouse/touch drag up is pressed.
 func (ih *InputHandler) IsUpPressed() bool {
-	return ih.isKeyPressed(ih.keyUp) || ih.isKeyPressed(ebiten.KeyUp) || ih.isMouseDragUp() || ih.isTouchDragUp()
+	return ih.anyKeyPressed(ih.scheme.Up) || ih.isGamepadPushed(ebiten.StandardGamepadButtonLeftTop, ebiten.StandardGamepadAxisLeftStickVertical, -1) ||
+		ih.scheme.Pointer && (ih.isMouseDragUp() || ih.isTouchDragUp())
 }
 
 // IsDownPressed checks if the down key or mouse/touch drag down is pressed.
 func (ih *InputHandler) IsDownPressed() bool {
-	return ih.isKeyPressed(ih.keyDown) || ih.isKeyPressed(ebiten.KeyDown) || ih.isMouseDragDown() || ih.isTouchDragDown()
+	return ih.anyKeyPressed(ih.scheme.Down) || ih.isGamepadPushed(ebiten.StandardGamepadButtonLeftBottom, ebiten.StandardGamepadAxisLeftStickVertical, 1) ||
+		ih.scheme.Pointer && (ih.isMouseDragDown() || ih.isTouchDragDown())
 }
 
 // IsLeftPressed checks if the left movement key or mouse/touch drag left is pressed.
 func (ih *InputHandler) IsLeftPressed() bool {
-	return ih.isKeyPressed(ih.keyLeft) || ih.isKeyPressed(ebiten.KeyLeft) || ih.isMouseDragLeft() || ih.isTouchDragLeft()
+	return ih.anyKeyPressed(ih.scheme.Left) || ih.isGamepadPushed(ebiten.StandardGamepadButtonLeftLeft, ebiten.StandardGamepadAxisLeftStickHorizontal, -1) ||
+		ih.scheme.Pointer && (ih.isMouseDragLeft() || ih.isTouchDragLeft())
 }
 
 // IsRightPressed checks if the right movement key or mouse/touch drag right is pressed.
 func (ih *InputHandler) IsRightPressed() bool {
-	return ih.isKeyPressed(ih.keyRight) || ih.isKeyPressed(ebiten.KeyRight) || ih.isMouseDragRight() || ih.isTouchDragRight()
+	return ih.anyKeyPressed(ih.scheme.Right) || ih.isGamepadPushed(ebiten.StandardGamepadButtonLeftRight, ebiten.StandardGamepadAxisLeftStickHorizontal, 1) ||
+		ih.scheme.Pointer && (ih.isMouseDragRight() || ih.isTouchDragRight())
+}
+
+// IsKickPressed checks if the kick key or mouse button is held.
+func (ih *InputHandler) IsKickPressed() bool {
+	return ih.anyKeyPressed(ih.scheme.Kick) || ih.isGamepadButtonPressed(ebiten.StandardGamepadButtonRightRight) ||
+		ih.scheme.Pointer && ih.isMouseButtonPressed(ih.mouseKick)
+}
+
+// dispatch sends an input event to the player of the handler.
+func (ih *InputHandler) dispatch(eventType interfaces.EventType) {
+	ih.eventManager.Dispatch(interfaces.Event{Type: interfaces.PlayerEvent(ih.player, eventType), Priority: 1})
+}
+
+// Private helper method to check if any of the keys is pressed.
+func (ih *InputHandler) anyKeyPressed(keys []ebiten.Key) bool {
+	for _, key := range keys {
+		if ih.isKeyPressed(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Private helper method to check if a button of the player's gamepad is pressed.
+func (ih *InputHandler) isGamepadButtonPressed(button ebiten.StandardGamepadButton) bool {
+	if ih.scheme.Gamepad < 0 {
+		return false
+	}
+	id, ok := ih.gamepad()
+	return ok && ebiten.IsStandardGamepadButtonPressed(id, button)
+}
+
+// Private helper method to check if the player's gamepad is pushed one way,
+// with the d-pad or past the dead zone of a stick. sign is the direction
+// along the axis.
+func (ih *InputHandler) isGamepadPushed(button ebiten.StandardGamepadButton, axis ebiten.StandardGamepadAxis, sign float64) bool {
+	if ih.isGamepadButtonPressed(button) {
+		return true
+	}
+	if ih.scheme.Gamepad < 0 {
+		return false
+	}
+	id, ok := ih.gamepad()
+	return ok && ebiten.StandardGamepadAxisValue(id, axis)*sign > gamepadDeadZone
+}
+
+// gamepad returns the ID of the player's gamepad, if it is connected and has
+// a standard layout.
+func (ih *InputHandler) gamepad() (ebiten.GamepadID, bool) {
+	ids := ebiten.AppendGamepadIDs(nil)
+	if ih.scheme.Gamepad >= len(ids) {
+		return 0, false
+	}
+	id := ids[ih.scheme.Gamepad]
+	return id, ebiten.IsStandardGamepadLayoutAvailable(id)
 }
 
 // Private helper method to check if a key is pressed and dispatch the event.
 func (ih *InputHandler) isKeyPressed(key ebiten.Key) bool {
 	if ebiten.IsKeyPressed(key) {
-		ih.eventManager.Dispatch(interfaces.Event{Type: interfaces.EventType(fmt.Sprintf("KeyPressed_%d", key)), Priority: 1})
+		ih.dispatch(interfaces.EventType(fmt.Sprintf("KeyPressed_%d", key)))
 		return true
 	}
 	return false
@@ -78,7 +208,7 @@ func (ih *InputHandler) isKeyPressed(key ebiten.Key) bool {
 // Private helper method to check if a mouse button is pressed and dispatch the event.
 func (ih *InputHandler) isMouseButtonPressed(button ebiten.MouseButton) bool {
 	if ebiten.IsMouseButtonPressed(button) {
-		ih.eventManager.Dispatch(interfaces.Event{Type: interfaces.EventType(fmt.Sprintf("MouseButtonPressed_%d", button)), Priority: 1})
+		ih.dispatch(interfaces.EventType(fmt.Sprintf("MouseButtonPressed_%d", button)))
 		return true
 	}
 	return false
@@ -87,7 +217,7 @@ func (ih *InputHandler) isMouseButtonPressed(button ebiten.MouseButton) bool {
 // Private helper method to check if a key was just pressed.
 func (ih *InputHandler) isKeyJustPressed(key ebiten.Key) bool {
 	if inpututil.IsKeyJustPressed(key) {
-		ih.eventManager.Dispatch(interfaces.Event{Type: interfaces.EventType(fmt.Sprintf("KeyJustPressed_%d", key)), Priority: 1})
+		ih.dispatch(interfaces.EventType(fmt.Sprintf("KeyJustPressed_%d", key)))
 		return true
 	}
 	return false
@@ -96,7 +226,7 @@ func (ih *InputHandler) isKeyJustPressed(key ebiten.Key) bool {
 // Private helper method to check if a mouse button was just pressed.
 func (ih *InputHandler) isMouseButtonJustPressed(button ebiten.MouseButton) bool {
 	if inpututil.IsMouseButtonJustPressed(button) {
-		ih.eventManager.Dispatch(interfaces.Event{Type: interfaces.EventType(fmt.Sprintf("MouseButtonJustPressed_%d", button)), Priority: 1})
+		ih.dispatch(interfaces.EventType(fmt.Sprintf("MouseButtonJustPressed_%d", button)))
 		return true
 	}
 	return false
@@ -202,8 +332,8 @@ func (ih *InputHandler) GetMousePosition() (int, int) {
 	return ebiten.CursorPosition()
 }
 
-// Update updates the input handler and dispatches events.
-func (ih *InputHandler) Update() error {
+// updatePointer tracks mouse and touch drags.
+func (ih *InputHandler) updatePointer() {
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		ih.mouseStartX, ih.mouseStartY = ebiten.CursorPosition()
 		ih.isDragging = true
@@ -216,25 +346,32 @@ func (ih *InputHandler) Update() error {
 	if len(touches) > 0 && inpututil.IsTouchJustReleased(touches[0]) {
 		ih.isTouchActive = false
 	}
+}
+
+// Update updates the input handler and dispatches events.
+func (ih *InputHandler) Update() error {
+	if ih.scheme.Pointer {
+		ih.updatePointer()
+	}
 
 	if ih.IsJumpPressed() {
-		ih.eventManager.Dispatch(interfaces.Event{Type: "KeyPressed_32", Priority: 1})
+		ih.dispatch("KeyPressed_32")
 	}
 	if ih.IsUpPressed() {
-		ih.eventManager.Dispatch(interfaces.Event{Type: "KeyPressed_87", Priority: 1})
+		ih.dispatch("KeyPressed_87")
 	}
 	if ih.IsDownPressed() {
-		ih.eventManager.Dispatch(interfaces.Event{Type: "KeyPressed_83", Priority: 1})
+		ih.dispatch("KeyPressed_83")
 	}
 	if ih.IsLeftPressed() {
-		ih.eventManager.Dispatch(interfaces.Event{Type: "KeyPressed_65", Priority: 1})
+		ih.dispatch("KeyPressed_65")
 	}
 	if ih.IsRightPressed() {
-		ih.eventManager.Dispatch(interfaces.Event{Type: "KeyPressed_68", Priority: 1})
+		ih.dispatch("KeyPressed_68")
 	}
 	// Check if no keys are pressed and dispatch the "NoKeyPressed" event
 	if !ih.IsJumpPressed() && !ih.IsUpPressed() && !ih.IsDownPressed() && !ih.IsLeftPressed() && !ih.IsRightPressed() {
-		ih.eventManager.Dispatch(interfaces.Event{Type: "NoKeyPressed", Priority: 1})
+		ih.dispatch("NoKeyPressed")
 	}
 	return nil
 }
@@ -2,18 +2,19 @@
 package items
 
 import (
-	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/internal/core"
 )
 
 // Item represents a game item.
 type Item struct {
-	Name        string                `json:"name"`
-	Image       string                `json:"image"`
-	Icon        string                `json:"icon"`
-	Description string                `json:"description"`
-	Appearance  interfaces.Appearance `json:"appearance"`
-	Abilities   []string              `json:"abilities"`
-	Version     int                   `json:"version"`
+	Name        string                      `json:"name"`
+	Image       string                      `json:"image"`
+	Icon        string                      `json:"icon"`
+	Description string                      `json:"description"`
+	Appearance  core.Appearance             `json:"appearance"`
+	Abilities   []string                    `json:"abilities"`
+	Resistances map[core.DamageType]float64 `json:"resistances,omitempty"`
+	Version     int                         `json:"version"`
 }
 
 // GetName returns the name of the item.
@@ -37,7 +38,7 @@ func (i *Item) GetDescription() string {
 }
 
 // GetAppearance returns the appearance of the item.
-func (i *Item) GetAppearance() interfaces.Appearance {
+func (i *Item) GetAppearance() core.Appearance {
 	return i.Appearance
 }
 
@@ -46,6 +47,11 @@ func (i *Item) GetAbilities() []string {
 	return i.Abilities
 }
 
+// GetResistances returns the fraction of each damage type the item blocks.
+func (i *Item) GetResistances() map[core.DamageType]float64 {
+	return i.Resistances
+}
+
 // GetVersion returns the version of the item.
 func (i *Item) GetVersion() int {
 	return i.Version
@@ -5,21 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/internal/core"
 	"github.com/joaorufino/gopher-game/internal/utils"
 )
 
 // ItemManagerImpl is a concrete implementation of the ItemManager interface.
 type ItemManagerImpl struct {
-	items        map[string]interfaces.Item
-	abilitiesMgr interfaces.AbilitiesManager
+	items map[string]core.Item
 }
 
 // NewItemManager creates a new ItemManagerImpl.
-func NewItemManager(abilitiesMgr interfaces.AbilitiesManager) *ItemManagerImpl {
+func NewItemManager() *ItemManagerImpl {
 	return &ItemManagerImpl{
-		items:        make(map[string]interfaces.Item),
-		abilitiesMgr: abilitiesMgr,
+		items: make(map[string]core.Item),
 	}
 }
 
@@ -47,7 +45,7 @@ func (im *ItemManagerImpl) LoadItems(path string) error {
 }
 
 // GetItem returns an item by its name.
-func (im *ItemManagerImpl) GetItem(name string) (interfaces.Item, error) {
+func (im *ItemManagerImpl) GetItem(name string) (core.Item, error) {
 	item, exists := im.items[name]
 	if !exists {
 		return nil, fmt.Errorf("item not found: %s", name)
@@ -56,7 +54,7 @@ func (im *ItemManagerImpl) GetItem(name string) (interfaces.Item, error) {
 }
 
 // AddItem adds a new item to the manager.
-func (im *ItemManagerImpl) AddItem(item interfaces.Item) {
+func (im *ItemManagerImpl) AddItem(item core.Item) {
 	im.items[item.GetName()] = item
 }
 
@@ -66,8 +64,8 @@ func (im *ItemManagerImpl) RemoveItem(name string) {
 }
 
 // GetAllItems returns all items managed by the ItemManager.
-func (im *ItemManagerImpl) GetAllItems() []interfaces.Item {
-	allItems := make([]interfaces.Item, 0, len(im.items))
+func (im *ItemManagerImpl) GetAllItems() []core.Item {
+	allItems := make([]core.Item, 0, len(im.items))
 	for _, item := range im.items {
 		allItems = append(allItems, item)
 	}
@@ -0,0 +1,38 @@
+package kick
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+const (
+	meterWidth  = 40
+	meterHeight = 6
+	meterGap    = 8
+	hintRadius  = 2
+)
+
+// Draw shows, while a kick charges, a power meter above the kicker going from
+// green to red and dots along the path the ball would take.
+func (c *Charger) Draw(screen *ebiten.Image, camera interfaces.Camera, kicker interfaces.Rect, path []interfaces.Vector2D) {
+	if !c.charging {
+		return
+	}
+	offsetX, offsetY := camera.GetOffset()
+	level := c.Level()
+
+	x := float32(kicker.Position.X + kicker.Size.X/2 - meterWidth/2 - offsetX)
+	y := float32(kicker.Position.Y - meterGap - meterHeight - offsetY)
+	vector.DrawFilledRect(screen, x, y, meterWidth, meterHeight, color.RGBA{40, 40, 40, 200}, true)
+	fill := color.RGBA{uint8(255 * level), uint8(255 * (1 - level)), 0, 255}
+	vector.DrawFilledRect(screen, x, y, float32(meterWidth*level), meterHeight, fill, true)
+
+	for i, point := range path {
+		// The hint fades out along the path
+		alpha := uint8(255 * (1 - float64(i)/float64(len(path))))
+		vector.DrawFilledCircle(screen, float32(point.X-offsetX), float32(point.Y-offsetY), hintRadius, color.RGBA{255, 255, 255, alpha}, true)
+	}
+}
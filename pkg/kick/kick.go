@@ -0,0 +1,167 @@
+package kick
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// Config sets how kicks charge and how far the kicker reaches.
+type Config struct {
+	MinPower   float64 // Speed of the ball after a tap
+	MaxPower   float64 // Speed of the ball after a full charge
+	ChargeTime float64 // Seconds to charge a kick fully
+	Reach      float64 // Gap to the ball the kicker still reaches it from
+}
+
+// DefaultConfig returns the kick settings used by soccer mode.
+func DefaultConfig() Config {
+	return Config{MinPower: 100, MaxPower: 500, ChargeTime: 1, Reach: 15}
+}
+
+// Charger charges a kick while the kick button is held and lets it go once
+// the button is released.
+type Charger struct {
+	config   Config
+	charging bool
+	held     float64
+}
+
+// NewCharger creates a charger, filling in the defaults for unset values.
+func NewCharger(config Config) *Charger {
+	defaults := DefaultConfig()
+	if config.MaxPower <= 0 {
+		config.MaxPower = defaults.MaxPower
+	}
+	if config.ChargeTime <= 0 {
+		config.ChargeTime = defaults.ChargeTime
+	}
+	if config.Reach <= 0 {
+		config.Reach = defaults.Reach
+	}
+	return &Charger{config: config}
+}
+
+// Update charges the kick while held is true. On the frame the button is
+// released it returns the power of the kick and true.
+func (c *Charger) Update(deltaTime float64, held bool) (float64, bool) {
+	if held {
+		if c.charging {
+			c.held += deltaTime
+		}
+		c.charging = true
+		return 0, false
+	}
+	if !c.charging {
+		return 0, false
+	}
+	power := c.Power()
+	c.Cancel()
+	return power, true
+}
+
+// Cancel drops the kick being charged.
+func (c *Charger) Cancel() {
+	c.charging = false
+	c.held = 0
+}
+
+// Charging reports whether a kick is being charged.
+func (c *Charger) Charging() bool {
+	return c.charging
+}
+
+// Level returns how charged the kick is, from 0 to 1.
+func (c *Charger) Level() float64 {
+	return math.Min(c.held/c.config.ChargeTime, 1)
+}
+
+// Power returns the speed the ball would get if the kick went now.
+func (c *Charger) Power() float64 {
+	return c.config.MinPower + (c.config.MaxPower-c.config.MinPower)*c.Level()
+}
+
+// Reach returns the gap to the ball the kicker still reaches it from.
+func (c *Charger) Reach() float64 {
+	return c.config.Reach
+}
+
+// Reaches reports whether ball is within reach of kicker.
+func Reaches(kicker, ball interfaces.Rect, reach float64) bool {
+	return ball.Position.X <= kicker.Position.X+kicker.Size.X+reach &&
+		ball.Position.X+ball.Size.X >= kicker.Position.X-reach &&
+		ball.Position.Y <= kicker.Position.Y+kicker.Size.Y+reach &&
+		ball.Position.Y+ball.Size.Y >= kicker.Position.Y-reach
+}
+
+// Nearest returns the index of the ball within reach of kicker that is
+// closest to it.
+func Nearest(kicker interfaces.Rect, balls []interfaces.Rect, reach float64) (int, bool) {
+	from := center(kicker)
+	nearest, best := -1, math.Inf(1)
+	for i, ball := range balls {
+		if !Reaches(kicker, ball, reach) {
+			continue
+		}
+		to := center(ball)
+		if d := math.Hypot(to.X-from.X, to.Y-from.Y); d < best {
+			nearest, best = i, d
+		}
+	}
+	return nearest, nearest >= 0
+}
+
+// Velocity returns the velocity of a ball kicked along aim with the given
+// power. A zero aim kicks nowhere.
+func Velocity(aim interfaces.Vector2D, power float64) interfaces.Vector2D {
+	length := math.Hypot(aim.X, aim.Y)
+	if length == 0 {
+		return interfaces.Vector2D{}
+	}
+	return interfaces.Vector2D{X: aim.X / length * power, Y: aim.Y / length * power}
+}
+
+// Trajectory returns where a ball leaving from with velocity would be at
+// points evenly spaced over duration seconds, under gravity.
+func Trajectory(from, velocity interfaces.Vector2D, gravity, duration float64, points int) []interfaces.Vector2D {
+	path := make([]interfaces.Vector2D, 0, points)
+	for i := 1; i <= points; i++ {
+		t := duration * float64(i) / float64(points)
+		path = append(path, interfaces.Vector2D{
+			X: from.X + velocity.X*t,
+			Y: from.Y + velocity.Y*t + gravity*t*t/2,
+		})
+	}
+	return path
+}
+
+func center(rect interfaces.Rect) interfaces.Vector2D {
+	return interfaces.Vector2D{X: rect.Position.X + rect.Size.X/2, Y: rect.Position.Y + rect.Size.Y/2}
+}
+
+// Kinds of kicks, as reported by EventBallKicked. Players only ever kick;
+// the AI knows what it meant to do.
+const (
+	KindKick    = "kick"
+	KindShot    = "shot"
+	KindPass    = "pass"
+	KindClear   = "clear"
+	KindDribble = "dribble"
+)
+
+// Event returns the EventBallKicked for a kick by player of team, sending the
+// ball from position with velocity.
+func Event(team, player, kind string, position, velocity interfaces.Vector2D) interfaces.Event {
+	return interfaces.Event{
+		Type:     interfaces.EventBallKicked,
+		Priority: 1,
+		Payload: map[string]interface{}{
+			"team":     team,
+			"player":   player,
+			"kind":     kind,
+			"position": position,
+			"velocity": velocity,
+			"power":    math.Hypot(velocity.X, velocity.Y),
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package kick
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestKick(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kick Suite")
+}
+
+func rect(x, y, w, h float64) interfaces.Rect {
+	return interfaces.Rect{Position: interfaces.Vector2D{X: x, Y: y}, Size: interfaces.Vector2D{X: w, Y: h}}
+}
+
+var _ = Describe("Charger", func() {
+	var charger *Charger
+
+	BeforeEach(func() {
+		charger = NewCharger(Config{MinPower: 100, MaxPower: 300, ChargeTime: 1, Reach: 10})
+	})
+
+	It("should kick on release with the power charged so far", func() {
+		_, kicked := charger.Update(0.1, true)
+		Expect(kicked).To(BeFalse())
+		charger.Update(0.5, true)
+		Expect(charger.Charging()).To(BeTrue())
+		Expect(charger.Level()).To(BeNumerically("~", 0.5))
+
+		power, kicked := charger.Update(0.1, false)
+		Expect(kicked).To(BeTrue())
+		Expect(power).To(BeNumerically("~", 200))
+		Expect(charger.Charging()).To(BeFalse())
+	})
+
+	It("should stop charging at full power", func() {
+		charger.Update(0, true)
+		charger.Update(5, true)
+		power, _ := charger.Update(0, false)
+		Expect(power).To(Equal(300.0))
+	})
+
+	It("should not kick without charging first", func() {
+		_, kicked := charger.Update(0.1, false)
+		Expect(kicked).To(BeFalse())
+
+		charger.Update(0, true)
+		charger.Cancel()
+		_, kicked = charger.Update(0.1, false)
+		Expect(kicked).To(BeFalse())
+	})
+})
+
+var _ = Describe("Kicking", func() {
+	It("should pick the nearest ball within reach", func() {
+		kicker := rect(100, 100, 30, 30)
+		balls := []interfaces.Rect{rect(200, 100, 20, 20), rect(135, 105, 20, 20), rect(80, 105, 20, 20)}
+		nearest, ok := Nearest(kicker, balls, 10)
+		Expect(ok).To(BeTrue())
+		Expect(nearest).To(Equal(2))
+
+		_, ok = Nearest(kicker, balls[:1], 10)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should send the ball along the aim", func() {
+		Expect(Velocity(interfaces.Vector2D{X: 3, Y: 4}, 100)).To(Equal(interfaces.Vector2D{X: 60, Y: 80}))
+		Expect(Velocity(interfaces.Vector2D{}, 100)).To(Equal(interfaces.Vector2D{}))
+	})
+
+	It("should bend the trajectory with gravity", func() {
+		path := Trajectory(interfaces.Vector2D{}, interfaces.Vector2D{X: 100, Y: 0}, 20, 1, 2)
+		Expect(path).To(Equal([]interfaces.Vector2D{{X: 50, Y: 2.5}, {X: 100, Y: 10}}))
+	})
+})
@@ -0,0 +1,22 @@
+package level
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// Draw draws the exit as a door.
+func (l *Level) Draw(screen *ebiten.Image, camera interfaces.Camera) {
+	if !l.HasExit() {
+		return
+	}
+	offsetX, offsetY := camera.GetOffset()
+	x := float32(l.Exit.Position.X - offsetX)
+	y := float32(l.Exit.Position.Y - offsetY)
+	width, height := float32(l.Exit.Size.X), float32(l.Exit.Size.Y)
+	vector.DrawFilledRect(screen, x, y, width, height, color.RGBA{255, 215, 0, 80}, false)
+	vector.StrokeRect(screen, x, y, width, height, 2, color.RGBA{255, 215, 0, 255}, false)
+}
@@ -0,0 +1,64 @@
+// Package level keeps track of where a level starts and ends, how the player
+// is doing in it and which level comes next.
+package level
+
+import (
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// Level implements interfaces.Level: the player starts at Start and completes
+// the level by reaching the Exit area.
+type Level struct {
+	Name    string
+	Start   interfaces.Vector2D
+	Exit    interfaces.Rect
+	enemies []interfaces.AIAgent
+}
+
+// NewLevel creates a level. A level with an empty exit area never completes.
+func NewLevel(name string, start interfaces.Vector2D, exit interfaces.Rect) *Level {
+	return &Level{Name: name, Start: start, Exit: exit}
+}
+
+// GetStartVector2D returns where the player starts.
+func (l *Level) GetStartVector2D() interfaces.Vector2D {
+	return l.Start
+}
+
+// GetEndVector2D returns the middle of the exit.
+func (l *Level) GetEndVector2D() interfaces.Vector2D {
+	return interfaces.Vector2D{X: l.Exit.Position.X + l.Exit.Size.X/2, Y: l.Exit.Position.Y + l.Exit.Size.Y/2}
+}
+
+// GetEnemies returns the enemies of the level.
+func (l *Level) GetEnemies() []interfaces.AIAgent {
+	return l.enemies
+}
+
+// AddEnemy adds an enemy to the level.
+func (l *Level) AddEnemy(enemy interfaces.AIAgent) {
+	l.enemies = append(l.enemies, enemy)
+}
+
+// RemoveEnemy takes a defeated enemy out of the level.
+func (l *Level) RemoveEnemy(enemy interfaces.AIAgent) {
+	for i, e := range l.enemies {
+		if e == enemy {
+			l.enemies = append(l.enemies[:i], l.enemies[i+1:]...)
+			return
+		}
+	}
+}
+
+// HasExit reports whether the level can be completed.
+func (l *Level) HasExit() bool {
+	return l.Exit.Size.X > 0 && l.Exit.Size.Y > 0
+}
+
+// Reached reports whether the player covering the given area is at the exit.
+func (l *Level) Reached(player interfaces.Rect) bool {
+	exit := l.Exit
+	return l.HasExit() &&
+		player.Position.X < exit.Position.X+exit.Size.X && exit.Position.X < player.Position.X+player.Size.X &&
+		player.Position.Y < exit.Position.Y+exit.Size.Y && exit.Position.Y < player.Position.Y+player.Size.Y
+}
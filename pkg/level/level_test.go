@@ -0,0 +1,132 @@
+package level
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLevel(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Level Suite")
+}
+
+// syncEvents hands events to the handlers right away.
+type syncEvents struct {
+	handlers   map[interfaces.EventType][]interfaces.EventHandler
+	dispatched []interfaces.Event
+}
+
+func (s *syncEvents) RegisterHandler(eventType interfaces.EventType, handler interfaces.EventHandler) {
+	s.handlers[eventType] = append(s.handlers[eventType], handler)
+}
+
+func (s *syncEvents) Dispatch(event interfaces.Event) {
+	s.dispatched = append(s.dispatched, event)
+	for _, handler := range s.handlers[event.Type] {
+		handler(event)
+	}
+}
+
+func (s *syncEvents) Wait() {}
+
+// fakeAgent stands in for an enemy.
+type fakeAgent struct {
+	interfaces.AIAgent
+	name string
+}
+
+func rect(x, y, w, h float64) interfaces.Rect {
+	return interfaces.Rect{Position: interfaces.Vector2D{X: x, Y: y}, Size: interfaces.Vector2D{X: w, Y: h}}
+}
+
+var _ = Describe("Level", func() {
+	It("should end in the middle of its exit", func() {
+		level := NewLevel("one", interfaces.Vector2D{X: 10, Y: 20}, rect(100, 0, 20, 40))
+		Expect(level.GetStartVector2D()).To(Equal(interfaces.Vector2D{X: 10, Y: 20}))
+		Expect(level.GetEndVector2D()).To(Equal(interfaces.Vector2D{X: 110, Y: 20}))
+		Expect(level.Reached(rect(90, 10, 20, 20))).To(BeTrue())
+		Expect(level.Reached(rect(0, 10, 20, 20))).To(BeFalse())
+	})
+
+	It("should never be reached without an exit", func() {
+		level := NewLevel("one", interfaces.Vector2D{}, interfaces.Rect{})
+		Expect(level.Reached(rect(0, 0, 20, 20))).To(BeFalse())
+	})
+
+	It("should list the enemies alive in it", func() {
+		level := NewLevel("one", interfaces.Vector2D{}, interfaces.Rect{})
+		first, second := &fakeAgent{name: "first"}, &fakeAgent{name: "second"}
+		level.AddEnemy(first)
+		level.AddEnemy(second)
+		level.RemoveEnemy(first)
+		level.RemoveEnemy(first)
+		Expect(level.GetEnemies()).To(Equal([]interfaces.AIAgent{second}))
+	})
+})
+
+var _ = Describe("Progress", func() {
+	var (
+		events   *syncEvents
+		progress *Progress
+	)
+
+	BeforeEach(func() {
+		events = &syncEvents{handlers: map[interfaces.EventType][]interfaces.EventHandler{}}
+		progress = NewProgress(events)
+		progress.Start(NewLevel("one", interfaces.Vector2D{}, rect(100, 0, 20, 40)))
+	})
+
+	It("should report the stats once the player reaches the exit", func() {
+		events.Dispatch(interfaces.Event{Type: interfaces.EventItemEquipped})
+		events.Dispatch(interfaces.Event{Type: interfaces.EventPlayerDied})
+		events.Dispatch(interfaces.Event{Type: interfaces.EventItemEquipped})
+
+		Expect(progress.Update(1.5, rect(0, 0, 20, 20))).To(BeFalse())
+		Expect(progress.Update(0.5, rect(100, 0, 20, 20))).To(BeTrue())
+		Expect(progress.Complete()).To(BeTrue())
+		Expect(progress.Stats()).To(Equal(Stats{Level: "one", Time: 2, Items: 2, Deaths: 1}))
+
+		completed := events.dispatched[len(events.dispatched)-1]
+		Expect(completed.Type).To(Equal(interfaces.EventLevelComplete))
+		Expect(completed.Payload).To(HaveKeyWithValue("deaths", 1))
+	})
+
+	It("should stop counting once the level is complete", func() {
+		progress.Update(1, rect(100, 0, 20, 20))
+		Expect(progress.Update(1, rect(100, 0, 20, 20))).To(BeFalse())
+		events.Dispatch(interfaces.Event{Type: interfaces.EventPlayerDied})
+		Expect(progress.Stats()).To(Equal(Stats{Level: "one", Time: 1}))
+	})
+
+	It("should start over on the next level", func() {
+		events.Dispatch(interfaces.Event{Type: interfaces.EventPlayerDied})
+		progress.Start(NewLevel("two", interfaces.Vector2D{}, rect(100, 0, 20, 40)))
+		Expect(progress.Stats()).To(Equal(Stats{Level: "two"}))
+		Expect(progress.Complete()).To(BeFalse())
+	})
+})
+
+var _ = Describe("List", func() {
+	list := &List{Levels: []Info{{Name: "one"}, {Name: "two"}}}
+
+	It("should hand off to the next level", func() {
+		next, ok := list.Next("one")
+		Expect(ok).To(BeTrue())
+		Expect(next.Name).To(Equal("two"))
+
+		_, ok = list.Next("two")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should start at the first level unless told otherwise", func() {
+		first, _ := list.First()
+		Expect(first.Name).To(Equal("one"))
+
+		starting := &List{StartingLevel: "two", Levels: list.Levels}
+		first, _ = starting.First()
+		Expect(first.Name).To(Equal("two"))
+	})
+})
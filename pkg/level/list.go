@@ -0,0 +1,68 @@
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/joaorufino/gopher-game/internal/utils"
+)
+
+// Info describes one level of the career in the level list.
+type Info struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Abilities   []string `json:"abilities"`
+	Milestone   string   `json:"milestone,omitempty"`
+	// File is the level file, relative to the assets directory.
+	File string `json:"file,omitempty"`
+}
+
+// List is the ordered list of levels the career goes through.
+type List struct {
+	StartingLevel string `json:"startingLevel"`
+	Levels        []Info `json:"levels"`
+}
+
+// LoadList reads the level list from a JSON file.
+func LoadList(path string) (*List, error) {
+	var list List
+	err := utils.LoadData(path, func(data []byte) error {
+		return json.Unmarshal(data, &list)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load level list %s: %w", path, err)
+	}
+	return &list, nil
+}
+
+// Find returns the level with the given name.
+func (l *List) Find(name string) (Info, bool) {
+	for _, entry := range l.Levels {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return Info{}, false
+}
+
+// First returns the starting level, or the first one listed when none is set.
+func (l *List) First() (Info, bool) {
+	if l.StartingLevel != "" {
+		return l.Find(l.StartingLevel)
+	}
+	if len(l.Levels) == 0 {
+		return Info{}, false
+	}
+	return l.Levels[0], true
+}
+
+// Next returns the level after the one with the given name. It reports false
+// after the last level and for unknown names.
+func (l *List) Next(name string) (Info, bool) {
+	for i, entry := range l.Levels {
+		if entry.Name == name && i+1 < len(l.Levels) {
+			return l.Levels[i+1], true
+		}
+	}
+	return Info{}, false
+}
@@ -0,0 +1,112 @@
+package level
+
+import (
+	"sync"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// Stats sums up how the player did in a level.
+type Stats struct {
+	Level string
+	// Time is how long the level took, in seconds.
+	Time   float64
+	Items  int
+	Deaths int
+}
+
+// Progress times the level being played, counts the items picked up and the
+// deaths in it, and announces when the player reaches the exit.
+type Progress struct {
+	eventManager interfaces.EventManager
+	mu           sync.Mutex
+	level        *Level
+	stats        Stats
+	complete     bool
+}
+
+// NewProgress creates a tracker counting the items and deaths reported on the
+// event manager.
+func NewProgress(eventManager interfaces.EventManager) *Progress {
+	p := &Progress{eventManager: eventManager}
+	eventManager.RegisterHandler(interfaces.EventItemEquipped, p.handleItemPicked)
+	eventManager.RegisterHandler(interfaces.EventPlayerDied, p.handlePlayerDied)
+	return p
+}
+
+// Start begins tracking a new level from scratch.
+func (p *Progress) Start(level *Level) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.level = level
+	p.stats = Stats{}
+	if level != nil {
+		p.stats.Level = level.Name
+	}
+	p.complete = false
+}
+
+// Level returns the level being tracked, or nil.
+func (p *Progress) Level() *Level {
+	return p.level
+}
+
+// Complete reports whether the player reached the exit of the level.
+func (p *Progress) Complete() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.complete
+}
+
+// Stats returns the stats of the level so far.
+func (p *Progress) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// Update counts the time spent in the level and reports whether the player
+// reached the exit this frame, dispatching a LevelComplete event if so.
+func (p *Progress) Update(deltaTime float64, player interfaces.Rect) bool {
+	if p.level == nil || p.complete {
+		return false
+	}
+	p.mu.Lock()
+	p.stats.Time += deltaTime
+	p.complete = p.level.Reached(player)
+	stats := p.stats
+	p.mu.Unlock()
+
+	if !p.complete {
+		return false
+	}
+	p.eventManager.Dispatch(interfaces.Event{
+		Type:     interfaces.EventLevelComplete,
+		Priority: 1,
+		Payload: map[string]interface{}{
+			"level":  stats.Level,
+			"time":   stats.Time,
+			"items":  stats.Items,
+			"deaths": stats.Deaths,
+		},
+	})
+	return true
+}
+
+func (p *Progress) handleItemPicked(interfaces.Event) {
+	p.count(func(stats *Stats) { stats.Items++ })
+}
+
+func (p *Progress) handlePlayerDied(interfaces.Event) {
+	p.count(func(stats *Stats) { stats.Deaths++ })
+}
+
+// count updates the stats of a level still being played. Events are handled
+// on their own goroutines, hence the lock.
+func (p *Progress) count(update func(stats *Stats)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.level != nil && !p.complete {
+		update(&p.stats)
+	}
+}
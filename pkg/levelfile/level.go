@@ -0,0 +1,429 @@
+// Package levelfile reads and writes the level files of the game: their
+// platforms, obstacles, items, triggers, enemies and logic, natively or
+// imported from Tiled. It does not depend on ebiten, so headless tools such
+// as levellint can load levels the same way the game does.
+package levelfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+	"github.com/joaorufino/gopher-game/internal/utils"
+	"github.com/joaorufino/gopher-game/pkg/logic"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	"github.com/joaorufino/gopher-game/pkg/spawner"
+	"github.com/joaorufino/gopher-game/pkg/tilemap/autotile"
+)
+
+// SpawnPoint marks a named position in a level, e.g. where the player starts.
+type SpawnPoint struct {
+	Name       string                 `json:"name"`
+	Position   core.Vector2D          `json:"position"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Trigger is a named area of a level that reacts when something enters it.
+type Trigger struct {
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type,omitempty"`
+	Body       core.Rect              `json:"body"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Checkpoint is an area of a level that becomes the respawn point once the
+// player reaches it.
+type Checkpoint struct {
+	Name       string                 `json:"name"`
+	Body       core.Rect              `json:"body"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Hazard is an area of a level that hurts the player, such as spikes or lava.
+type Hazard struct {
+	Name       string                 `json:"name"`
+	Body       core.Rect              `json:"body"`
+	Damage     core.Damage            `json:"damage"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Enemy is an enemy of a level, which the AI moves with the named behavior:
+// patrol, chase, flee, guard or jump.
+type Enemy struct {
+	Behavior string    `json:"behavior"`
+	Body     core.Rect `json:"body"`
+	// Tree names a behavior tree for the enemy to follow instead of its
+	// behavior.
+	Tree string `json:"tree,omitempty"`
+	// Speed, Radius and JumpVelocity tune the behavior; zero keeps the
+	// defaults of the AI.
+	Speed        float64 `json:"speed,omitempty"`
+	Radius       float64 `json:"radius,omitempty"`
+	JumpVelocity float64 `json:"jumpVelocity,omitempty"`
+	// Points are where a patrol goes, by the center of the enemy.
+	Points []core.Vector2D `json:"points,omitempty"`
+	// Damage, when set, hurts the player on contact.
+	Damage     *core.Damage           `json:"damage,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// EnemySpawner is an area of a level that brings in enemies over time, at
+// a steady rate or in waves, once its condition is met.
+type EnemySpawner struct {
+	Name string `json:"name"`
+	// Enemy is the kind of enemy spawned; its body gives the size, and its
+	// position is picked inside Area.
+	Enemy Enemy     `json:"enemy"`
+	Area  core.Rect `json:"area"`
+	// Rate is how many enemies spawn per second.
+	Rate float64 `json:"rate,omitempty"`
+	// MaxAlive is how many enemies of the spawner are alive at once.
+	MaxAlive int `json:"maxAlive,omitempty"`
+	// Waves are spawned one after another, each once the previous one is
+	// defeated; without waves enemies keep coming.
+	Waves []spawner.Wave `json:"waves,omitempty"`
+	// After is the trigger or logic object the spawner waits for.
+	After      *spawner.Condition     `json:"after,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Spawner returns how the spawner spawns its enemies.
+func (s EnemySpawner) Spawner() spawner.Config {
+	config := spawner.Config{
+		Name:     s.Name,
+		Area:     s.Area,
+		Size:     s.Enemy.Body.Size,
+		Rate:     s.Rate,
+		MaxAlive: s.MaxAlive,
+		Waves:    s.Waves,
+	}
+	if s.After != nil {
+		config.After = *s.After
+	}
+	return config
+}
+
+// LogicObject is a switch, pressure plate, door, timed gate, spawner or logic
+// gate of a level. Objects are wired together by listing the ids of the
+// objects they follow as their inputs.
+type LogicObject struct {
+	ID   string     `json:"id"`
+	Kind logic.Kind `json:"kind"`
+	// Body is the area of switches, plates, doors, timed gates and spawners.
+	Body   *core.Rect `json:"body,omitempty"`
+	Inputs []string   `json:"inputs,omitempty"`
+	// On is the initial state of a switch.
+	On bool `json:"on,omitempty"`
+	// Duration is how long a delay waits or a timed gate stays open, in seconds.
+	Duration float64 `json:"duration,omitempty"`
+	// Spawn is the type of the pushable obstacle a spawner creates.
+	Spawn string `json:"spawn,omitempty"`
+	// Limit is how many obstacles a spawner keeps; spawning another one
+	// removes the oldest. It defaults to one.
+	Limit      int                    `json:"limit,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// fallMargin is how far below its lowest platform a level without a kill
+// plane ends.
+const fallMargin = 600
+
+// TriggerExit is the type of the trigger that completes a level.
+const TriggerExit = "exit"
+
+// Tileset describes a tile sheet image used by tile layers.
+type Tileset struct {
+	Name       string `json:"name"`
+	FirstGID   int    `json:"firstGid"`
+	Image      string `json:"image"`
+	TileWidth  int    `json:"tileWidth"`
+	TileHeight int    `json:"tileHeight"`
+	Columns    int    `json:"columns"`
+	TileCount  int    `json:"tileCount"`
+	Margin     int    `json:"margin,omitempty"`
+	Spacing    int    `json:"spacing,omitempty"`
+	// Autotiles replace terrain tiles by the tile matching their neighbours.
+	// All ids are global tile ids, like the layer data.
+	Autotiles []autotile.Rule `json:"autotiles,omitempty"`
+}
+
+// TileLayer is a grid of global tile ids; 0 means an empty cell.
+type TileLayer struct {
+	Name       string                 `json:"name"`
+	Width      int                    `json:"width"`
+	Height     int                    `json:"height"`
+	TileWidth  int                    `json:"tileWidth"`
+	TileHeight int                    `json:"tileHeight"`
+	Offset     core.Vector2D          `json:"offset"`
+	Opacity    float64                `json:"opacity"`
+	Visible    bool                   `json:"visible"`
+	Data       []int                  `json:"data"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// LevelData is the in-memory form of a level file. Native level JSON files
+// unmarshal straight into it and the Tiled importer produces it as well.
+type LevelData struct {
+	Chapter     int            `json:"chapter,omitempty"`
+	Story       string         `json:"story,omitempty"`
+	Platforms   []Platform     `json:"platforms"`
+	Obstacles   []Obstacle     `json:"obstacles"`
+	Items       []ItemOnMap    `json:"items"`
+	SpawnPoints []SpawnPoint   `json:"spawnPoints,omitempty"`
+	Triggers    []Trigger      `json:"triggers,omitempty"`
+	Checkpoints []Checkpoint   `json:"checkpoints,omitempty"`
+	Hazards     []Hazard       `json:"hazards,omitempty"`
+	Enemies     []Enemy        `json:"enemies,omitempty"`
+	Spawners    []EnemySpawner `json:"spawners,omitempty"`
+	Logic       []LogicObject  `json:"logic,omitempty"`
+	// KillPlane is the height below which the player falls out of the level.
+	KillPlane  *float64               `json:"killPlane,omitempty"`
+	Tilesets   []Tileset              `json:"tilesets,omitempty"`
+	TileLayers []TileLayer            `json:"tileLayers,omitempty"`
+	Background string                 `json:"background"`
+	Layers     []ParallaxLayer        `json:"layers,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// imported is set for levels converted from another format, which Encode
+	// cannot write back.
+	imported bool
+}
+
+// Imported reports whether the level was converted from a Tiled map.
+func (l *LevelData) Imported() bool {
+	return l.imported
+}
+
+// FallLimit returns the height below which the player has fallen out of the
+// level: its kill plane, or a margin below the lowest platform when it has
+// none. It reports false for a level without either.
+func (l *LevelData) FallLimit() (float64, bool) {
+	if l.KillPlane != nil {
+		return *l.KillPlane, true
+	}
+	if len(l.Platforms) == 0 {
+		return 0, false
+	}
+	bottom := math.Inf(-1)
+	for _, platform := range l.Platforms {
+		bottom = math.Max(bottom, platform.RigidBody.Position.Y+platform.RigidBody.Size.Y)
+	}
+	return bottom + fallMargin, true
+}
+
+// Start returns where the player starts: the spawn point named "start", or
+// else "player". It reports false for a level without either.
+func (l *LevelData) Start() (core.Vector2D, bool) {
+	for _, name := range []string{"start", "player"} {
+		for _, spawn := range l.SpawnPoints {
+			if spawn.Name == name {
+				return spawn.Position, true
+			}
+		}
+	}
+	return core.Vector2D{}, false
+}
+
+// Exit returns the trigger that completes the level: the one of type "exit",
+// or else the one named so. Its "next" property may name the file of the
+// level that follows. It reports false for a level without one.
+func (l *LevelData) Exit() (Trigger, bool) {
+	for _, trigger := range l.Triggers {
+		if trigger.Type == TriggerExit {
+			return trigger, true
+		}
+	}
+	for _, trigger := range l.Triggers {
+		if trigger.Name == TriggerExit {
+			return trigger, true
+		}
+	}
+	return Trigger{}, false
+}
+
+// Circuit wires the logic objects of the level together.
+func (l *LevelData) Circuit() (*logic.Circuit, error) {
+	nodes := make([]logic.Node, len(l.Logic))
+	for i, object := range l.Logic {
+		nodes[i] = logic.Node{ID: object.ID, Kind: object.Kind, Inputs: object.Inputs, On: object.On, Duration: object.Duration}
+	}
+	return logic.NewCircuit(nodes)
+}
+
+// Load reads a level from disk. Tiled maps (.tmx, or .json files exported by
+// Tiled) are converted, anything else is read as a native level.
+func Load(levelPath string) (*LevelData, error) {
+	var level *LevelData
+	// On WASM LoadData only logs what the callback returns, so keep it.
+	var parseErr error
+	err := utils.LoadData(levelPath, func(data []byte) error {
+		switch {
+		case strings.EqualFold(path.Ext(levelPath), ".tmx"):
+			level, parseErr = ParseTMX(data, path.Dir(levelPath))
+		case isTiledJSON(data):
+			level, parseErr = ParseTiledJSON(data, path.Dir(levelPath))
+		default:
+			level = &LevelData{}
+			parseErr = json.Unmarshal(data, level)
+		}
+		return parseErr
+	})
+	if err == nil {
+		err = parseErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load level %s: %w", levelPath, err)
+	}
+	if level == nil {
+		return nil, fmt.Errorf("failed to load level %s: no data", levelPath)
+	}
+	level.normalize()
+	return level, nil
+}
+
+// normalize fills in the runtime state that level files do not carry.
+func (l *LevelData) normalize() {
+	for i := range l.Platforms {
+		platform := &l.Platforms[i]
+		if platform.RigidBody == nil {
+			platform.RigidBody = &physics.RigidBody{}
+		}
+		platform.RigidBody = prepareBody(platform.RigidBody, "platform", true)
+		platform.RigidBody.IsOneWay = platform.Kind == PlatformOneWay
+		platform.Movement.InitialPosX = platform.RigidBody.Position.X
+		platform.Movement.InitialPosY = platform.RigidBody.Position.Y
+	}
+	for i := range l.Obstacles {
+		obstacle := &l.Obstacles[i]
+		if obstacle.RigidBody == nil {
+			obstacle.RigidBody = &physics.RigidBody{}
+		}
+		obstacle.RigidBody = prepareBody(obstacle.RigidBody, obstacle.Type, true)
+		obstacle.Movement.InitialPosX = obstacle.RigidBody.Position.X
+		obstacle.Movement.InitialPosY = obstacle.RigidBody.Position.Y
+	}
+	for i := range l.Items {
+		item := &l.Items[i]
+		if item.RigidBody == nil {
+			item.RigidBody = &physics.RigidBody{}
+		}
+		item.RigidBody = prepareBody(item.RigidBody, item.Name, true)
+		item.RigidBody.SetPickable(true)
+	}
+}
+
+// prepareBody rebuilds a body decoded from JSON through NewRigidBody so it gets
+// the same defaults as bodies created in code.
+func prepareBody(rb *physics.RigidBody, identifier string, isStatic bool) *physics.RigidBody {
+	mass := rb.Mass
+	if mass == 0 {
+		mass = 1
+	}
+	if identifier == "" {
+		identifier = rb.Identifier
+	}
+	body := physics.NewRigidBody(rb.Position, rb.Size, mass, isStatic, identifier)
+	body.IsPushable = rb.IsPushable
+	return body
+}
+
+// levelBody is the part of a rigid body that level files store.
+type levelBody struct {
+	Position   core.Vector2D `json:"position"`
+	Size       core.Vector2D `json:"size"`
+	Mass       float64       `json:"mass,omitempty"`
+	IsPushable bool          `json:"isPushable,omitempty"`
+}
+
+func newLevelBody(rb *physics.RigidBody) levelBody {
+	body := levelBody{Position: rb.Position, Size: rb.Size, IsPushable: rb.IsPushable}
+	if rb.Mass != 1 {
+		body.Mass = rb.Mass
+	}
+	return body
+}
+
+// Encode serializes the level in the native level format. Only what a level
+// file describes is written, not the runtime state of the bodies.
+func (l *LevelData) Encode() ([]byte, error) {
+	type platform struct {
+		Body       levelBody              `json:"body"`
+		Kind       PlatformKind           `json:"kind,omitempty"`
+		Movement   *Movement              `json:"movement,omitempty"`
+		Properties map[string]interface{} `json:"properties,omitempty"`
+	}
+	type obstacle struct {
+		Type       string                 `json:"type"`
+		Movement   *Movement              `json:"movement,omitempty"`
+		Body       levelBody              `json:"body"`
+		Damage     *core.Damage           `json:"damage,omitempty"`
+		Properties map[string]interface{} `json:"properties,omitempty"`
+	}
+	type item struct {
+		Name       string                 `json:"name"`
+		Body       levelBody              `json:"body"`
+		Properties map[string]interface{} `json:"properties,omitempty"`
+	}
+	out := struct {
+		Chapter     int                    `json:"chapter,omitempty"`
+		Story       string                 `json:"story,omitempty"`
+		Platforms   []platform             `json:"platforms"`
+		Obstacles   []obstacle             `json:"obstacles"`
+		Items       []item                 `json:"items"`
+		SpawnPoints []SpawnPoint           `json:"spawnPoints,omitempty"`
+		Triggers    []Trigger              `json:"triggers,omitempty"`
+		Checkpoints []Checkpoint           `json:"checkpoints,omitempty"`
+		Hazards     []Hazard               `json:"hazards,omitempty"`
+		Enemies     []Enemy                `json:"enemies,omitempty"`
+		Spawners    []EnemySpawner         `json:"spawners,omitempty"`
+		Logic       []LogicObject          `json:"logic,omitempty"`
+		KillPlane   *float64               `json:"killPlane,omitempty"`
+		Tilesets    []Tileset              `json:"tilesets,omitempty"`
+		TileLayers  []TileLayer            `json:"tileLayers,omitempty"`
+		Background  string                 `json:"background"`
+		Layers      []ParallaxLayer        `json:"layers,omitempty"`
+		Properties  map[string]interface{} `json:"properties,omitempty"`
+	}{
+		Chapter:     l.Chapter,
+		Story:       l.Story,
+		Platforms:   []platform{},
+		Obstacles:   []obstacle{},
+		Items:       []item{},
+		SpawnPoints: l.SpawnPoints,
+		Triggers:    l.Triggers,
+		Checkpoints: l.Checkpoints,
+		Hazards:     l.Hazards,
+		Enemies:     l.Enemies,
+		Spawners:    l.Spawners,
+		Logic:       l.Logic,
+		KillPlane:   l.KillPlane,
+		Tilesets:    l.Tilesets,
+		TileLayers:  l.TileLayers,
+		Background:  l.Background,
+		Layers:      l.Layers,
+		Properties:  l.Properties,
+	}
+	for _, p := range l.Platforms {
+		encoded := platform{Body: newLevelBody(p.RigidBody), Kind: p.Kind, Properties: p.Properties}
+		if p.Movement.Type != "" {
+			movement := p.Movement
+			encoded.Movement = &movement
+		}
+		out.Platforms = append(out.Platforms, encoded)
+	}
+	for _, o := range l.Obstacles {
+		encoded := obstacle{Type: o.Type, Body: newLevelBody(o.RigidBody), Damage: o.Damage, Properties: o.Properties}
+		if o.Movement.Type != "" {
+			movement := o.Movement
+			encoded.Movement = &movement
+		}
+		out.Obstacles = append(out.Obstacles, encoded)
+	}
+	for _, i := range l.Items {
+		out.Items = append(out.Items, item{Name: i.Name, Body: newLevelBody(i.RigidBody), Properties: i.Properties})
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
@@ -0,0 +1,71 @@
+package levelfile
+
+import (
+	"encoding/json"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+	"github.com/joaorufino/gopher-game/pkg/spawner"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const nativeLevel = `{
+  "platforms": [{"body": {"position": {"x": 0, "y": 300}, "size": {"x": 200, "y": 20}}}],
+  "obstacles": [
+    {"type": "docker_container", "body": {"position": {"x": 50, "y": 250}, "size": {"x": 50, "y": 50}},
+     "movement": {"type": "horizontal", "distance": 100, "speed": 50}}
+  ],
+  "items": [{"name": "Kubernetes Shield", "body": {"position": {"x": 100, "y": 200}, "size": {"x": 50, "y": 50}}}],
+  "background": "images/background.png"
+}`
+
+var _ = Describe("Level encoding", func() {
+	It("should round trip a native level without runtime body state", func() {
+		level := &LevelData{}
+		Expect(json.Unmarshal([]byte(nativeLevel), level)).To(Succeed())
+		level.normalize()
+		level.Obstacles[0].RigidBody.Velocity.X = 12
+
+		data, err := level.Encode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("velocity"))
+		Expect(string(data)).NotTo(ContainSubstring("CollidingBodies"))
+
+		decoded := &LevelData{}
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		decoded.normalize()
+		Expect(decoded.Platforms[0].RigidBody.Size.X).To(Equal(200.0))
+		Expect(decoded.Obstacles[0].Movement).To(Equal(level.Obstacles[0].Movement))
+		Expect(decoded.Items[0].Name).To(Equal("Kubernetes Shield"))
+		Expect(decoded.Items[0].RigidBody.IsPickable).To(BeTrue())
+		Expect(decoded.Background).To(Equal("images/background.png"))
+	})
+
+	It("should read enemy spawners and keep them when encoding", func() {
+		level := &LevelData{}
+		Expect(json.Unmarshal([]byte(`{
+  "platforms": [],
+  "spawners": [{
+    "name": "ambush",
+    "enemy": {"behavior": "chase", "body": {"size": {"x": 24, "y": 24}}},
+    "area": {"position": {"x": 500, "y": 200}, "size": {"x": 200, "y": 60}},
+    "maxAlive": 2,
+    "waves": [{"count": 2}, {"count": 4, "rate": 2, "delay": 3}],
+    "after": {"trigger": "gate"}
+  }]
+}`), level)).To(Succeed())
+		config := level.Spawners[0].Spawner()
+		Expect(config.Name).To(Equal("ambush"))
+		Expect(config.Size).To(Equal(core.Vector2D{X: 24, Y: 24}))
+		Expect(config.MaxAlive).To(Equal(2))
+		Expect(config.Waves).To(Equal([]spawner.Wave{{Count: 2}, {Count: 4, Rate: 2, Delay: 3}}))
+		Expect(config.After).To(Equal(spawner.Condition{Trigger: "gate"}))
+
+		data, err := level.Encode()
+		Expect(err).NotTo(HaveOccurred())
+		decoded := &LevelData{}
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(decoded.Spawners).To(Equal(level.Spawners))
+	})
+})
@@ -0,0 +1,100 @@
+package levelfile
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// Movement defines the movement properties for an obstacle.
+type Movement struct {
+	Type        string  `json:"type"`
+	Distance    float64 `json:"distance"`
+	Speed       float64 `json:"speed"`
+	InitialPosX float64 `json:"-"`
+	InitialPosY float64 `json:"-"`
+}
+
+// Obstacle represents an obstacle with potential movement.
+type Obstacle struct {
+	Type      string             `json:"type"`
+	Movement  Movement           `json:"movement"`
+	RigidBody *physics.RigidBody `json:"body"`
+	// Damage, when set, hurts the player on contact.
+	Damage     *core.Damage           `json:"damage,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// PlatformKind tells how a platform behaves.
+type PlatformKind string
+
+const (
+	PlatformStatic    PlatformKind = "static"
+	PlatformMoving    PlatformKind = "moving"
+	PlatformCrumbling PlatformKind = "crumbling"
+	PlatformOneWay    PlatformKind = "oneWay"
+)
+
+const (
+	// crumbleDelay is how long a crumbling platform holds once stood on.
+	crumbleDelay = 0.5
+	// crumbleRespawnDelay is how long a crumbled platform stays away.
+	crumbleRespawnDelay = 3.0
+)
+
+// Platform represents a platform in the game. Platforms without a kind are
+// static.
+type Platform struct {
+	RigidBody  *physics.RigidBody     `json:"body"`
+	Kind       PlatformKind           `json:"kind,omitempty"`
+	Movement   Movement               `json:"movement"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	crumbleElapsed float64
+	respawnIn      float64
+	broken         bool
+}
+
+// Crumble advances a crumbling platform by deltaTime seconds: once stood on
+// it breaks after a moment, and comes back a while later. It reports whether
+// the platform broke or came back.
+func (p *Platform) Crumble(deltaTime float64, stoodOn bool) bool {
+	if p.broken {
+		p.respawnIn -= deltaTime
+		if p.respawnIn <= 0 {
+			p.broken = false
+			p.crumbleElapsed = 0
+			return true
+		}
+		return false
+	}
+	if p.crumbleElapsed > 0 || stoodOn {
+		p.crumbleElapsed += deltaTime
+		if p.crumbleElapsed >= crumbleDelay {
+			p.broken = true
+			p.respawnIn = crumbleRespawnDelay
+			return true
+		}
+	}
+	return false
+}
+
+// Broken reports whether a crumbling platform is broken.
+func (p *Platform) Broken() bool {
+	return p.broken
+}
+
+// Cracked returns how far a crumbling platform is from breaking, from 0 when
+// untouched to 1 when it breaks.
+func (p *Platform) Cracked() float64 {
+	return math.Min(p.crumbleElapsed/crumbleDelay, 1)
+}
+
+// ItemOnMap represents an item placed on the map.
+type ItemOnMap struct {
+	Name       string                 `json:"name"`
+	RigidBody  *physics.RigidBody     `json:"body"`
+	Item       core.Item              `json:"-"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
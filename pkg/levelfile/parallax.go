@@ -0,0 +1,52 @@
+package levelfile
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+)
+
+// ParallaxLayer is an image drawn behind or in front of the level that
+// scrolls at its own speed.
+type ParallaxLayer struct {
+	Image string `json:"image"`
+	// ScrollX and ScrollY tell how much the layer follows the camera: 0 keeps
+	// it fixed on screen, 1 moves it with the level and values in between make
+	// it look further away.
+	ScrollX float64       `json:"scrollX"`
+	ScrollY float64       `json:"scrollY"`
+	Offset  core.Vector2D `json:"offset"`
+	RepeatX bool          `json:"repeatX,omitempty"`
+	RepeatY bool          `json:"repeatY,omitempty"`
+	// Tint is a color in "#rrggbb" or "#aarrggbb" form the image is multiplied with.
+	Tint string `json:"tint,omitempty"`
+	// AutoScroll moves the layer on its own, in pixels per second.
+	AutoScroll core.Vector2D `json:"autoScroll"`
+	// Foreground layers are drawn over the player instead of behind the level.
+	Foreground bool `json:"foreground,omitempty"`
+}
+
+// ParseTint parses a "#rrggbb" or "#aarrggbb" color, the format Tiled uses.
+// An empty string is no tint, i.e. opaque white.
+func ParseTint(value string) (color.NRGBA, error) {
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	if value == "" {
+		return white, nil
+	}
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return white, fmt.Errorf("invalid tint %q", value)
+	}
+	parsed, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return white, fmt.Errorf("invalid tint %q: %w", value, err)
+	}
+	alpha := uint64(0xff)
+	if len(hex) == 8 {
+		alpha = parsed >> 24
+	}
+	return color.NRGBA{R: uint8(parsed >> 16), G: uint8(parsed >> 8), B: uint8(parsed), A: uint8(alpha)}, nil
+}
@@ -0,0 +1,859 @@
+package levelfile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+	"github.com/joaorufino/gopher-game/internal/utils"
+	"github.com/joaorufino/gopher-game/pkg/logic"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// tiledGIDMask strips the flip flags Tiled stores in the high bits of a tile id.
+const tiledGIDMask = 0x1FFFFFFF
+
+// tiledMap mirrors the Tiled JSON map format. TMX files are decoded into the
+// same structure so both formats share one conversion path.
+type tiledMap struct {
+	Width      int             `json:"width"`
+	Height     int             `json:"height"`
+	TileWidth  int             `json:"tilewidth"`
+	TileHeight int             `json:"tileheight"`
+	Infinite   bool            `json:"infinite"`
+	Layers     []tiledLayer    `json:"layers"`
+	Tilesets   []tiledTileset  `json:"tilesets"`
+	Properties []tiledProperty `json:"properties"`
+}
+
+type tiledLayer struct {
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Class       string          `json:"class"`
+	Visible     bool            `json:"visible"`
+	Opacity     float64         `json:"opacity"`
+	OffsetX     float64         `json:"offsetx"`
+	OffsetY     float64         `json:"offsety"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Data        json.RawMessage `json:"data"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Image       string          `json:"image"`
+	ParallaxX   *float64        `json:"parallaxx"`
+	ParallaxY   *float64        `json:"parallaxy"`
+	RepeatX     bool            `json:"repeatx"`
+	RepeatY     bool            `json:"repeaty"`
+	TintColor   string          `json:"tintcolor"`
+	Objects     []tiledObject   `json:"objects"`
+	Layers      []tiledLayer    `json:"layers"`
+	Properties  []tiledProperty `json:"properties"`
+
+	tiles []int
+}
+
+type tiledObject struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	Class      string          `json:"class"`
+	X          float64         `json:"x"`
+	Y          float64         `json:"y"`
+	Width      float64         `json:"width"`
+	Height     float64         `json:"height"`
+	GID        uint32          `json:"gid"`
+	Point      bool            `json:"point"`
+	Properties []tiledProperty `json:"properties"`
+}
+
+type tiledTileset struct {
+	FirstGID   int    `json:"firstgid"`
+	Source     string `json:"source"`
+	Name       string `json:"name"`
+	Image      string `json:"image"`
+	ImageWidth int    `json:"imagewidth"`
+	TileWidth  int    `json:"tilewidth"`
+	TileHeight int    `json:"tileheight"`
+	Columns    int    `json:"columns"`
+	TileCount  int    `json:"tilecount"`
+	Margin     int    `json:"margin"`
+	Spacing    int    `json:"spacing"`
+}
+
+type tiledProperty struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// isTiledJSON reports whether data looks like a map exported by Tiled.
+func isTiledJSON(data []byte) bool {
+	var probe struct {
+		TiledVersion string          `json:"tiledversion"`
+		Orientation  string          `json:"orientation"`
+		Layers       json.RawMessage `json:"layers"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return probe.TiledVersion != "" || (probe.Orientation != "" && probe.Layers != nil)
+}
+
+// ParseTiledJSON converts a map exported from Tiled as JSON into level data.
+// baseDir is the directory of the map file, used to resolve relative paths.
+func ParseTiledJSON(data []byte, baseDir string) (*LevelData, error) {
+	var tm tiledMap
+	if err := json.Unmarshal(data, &tm); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Tiled map: %w", err)
+	}
+	if err := decodeTiledLayers(tm.Layers); err != nil {
+		return nil, err
+	}
+	return convertTiledMap(&tm, baseDir)
+}
+
+func decodeTiledLayers(layers []tiledLayer) error {
+	for i := range layers {
+		layer := &layers[i]
+		if len(layer.Layers) > 0 {
+			if err := decodeTiledLayers(layer.Layers); err != nil {
+				return err
+			}
+		}
+		if layer.Type != "tilelayer" || len(layer.Data) == 0 {
+			continue
+		}
+		if layer.Data[0] == '"' {
+			var encoded string
+			if err := json.Unmarshal(layer.Data, &encoded); err != nil {
+				return fmt.Errorf("layer %q: %w", layer.Name, err)
+			}
+			tiles, err := decodeTileData(encoded, layer.Encoding, layer.Compression)
+			if err != nil {
+				return fmt.Errorf("layer %q: %w", layer.Name, err)
+			}
+			layer.tiles = tiles
+			continue
+		}
+		if err := json.Unmarshal(layer.Data, &layer.tiles); err != nil {
+			return fmt.Errorf("layer %q: %w", layer.Name, err)
+		}
+	}
+	return nil
+}
+
+// decodeTileData decodes csv or base64 (optionally zlib/gzip compressed) tile data.
+func decodeTileData(raw, encoding, compression string) ([]int, error) {
+	switch encoding {
+	case "csv":
+		var tiles []int
+		for _, field := range strings.Split(raw, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			gid, err := strconv.ParseUint(field, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid csv tile id %q: %w", field, err)
+			}
+			tiles = append(tiles, int(gid))
+		}
+		return tiles, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 tile data: %w", err)
+		}
+		var reader io.Reader = bytes.NewReader(decoded)
+		switch compression {
+		case "":
+		case "zlib":
+			if reader, err = zlib.NewReader(reader); err != nil {
+				return nil, fmt.Errorf("invalid zlib tile data: %w", err)
+			}
+		case "gzip":
+			if reader, err = gzip.NewReader(reader); err != nil {
+				return nil, fmt.Errorf("invalid gzip tile data: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported tile compression %q", compression)
+		}
+		decoded, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tile data: %w", err)
+		}
+		if len(decoded)%4 != 0 {
+			return nil, fmt.Errorf("tile data length %d is not a multiple of 4", len(decoded))
+		}
+		tiles := make([]int, len(decoded)/4)
+		for i := range tiles {
+			b := decoded[i*4:]
+			tiles[i] = int(uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24)
+		}
+		return tiles, nil
+	default:
+		return nil, fmt.Errorf("unsupported tile encoding %q", encoding)
+	}
+}
+
+// convertTiledMap turns a decoded Tiled map into level data.
+func convertTiledMap(tm *tiledMap, baseDir string) (*LevelData, error) {
+	if tm.Infinite {
+		return nil, fmt.Errorf("infinite Tiled maps are not supported")
+	}
+
+	level := &LevelData{Properties: tiledProperties(tm.Properties), imported: true}
+	if background, ok := level.Properties["background"].(string); ok {
+		level.Background = resolveTiledPath(baseDir, background)
+	}
+	if story, ok := level.Properties["story"].(string); ok {
+		level.Story = story
+	}
+	if chapter, ok := level.Properties["chapter"]; ok {
+		level.Chapter = int(toFloat(chapter))
+	}
+	if killPlane, ok := level.Properties["killPlane"]; ok {
+		y := toFloat(killPlane)
+		level.KillPlane = &y
+	}
+
+	for _, ts := range tm.Tilesets {
+		tileset, err := loadTiledTileset(ts, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		level.Tilesets = append(level.Tilesets, tileset)
+	}
+
+	background := level.Background
+	if err := convertTiledLayers(level, tm, tm.Layers, core.Vector2D{}, baseDir); err != nil {
+		return nil, err
+	}
+	// A background set as a map property stays behind the image layers.
+	if background != "" && len(level.Layers) > 0 {
+		level.Layers = append([]ParallaxLayer{{Image: background, ScrollX: 1, ScrollY: 1}}, level.Layers...)
+	}
+	return level, nil
+}
+
+func convertTiledLayers(level *LevelData, tm *tiledMap, layers []tiledLayer, offset core.Vector2D, baseDir string) error {
+	for _, layer := range layers {
+		layerOffset := core.Vector2D{X: offset.X + layer.OffsetX, Y: offset.Y + layer.OffsetY}
+		switch layer.Type {
+		case "group":
+			if err := convertTiledLayers(level, tm, layer.Layers, layerOffset, baseDir); err != nil {
+				return err
+			}
+		case "imagelayer":
+			if layer.Image == "" {
+				continue
+			}
+			if level.Background == "" {
+				level.Background = resolveTiledPath(baseDir, layer.Image)
+			}
+			level.Layers = append(level.Layers, tiledImageLayer(layer, layerOffset, baseDir))
+		case "tilelayer":
+			tileLayer := TileLayer{
+				Name:       layer.Name,
+				Width:      layer.Width,
+				Height:     layer.Height,
+				TileWidth:  tm.TileWidth,
+				TileHeight: tm.TileHeight,
+				Offset:     layerOffset,
+				Opacity:    layer.Opacity,
+				Visible:    layer.Visible,
+				Data:       make([]int, len(layer.tiles)),
+				Properties: tiledProperties(layer.Properties),
+			}
+			if len(layer.tiles) != layer.Width*layer.Height {
+				return fmt.Errorf("layer %q has %d tiles, expected %d", layer.Name, len(layer.tiles), layer.Width*layer.Height)
+			}
+			for i, gid := range layer.tiles {
+				tileLayer.Data[i] = gid & tiledGIDMask
+			}
+			level.TileLayers = append(level.TileLayers, tileLayer)
+			if isTruthy(tileLayer.Properties["collides"]) {
+				level.Platforms = append(level.Platforms, collisionPlatforms(tileLayer)...)
+			}
+		case "objectgroup":
+			for _, obj := range layer.Objects {
+				convertTiledObject(level, layer, obj, layerOffset)
+			}
+		}
+	}
+	return nil
+}
+
+// convertTiledObject maps one object onto a platform, obstacle, item, spawn
+// point, trigger, checkpoint, hazard, enemy or logic object, depending on its type
+// (or class), falling back to the name of the layer it lives in.
+func convertTiledObject(level *LevelData, layer tiledLayer, obj tiledObject, offset core.Vector2D) {
+	props := tiledProperties(obj.Properties)
+	position := core.Vector2D{X: obj.X + offset.X, Y: obj.Y + offset.Y}
+	if obj.GID != 0 {
+		// Tile objects are anchored at their bottom-left corner.
+		position.Y -= obj.Height
+	}
+	size := core.Vector2D{X: obj.Width, Y: obj.Height}
+
+	switch tiledObjectKind(obj, layer) {
+	case "platform":
+		body := physics.NewRigidBody(position, size, 1, true, "platform")
+		body.IsPushable = isTruthy(props["pushable"])
+		kind, _ := props["kind"].(string)
+		level.Platforms = append(level.Platforms, Platform{RigidBody: body, Kind: PlatformKind(kind), Movement: tiledMovement(props), Properties: props})
+	case "obstacle":
+		obstacleType := obj.Name
+		if kind, ok := props["kind"].(string); ok {
+			obstacleType = kind
+		}
+		body := physics.NewRigidBody(position, size, 1, true, obstacleType)
+		body.IsPushable = isTruthy(props["pushable"])
+		obstacle := Obstacle{Type: obstacleType, Movement: tiledMovement(props), RigidBody: body, Properties: props}
+		if _, ok := props["damage"]; ok {
+			damage := tiledDamage(props)
+			obstacle.Damage = &damage
+		}
+		level.Obstacles = append(level.Obstacles, obstacle)
+	case "item":
+		name := obj.Name
+		if itemName, ok := props["item"].(string); ok {
+			name = itemName
+		}
+		body := physics.NewRigidBody(position, size, 1, true, name)
+		level.Items = append(level.Items, ItemOnMap{Name: name, RigidBody: body, Properties: props})
+	case "spawn":
+		level.SpawnPoints = append(level.SpawnPoints, SpawnPoint{Name: obj.Name, Position: position, Properties: props})
+	case "trigger":
+		triggerType, _ := props["triggerType"].(string)
+		level.Triggers = append(level.Triggers, Trigger{
+			Name:       obj.Name,
+			Type:       triggerType,
+			Body:       core.Rect{Position: position, Size: size},
+			Properties: props,
+		})
+	case "checkpoint":
+		level.Checkpoints = append(level.Checkpoints, Checkpoint{
+			Name:       obj.Name,
+			Body:       core.Rect{Position: position, Size: size},
+			Properties: props,
+		})
+	case "hazard":
+		level.Hazards = append(level.Hazards, Hazard{
+			Name:       obj.Name,
+			Body:       core.Rect{Position: position, Size: size},
+			Damage:     tiledDamage(props),
+			Properties: props,
+		})
+	case "enemy":
+		behavior := obj.Name
+		if name, ok := props["behavior"].(string); ok {
+			behavior = name
+		}
+		tree, _ := props["tree"].(string)
+		enemy := Enemy{
+			Behavior:     behavior,
+			Body:         core.Rect{Position: position, Size: size},
+			Tree:         tree,
+			Speed:        toFloat(props["speed"]),
+			Radius:       toFloat(props["radius"]),
+			JumpVelocity: toFloat(props["jumpVelocity"]),
+			Properties:   props,
+		}
+		if _, ok := props["damage"]; ok {
+			damage := tiledDamage(props)
+			enemy.Damage = &damage
+		}
+		level.Enemies = append(level.Enemies, enemy)
+	case "logic":
+		kind, _ := tiledLogicKind(tiledKind(obj, layer))
+		object := LogicObject{
+			ID:         obj.Name,
+			Kind:       kind,
+			On:         isTruthy(props["on"]),
+			Duration:   toFloat(props["duration"]),
+			Limit:      int(toFloat(props["limit"])),
+			Properties: props,
+		}
+		if id, ok := props["id"].(string); ok {
+			object.ID = id
+		}
+		if inputs, ok := props["inputs"].(string); ok {
+			for _, input := range strings.Split(inputs, ",") {
+				if input = strings.TrimSpace(input); input != "" {
+					object.Inputs = append(object.Inputs, input)
+				}
+			}
+		}
+		object.Spawn, _ = props["spawn"].(string)
+		if size.X > 0 && size.Y > 0 {
+			object.Body = &core.Rect{Position: position, Size: size}
+		}
+		level.Logic = append(level.Logic, object)
+	default:
+		log.Printf("tiled: skipping object %d (%q) in layer %q: unknown type", obj.ID, obj.Name, layer.Name)
+	}
+}
+
+// tiledDamage reads how much an obstacle or hazard hurts from its properties.
+func tiledDamage(props map[string]interface{}) core.Damage {
+	damage := core.Damage{Amount: toFloat(props["damage"]), Knockback: toFloat(props["knockback"])}
+	if damageType, ok := props["damageType"].(string); ok {
+		damage.Type = core.DamageType(damageType)
+	}
+	return damage
+}
+
+// tiledMovement reads the movement of a platform or obstacle from its properties.
+func tiledMovement(props map[string]interface{}) Movement {
+	movement := Movement{Distance: toFloat(props["distance"]), Speed: toFloat(props["speed"])}
+	if movementType, ok := props["movement"].(string); ok {
+		movement.Type = movementType
+	} else if movementType, ok := props["movementType"].(string); ok {
+		movement.Type = movementType
+	}
+	return movement
+}
+
+// tiledImageLayer converts a Tiled image layer into a parallax layer. The
+// layer opacity is folded into the tint; the custom properties "foreground",
+// "autoScrollX" and "autoScrollY" cover what Tiled has no setting for.
+func tiledImageLayer(layer tiledLayer, offset core.Vector2D, baseDir string) ParallaxLayer {
+	props := tiledProperties(layer.Properties)
+	parallax := ParallaxLayer{
+		Image:      resolveTiledPath(baseDir, layer.Image),
+		ScrollX:    1,
+		ScrollY:    1,
+		Offset:     offset,
+		RepeatX:    layer.RepeatX,
+		RepeatY:    layer.RepeatY,
+		Tint:       tiledTint(layer.TintColor, layer.Opacity),
+		AutoScroll: core.Vector2D{X: toFloat(props["autoScrollX"]), Y: toFloat(props["autoScrollY"])},
+		Foreground: isTruthy(props["foreground"]),
+	}
+	if layer.ParallaxX != nil {
+		parallax.ScrollX = *layer.ParallaxX
+	}
+	if layer.ParallaxY != nil {
+		parallax.ScrollY = *layer.ParallaxY
+	}
+	return parallax
+}
+
+// tiledTint combines a Tiled tint color with the layer opacity.
+func tiledTint(tint string, opacity float64) string {
+	if opacity >= 1 || opacity < 0 {
+		return tint
+	}
+	hex := strings.TrimPrefix(tint, "#")
+	alpha := 1.0
+	switch len(hex) {
+	case 0:
+		hex = "ffffff"
+	case 8:
+		a, _ := strconv.ParseUint(hex[:2], 16, 8)
+		alpha = float64(a) / 0xff
+		hex = hex[2:]
+	}
+	return fmt.Sprintf("#%02x%s", int(math.Round(alpha*opacity*0xff)), hex)
+}
+
+// tiledKind returns the type (or class) of an object, falling back to the
+// class or name of its layer.
+func tiledKind(obj tiledObject, layer tiledLayer) string {
+	kind := obj.Type
+	if kind == "" {
+		kind = obj.Class
+	}
+	if kind == "" {
+		kind = layer.Class
+	}
+	if kind == "" {
+		kind = layer.Name
+	}
+	return strings.TrimSpace(kind)
+}
+
+// tiledLogicKind matches a Tiled type with a logic object kind, ignoring case.
+func tiledLogicKind(kind string) (logic.Kind, bool) {
+	for _, known := range logic.Kinds {
+		if strings.EqualFold(string(known), kind) {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+func tiledObjectKind(obj tiledObject, layer tiledLayer) string {
+	kind := tiledKind(obj, layer)
+	if _, ok := tiledLogicKind(kind); ok {
+		return "logic"
+	}
+	switch strings.ToLower(kind) {
+	case "platform", "platforms":
+		return "platform"
+	case "obstacle", "obstacles":
+		return "obstacle"
+	case "item", "items":
+		return "item"
+	case "spawn", "spawns", "spawnpoint", "spawnpoints", "spawn_point", "spawn_points":
+		return "spawn"
+	case "trigger", "triggers":
+		return "trigger"
+	case "checkpoint", "checkpoints":
+		return "checkpoint"
+	case "hazard", "hazards":
+		return "hazard"
+	case "enemy", "enemies":
+		return "enemy"
+	}
+	return ""
+}
+
+// collisionPlatforms merges each horizontal run of solid tiles into a static platform.
+func collisionPlatforms(layer TileLayer) []Platform {
+	var platforms []Platform
+	tw, th := float64(layer.TileWidth), float64(layer.TileHeight)
+	for row := 0; row < layer.Height; row++ {
+		start := -1
+		for col := 0; col <= layer.Width; col++ {
+			solid := col < layer.Width && layer.Data[row*layer.Width+col] != 0
+			if solid && start < 0 {
+				start = col
+			}
+			if !solid && start >= 0 {
+				position := core.Vector2D{X: layer.Offset.X + float64(start)*tw, Y: layer.Offset.Y + float64(row)*th}
+				size := core.Vector2D{X: float64(col-start) * tw, Y: th}
+				platforms = append(platforms, Platform{RigidBody: physics.NewRigidBody(position, size, 1, true, "platform")})
+				start = -1
+			}
+		}
+	}
+	return platforms
+}
+
+// loadTiledTileset resolves embedded and external (.tsx or .json) tilesets.
+func loadTiledTileset(ts tiledTileset, baseDir string) (Tileset, error) {
+	if ts.Source != "" {
+		sourcePath := resolveTiledPath(baseDir, ts.Source)
+		external := tiledTileset{}
+		err := utils.LoadData(sourcePath, func(data []byte) error {
+			if strings.EqualFold(path.Ext(sourcePath), ".tsx") {
+				var tsx tmxTileset
+				if err := xml.Unmarshal(data, &tsx); err != nil {
+					return err
+				}
+				external = tsx.toTiled()
+				return nil
+			}
+			return json.Unmarshal(data, &external)
+		})
+		if err != nil {
+			return Tileset{}, fmt.Errorf("failed to load tileset %s: %w", sourcePath, err)
+		}
+		external.FirstGID = ts.FirstGID
+		ts = external
+		baseDir = path.Dir(sourcePath)
+	}
+
+	columns := ts.Columns
+	if columns == 0 && ts.TileWidth > 0 {
+		columns = (ts.ImageWidth - 2*ts.Margin + ts.Spacing) / (ts.TileWidth + ts.Spacing)
+	}
+	return Tileset{
+		Name:       ts.Name,
+		FirstGID:   ts.FirstGID,
+		Image:      resolveTiledPath(baseDir, ts.Image),
+		TileWidth:  ts.TileWidth,
+		TileHeight: ts.TileHeight,
+		Columns:    columns,
+		TileCount:  ts.TileCount,
+		Margin:     ts.Margin,
+		Spacing:    ts.Spacing,
+	}, nil
+}
+
+// resolveTiledPath makes a path stored relative to a Tiled file relative to the
+// asset root instead, which is what the resource manager expects.
+func resolveTiledPath(baseDir, p string) string {
+	if p == "" || path.IsAbs(p) {
+		return p
+	}
+	return path.Clean(path.Join(baseDir, p))
+}
+
+// tiledProperties flattens Tiled custom properties into a plain map.
+func tiledProperties(props []tiledProperty) map[string]interface{} {
+	if len(props) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(props))
+	for _, prop := range props {
+		result[prop.Name] = prop.Value
+	}
+	return result
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
+
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	case float64:
+		return v != 0
+	}
+	return false
+}
+
+// TMX (XML) representation of a Tiled map.
+type tmxMap struct {
+	XMLName    xml.Name      `xml:"map"`
+	Width      int           `xml:"width,attr"`
+	Height     int           `xml:"height,attr"`
+	TileWidth  int           `xml:"tilewidth,attr"`
+	TileHeight int           `xml:"tileheight,attr"`
+	Infinite   int           `xml:"infinite,attr"`
+	Properties []tmxProperty `xml:"properties>property"`
+	Tilesets   []tmxTileset  `xml:"tileset"`
+	Layers     []tmxLayer    `xml:",any"`
+}
+
+type tmxLayer struct {
+	XMLName    xml.Name
+	Name       string        `xml:"name,attr"`
+	Class      string        `xml:"class,attr"`
+	Visible    *int          `xml:"visible,attr"`
+	Opacity    *float64      `xml:"opacity,attr"`
+	OffsetX    float64       `xml:"offsetx,attr"`
+	OffsetY    float64       `xml:"offsety,attr"`
+	ParallaxX  *float64      `xml:"parallaxx,attr"`
+	ParallaxY  *float64      `xml:"parallaxy,attr"`
+	RepeatX    int           `xml:"repeatx,attr"`
+	RepeatY    int           `xml:"repeaty,attr"`
+	TintColor  string        `xml:"tintcolor,attr"`
+	Width      int           `xml:"width,attr"`
+	Height     int           `xml:"height,attr"`
+	Data       *tmxData      `xml:"data"`
+	Image      *tmxImage     `xml:"image"`
+	Objects    []tmxObject   `xml:"object"`
+	Properties []tmxProperty `xml:"properties>property"`
+	Layers     []tmxLayer    `xml:",any"`
+}
+
+type tmxData struct {
+	Encoding    string `xml:"encoding,attr"`
+	Compression string `xml:"compression,attr"`
+	Tiles       []struct {
+		GID uint32 `xml:"gid,attr"`
+	} `xml:"tile"`
+	Text string `xml:",chardata"`
+}
+
+type tmxImage struct {
+	Source string `xml:"source,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+}
+
+type tmxObject struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	X          float64       `xml:"x,attr"`
+	Y          float64       `xml:"y,attr"`
+	Width      float64       `xml:"width,attr"`
+	Height     float64       `xml:"height,attr"`
+	GID        uint32        `xml:"gid,attr"`
+	Point      *struct{}     `xml:"point"`
+	Properties []tmxProperty `xml:"properties>property"`
+}
+
+type tmxTileset struct {
+	FirstGID   int       `xml:"firstgid,attr"`
+	Source     string    `xml:"source,attr"`
+	Name       string    `xml:"name,attr"`
+	TileWidth  int       `xml:"tilewidth,attr"`
+	TileHeight int       `xml:"tileheight,attr"`
+	TileCount  int       `xml:"tilecount,attr"`
+	Columns    int       `xml:"columns,attr"`
+	Margin     int       `xml:"margin,attr"`
+	Spacing    int       `xml:"spacing,attr"`
+	Image      *tmxImage `xml:"image"`
+}
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+// ParseTMX converts a Tiled TMX (XML) map into level data.
+// baseDir is the directory of the map file, used to resolve relative paths.
+func ParseTMX(data []byte, baseDir string) (*LevelData, error) {
+	var tmx tmxMap
+	if err := xml.Unmarshal(data, &tmx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TMX map: %w", err)
+	}
+
+	tm := &tiledMap{
+		Width:      tmx.Width,
+		Height:     tmx.Height,
+		TileWidth:  tmx.TileWidth,
+		TileHeight: tmx.TileHeight,
+		Infinite:   tmx.Infinite != 0,
+		Properties: tmxProperties(tmx.Properties),
+	}
+	for _, ts := range tmx.Tilesets {
+		tm.Tilesets = append(tm.Tilesets, ts.toTiled())
+	}
+	layers, err := tmxLayers(tmx.Layers)
+	if err != nil {
+		return nil, err
+	}
+	tm.Layers = layers
+	return convertTiledMap(tm, baseDir)
+}
+
+func tmxLayers(layers []tmxLayer) ([]tiledLayer, error) {
+	var result []tiledLayer
+	for _, layer := range layers {
+		converted := tiledLayer{
+			Name:       layer.Name,
+			Class:      layer.Class,
+			Visible:    layer.Visible == nil || *layer.Visible != 0,
+			Opacity:    1,
+			OffsetX:    layer.OffsetX,
+			OffsetY:    layer.OffsetY,
+			ParallaxX:  layer.ParallaxX,
+			ParallaxY:  layer.ParallaxY,
+			RepeatX:    layer.RepeatX != 0,
+			RepeatY:    layer.RepeatY != 0,
+			TintColor:  layer.TintColor,
+			Width:      layer.Width,
+			Height:     layer.Height,
+			Properties: tmxProperties(layer.Properties),
+		}
+		if layer.Opacity != nil {
+			converted.Opacity = *layer.Opacity
+		}
+
+		switch layer.XMLName.Local {
+		case "layer":
+			converted.Type = "tilelayer"
+			if layer.Data != nil {
+				if layer.Data.Encoding == "" {
+					for _, tile := range layer.Data.Tiles {
+						converted.tiles = append(converted.tiles, int(tile.GID))
+					}
+				} else {
+					tiles, err := decodeTileData(layer.Data.Text, layer.Data.Encoding, layer.Data.Compression)
+					if err != nil {
+						return nil, fmt.Errorf("layer %q: %w", layer.Name, err)
+					}
+					converted.tiles = tiles
+				}
+			}
+		case "objectgroup":
+			converted.Type = "objectgroup"
+			for _, obj := range layer.Objects {
+				converted.Objects = append(converted.Objects, tiledObject{
+					ID:         obj.ID,
+					Name:       obj.Name,
+					Type:       obj.Type,
+					Class:      obj.Class,
+					X:          obj.X,
+					Y:          obj.Y,
+					Width:      obj.Width,
+					Height:     obj.Height,
+					GID:        obj.GID,
+					Point:      obj.Point != nil,
+					Properties: tmxProperties(obj.Properties),
+				})
+			}
+		case "imagelayer":
+			converted.Type = "imagelayer"
+			if layer.Image != nil {
+				converted.Image = layer.Image.Source
+			}
+		case "group":
+			converted.Type = "group"
+			children, err := tmxLayers(layer.Layers)
+			if err != nil {
+				return nil, err
+			}
+			converted.Layers = children
+		default:
+			continue
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+func (ts tmxTileset) toTiled() tiledTileset {
+	converted := tiledTileset{
+		FirstGID:   ts.FirstGID,
+		Source:     ts.Source,
+		Name:       ts.Name,
+		TileWidth:  ts.TileWidth,
+		TileHeight: ts.TileHeight,
+		TileCount:  ts.TileCount,
+		Columns:    ts.Columns,
+		Margin:     ts.Margin,
+		Spacing:    ts.Spacing,
+	}
+	if ts.Image != nil {
+		converted.Image = ts.Image.Source
+		converted.ImageWidth = ts.Image.Width
+	}
+	return converted
+}
+
+// tmxProperties converts TMX properties, whose values are always strings, into
+// typed values matching what the JSON format would have produced.
+func tmxProperties(props []tmxProperty) []tiledProperty {
+	var result []tiledProperty
+	for _, prop := range props {
+		raw := prop.Value
+		if raw == "" {
+			raw = prop.Text
+		}
+		var value interface{} = raw
+		switch prop.Type {
+		case "bool":
+			value, _ = strconv.ParseBool(raw)
+		case "int", "float", "object":
+			value, _ = strconv.ParseFloat(raw, 64)
+		}
+		result = append(result, tiledProperty{Name: prop.Name, Type: prop.Type, Value: value})
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package levelfile
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+	"github.com/joaorufino/gopher-game/pkg/logic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLevelfile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Levelfile Suite")
+}
+
+const tiledJSONMap = `{
+  "tiledversion": "1.10.2",
+  "orientation": "orthogonal",
+  "width": 3, "height": 2, "tilewidth": 32, "tileheight": 32,
+  "properties": [{"name": "background", "type": "file", "value": "../images/background.png"}],
+  "tilesets": [{"firstgid": 1, "name": "ground", "image": "../images/tiles.png", "imagewidth": 64, "tilewidth": 32, "tileheight": 32, "tilecount": 2}],
+  "layers": [
+    {"type": "tilelayer", "name": "ground", "width": 3, "height": 2, "visible": true, "opacity": 1,
+     "properties": [{"name": "collides", "type": "bool", "value": true}],
+     "data": [0, 0, 0, 1, 2, 2147483649]},
+    {"type": "objectgroup", "name": "obstacles", "visible": true, "opacity": 1, "objects": [
+      {"id": 1, "name": "docker_container", "x": 10, "y": 20, "width": 50, "height": 50,
+       "properties": [{"name": "movement", "type": "string", "value": "vertical"}, {"name": "distance", "type": "float", "value": 40}, {"name": "speed", "type": "float", "value": 25}]}
+    ]},
+    {"type": "objectgroup", "name": "entities", "visible": true, "opacity": 1, "objects": [
+      {"id": 2, "name": "Kubernetes Shield", "type": "item", "x": 100, "y": 0, "width": 50, "height": 50},
+      {"id": 3, "name": "start", "type": "spawn", "x": 5, "y": 6, "point": true},
+      {"id": 4, "name": "exit", "class": "trigger", "x": 90, "y": 0, "width": 20, "height": 64,
+       "properties": [{"name": "next", "type": "string", "value": "level2.json"}]}
+    ]}
+  ]
+}`
+
+const tmxMapData = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" orientation="orthogonal" width="2" height="1" tilewidth="16" tileheight="16" infinite="0">
+ <properties>
+  <property name="chapter" type="int" value="3"/>
+ </properties>
+ <tileset firstgid="1" name="ground" tilewidth="16" tileheight="16" tilecount="4" columns="2">
+  <image source="tiles.png" width="32" height="32"/>
+ </tileset>
+ <layer id="1" name="ground" width="2" height="1">
+  <data encoding="csv">1,0</data>
+ </layer>
+ <objectgroup id="2" name="platforms" offsetx="5">
+  <object id="1" x="0" y="100" width="200" height="20"/>
+ </objectgroup>
+ <imagelayer id="3" name="clouds" offsety="-40" parallaxx="0.5" parallaxy="0.25" repeatx="1" tintcolor="#ff8000" opacity="0.5">
+  <image source="../images/clouds.png" width="256" height="128"/>
+  <properties>
+   <property name="autoScrollX" type="float" value="-12"/>
+  </properties>
+ </imagelayer>
+ <imagelayer id="4" name="leaves">
+  <image source="../images/leaves.png" width="256" height="128"/>
+  <properties>
+   <property name="foreground" type="bool" value="true"/>
+  </properties>
+ </imagelayer>
+ <objectgroup id="5" name="logic">
+  <object id="2" name="lever" type="switch" x="10" y="10" width="16" height="16"/>
+  <object id="3" name="later" class="Delay" x="30" y="10">
+   <properties>
+    <property name="inputs" value="lever"/>
+    <property name="duration" type="float" value="0.5"/>
+   </properties>
+   <point/>
+  </object>
+  <object id="4" name="door" type="door" x="50" y="0" width="20" height="100">
+   <properties>
+    <property name="inputs" value="later, lever"/>
+   </properties>
+  </object>
+ </objectgroup>
+</map>`
+
+var _ = Describe("Tiled importer", func() {
+	Describe("parsing a Tiled JSON map", func() {
+		var level *LevelData
+
+		BeforeEach(func() {
+			var err error
+			level, err = ParseTiledJSON([]byte(tiledJSONMap), "levels")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should be detected as a Tiled map", func() {
+			Expect(isTiledJSON([]byte(tiledJSONMap))).To(BeTrue())
+			Expect(isTiledJSON([]byte(`{"platforms": []}`))).To(BeFalse())
+		})
+
+		It("should resolve asset paths against the map directory", func() {
+			Expect(level.Background).To(Equal("images/background.png"))
+			Expect(level.Tilesets[0].Image).To(Equal("images/tiles.png"))
+			Expect(level.Tilesets[0].Columns).To(Equal(2))
+		})
+
+		It("should strip flip flags from tile ids", func() {
+			Expect(level.TileLayers[0].Data).To(Equal([]int{0, 0, 0, 1, 2, 1}))
+		})
+
+		It("should turn colliding tile rows into platforms", func() {
+			Expect(level.Platforms).To(HaveLen(1))
+			Expect(level.Platforms[0].RigidBody.Position.Y).To(Equal(32.0))
+			Expect(level.Platforms[0].RigidBody.Size.X).To(Equal(96.0))
+		})
+
+		It("should map obstacles and their movement", func() {
+			Expect(level.Obstacles).To(HaveLen(1))
+			Expect(level.Obstacles[0].Type).To(Equal("docker_container"))
+			Expect(level.Obstacles[0].Movement).To(Equal(Movement{Type: "vertical", Distance: 40, Speed: 25}))
+		})
+
+		It("should map items, spawn points and triggers", func() {
+			Expect(level.Items[0].Name).To(Equal("Kubernetes Shield"))
+			Expect(level.SpawnPoints[0].Name).To(Equal("start"))
+			Expect(level.Triggers[0].Name).To(Equal("exit"))
+			exit, _ := level.Exit()
+			Expect(exit.Properties).To(HaveKeyWithValue("next", "level2.json"))
+		})
+	})
+
+	Describe("parsing a TMX map", func() {
+		It("should produce the same structures as the JSON format", func() {
+			level, err := ParseTMX([]byte(tmxMapData), "levels")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(level.Chapter).To(Equal(3))
+			Expect(level.Tilesets[0].Image).To(Equal("levels/tiles.png"))
+			Expect(level.TileLayers[0].Data).To(Equal([]int{1, 0}))
+			Expect(level.TileLayers[0].Visible).To(BeTrue())
+			Expect(level.Platforms).To(HaveLen(1))
+			Expect(level.Platforms[0].RigidBody.Position.X).To(Equal(5.0))
+		})
+
+		It("should map logic objects and wire them together", func() {
+			level, err := ParseTMX([]byte(tmxMapData), "levels")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(level.Logic).To(HaveLen(3))
+			Expect(level.Logic[0].Kind).To(Equal(logic.KindSwitch))
+			Expect(level.Logic[1].Kind).To(Equal(logic.KindDelay))
+			Expect(level.Logic[1].Body).To(BeNil())
+			Expect(level.Logic[1].Duration).To(Equal(0.5))
+			Expect(level.Logic[2].Inputs).To(Equal([]string{"later", "lever"}))
+			Expect(level.Logic[2].Body.Size).To(Equal(core.Vector2D{X: 20, Y: 100}))
+			_, err = level.Circuit()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should turn image layers into parallax layers", func() {
+			level, err := ParseTMX([]byte(tmxMapData), "levels")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(level.Background).To(Equal("images/clouds.png"))
+			Expect(level.Layers).To(Equal([]ParallaxLayer{
+				{
+					Image:      "images/clouds.png",
+					ScrollX:    0.5,
+					ScrollY:    0.25,
+					Offset:     core.Vector2D{Y: -40},
+					RepeatX:    true,
+					Tint:       "#80ff8000",
+					AutoScroll: core.Vector2D{X: -12},
+				},
+				{Image: "images/leaves.png", ScrollX: 1, ScrollY: 1, Foreground: true},
+			}))
+		})
+	})
+})
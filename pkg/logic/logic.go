@@ -0,0 +1,218 @@
+// Package logic evaluates the switches, plates, logic gates and doors of a
+// level, wired together by id.
+package logic
+
+import (
+	"fmt"
+)
+
+// Kind tells what a node does.
+type Kind string
+
+const (
+	// KindSwitch is flipped by the player.
+	KindSwitch Kind = "switch"
+	// KindPlate is on while something rests on it.
+	KindPlate Kind = "plate"
+	// KindAnd is on while all its inputs are.
+	KindAnd Kind = "and"
+	// KindOr is on while any of its inputs is.
+	KindOr Kind = "or"
+	// KindNot is on while none of its inputs is.
+	KindNot Kind = "not"
+	// KindToggle flips whenever its inputs turn on.
+	KindToggle Kind = "toggle"
+	// KindDelay follows its inputs once they held for Duration seconds.
+	KindDelay Kind = "delay"
+	// KindDoor is open while any of its inputs is on.
+	KindDoor Kind = "door"
+	// KindTimedGate opens for Duration seconds whenever its inputs turn on.
+	KindTimedGate Kind = "timedGate"
+	// KindSpawner spawns something whenever its inputs turn on.
+	KindSpawner Kind = "spawner"
+)
+
+// Kinds lists every known kind.
+var Kinds = []Kind{KindSwitch, KindPlate, KindAnd, KindOr, KindNot, KindToggle, KindDelay, KindDoor, KindTimedGate, KindSpawner}
+
+// IsSensor reports whether nodes of the kind are set from the outside rather
+// than by inputs.
+func (k Kind) IsSensor() bool {
+	return k == KindSwitch || k == KindPlate
+}
+
+// Node describes one element of a circuit.
+type Node struct {
+	ID     string
+	Kind   Kind
+	Inputs []string
+	// On is the initial state of a switch.
+	On bool
+	// Duration is how long a delay waits or a timed gate stays open, in seconds.
+	Duration float64
+}
+
+type node struct {
+	Node
+	inputs   []*node
+	on       bool
+	sensor   bool
+	inputsOn bool
+	timer    float64
+}
+
+// Circuit holds the state of connected nodes.
+type Circuit struct {
+	nodes map[string]*node
+	// order lists the nodes so that every node comes after its inputs.
+	order []*node
+}
+
+// NewCircuit wires the nodes together. It fails on unknown kinds, missing or
+// duplicate ids and on loops, which would never settle.
+func NewCircuit(nodes []Node) (*Circuit, error) {
+	c := &Circuit{nodes: map[string]*node{}}
+	for _, n := range nodes {
+		if n.ID == "" {
+			return nil, fmt.Errorf("%s without an id", n.Kind)
+		}
+		if !knownKind(n.Kind) {
+			return nil, fmt.Errorf("%q has unknown kind %q", n.ID, n.Kind)
+		}
+		if _, exists := c.nodes[n.ID]; exists {
+			return nil, fmt.Errorf("%q is defined more than once", n.ID)
+		}
+		c.nodes[n.ID] = &node{Node: n, sensor: n.On}
+	}
+	for _, n := range nodes {
+		current := c.nodes[n.ID]
+		if !n.Kind.IsSensor() && len(n.Inputs) == 0 {
+			return nil, fmt.Errorf("%s %q has no inputs", n.Kind, n.ID)
+		}
+		for _, id := range n.Inputs {
+			input, exists := c.nodes[id]
+			if !exists {
+				return nil, fmt.Errorf("%q has unknown input %q", n.ID, id)
+			}
+			current.inputs = append(current.inputs, input)
+		}
+	}
+
+	// Order the nodes depth first from their inputs, failing on loops.
+	visiting := map[*node]bool{}
+	done := map[*node]bool{}
+	var visit func(n *node) error
+	visit = func(n *node) error {
+		if done[n] {
+			return nil
+		}
+		if visiting[n] {
+			return fmt.Errorf("%q is part of a loop", n.ID)
+		}
+		visiting[n] = true
+		for _, input := range n.inputs {
+			if err := visit(input); err != nil {
+				return err
+			}
+		}
+		done[n] = true
+		c.order = append(c.order, n)
+		return nil
+	}
+	for _, n := range nodes {
+		if err := visit(c.nodes[n.ID]); err != nil {
+			return nil, err
+		}
+	}
+
+	c.Update(0)
+	return c, nil
+}
+
+// On reports whether the node with the given id is on.
+func (c *Circuit) On(id string) bool {
+	n, ok := c.nodes[id]
+	return ok && n.on
+}
+
+// Flip switches a switch to its other state.
+func (c *Circuit) Flip(id string) {
+	if n, ok := c.nodes[id]; ok && n.Kind.IsSensor() {
+		n.sensor = !n.sensor
+	}
+}
+
+// Set sets the state of a sensor, e.g. whether a plate is pressed.
+func (c *Circuit) Set(id string, on bool) {
+	if n, ok := c.nodes[id]; ok && n.Kind.IsSensor() {
+		n.sensor = on
+	}
+}
+
+// Update propagates the sensor states through the circuit and returns the ids
+// of the nodes that changed state, inputs first.
+func (c *Circuit) Update(deltaTime float64) []string {
+	var changed []string
+	for _, n := range c.order {
+		before := n.on
+		n.update(deltaTime)
+		if n.on != before {
+			changed = append(changed, n.ID)
+		}
+	}
+	return changed
+}
+
+func (n *node) update(deltaTime float64) {
+	anyOn, allOn := false, true
+	for _, input := range n.inputs {
+		anyOn = anyOn || input.on
+		allOn = allOn && input.on
+	}
+	rising := anyOn && !n.inputsOn
+	n.inputsOn = anyOn
+
+	switch n.Kind {
+	case KindSwitch, KindPlate:
+		n.on = n.sensor
+	case KindAnd:
+		n.on = allOn
+	case KindOr, KindDoor, KindSpawner:
+		n.on = anyOn
+	case KindNot:
+		n.on = !anyOn
+	case KindToggle:
+		if rising {
+			n.on = !n.on
+		}
+	case KindDelay:
+		if anyOn == n.on {
+			n.timer = 0
+			return
+		}
+		n.timer += deltaTime
+		if n.timer >= n.Duration {
+			n.on = anyOn
+			n.timer = 0
+		}
+	case KindTimedGate:
+		if rising {
+			n.on = true
+			n.timer = n.Duration
+			return
+		}
+		n.timer -= deltaTime
+		if n.timer <= 0 {
+			n.on = false
+		}
+	}
+}
+
+func knownKind(kind Kind) bool {
+	for _, known := range Kinds {
+		if known == kind {
+			return true
+		}
+	}
+	return false
+}
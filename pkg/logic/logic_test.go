@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLogic(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Logic Suite")
+}
+
+var _ = Describe("Circuit", func() {
+	It("should open a door once both a switch and a plate are on", func() {
+		circuit, err := NewCircuit([]Node{
+			{ID: "door", Kind: KindDoor, Inputs: []string{"both"}},
+			{ID: "both", Kind: KindAnd, Inputs: []string{"lever", "plate"}},
+			{ID: "lever", Kind: KindSwitch},
+			{ID: "plate", Kind: KindPlate},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		circuit.Flip("lever")
+		Expect(circuit.Update(0.1)).To(Equal([]string{"lever"}))
+		Expect(circuit.On("door")).To(BeFalse())
+
+		circuit.Set("plate", true)
+		Expect(circuit.Update(0.1)).To(ConsistOf("plate", "both", "door"))
+		Expect(circuit.On("door")).To(BeTrue())
+	})
+
+	It("should start switches in their initial state", func() {
+		circuit, err := NewCircuit([]Node{
+			{ID: "lever", Kind: KindSwitch, On: true},
+			{ID: "closed", Kind: KindNot, Inputs: []string{"lever"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(circuit.On("lever")).To(BeTrue())
+		Expect(circuit.On("closed")).To(BeFalse())
+	})
+
+	It("should flip a toggle each time its input turns on", func() {
+		circuit, err := NewCircuit([]Node{
+			{ID: "plate", Kind: KindPlate},
+			{ID: "toggle", Kind: KindToggle, Inputs: []string{"plate"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		circuit.Set("plate", true)
+		circuit.Update(0.1)
+		Expect(circuit.On("toggle")).To(BeTrue())
+		// Holding the plate down does not flip it again.
+		circuit.Update(0.1)
+		Expect(circuit.On("toggle")).To(BeTrue())
+
+		circuit.Set("plate", false)
+		circuit.Update(0.1)
+		circuit.Set("plate", true)
+		circuit.Update(0.1)
+		Expect(circuit.On("toggle")).To(BeFalse())
+	})
+
+	It("should wait before following a delayed input", func() {
+		circuit, err := NewCircuit([]Node{
+			{ID: "lever", Kind: KindSwitch},
+			{ID: "later", Kind: KindDelay, Inputs: []string{"lever"}, Duration: 1},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		circuit.Flip("lever")
+		circuit.Update(0.5)
+		Expect(circuit.On("later")).To(BeFalse())
+		circuit.Update(0.5)
+		Expect(circuit.On("later")).To(BeTrue())
+	})
+
+	It("should close a timed gate after its duration", func() {
+		circuit, err := NewCircuit([]Node{
+			{ID: "plate", Kind: KindPlate},
+			{ID: "gate", Kind: KindTimedGate, Inputs: []string{"plate"}, Duration: 2},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		circuit.Set("plate", true)
+		circuit.Update(0.1)
+		circuit.Set("plate", false)
+		circuit.Update(1)
+		Expect(circuit.On("gate")).To(BeTrue())
+		circuit.Update(1)
+		Expect(circuit.On("gate")).To(BeFalse())
+	})
+
+	DescribeTable("should reject invalid circuits",
+		func(nodes []Node, message string) {
+			_, err := NewCircuit(nodes)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown kind", []Node{{ID: "a", Kind: "lamp"}}, "unknown kind"),
+		Entry("duplicate id", []Node{{ID: "a", Kind: KindSwitch}, {ID: "a", Kind: KindPlate}}, "more than once"),
+		Entry("missing input", []Node{{ID: "door", Kind: KindDoor, Inputs: []string{"lever"}}}, "unknown input"),
+		Entry("no inputs", []Node{{ID: "door", Kind: KindDoor}}, "no inputs"),
+		Entry("loop", []Node{
+			{ID: "a", Kind: KindOr, Inputs: []string{"b"}},
+			{ID: "b", Kind: KindOr, Inputs: []string{"a"}},
+		}, "loop"),
+	)
+})
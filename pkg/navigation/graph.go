@@ -0,0 +1,278 @@
+package navigation
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// LinkKind is how an agent gets from one platform to another.
+type LinkKind int
+
+const (
+	// LinkWalk runs across to a platform at the same height next to it.
+	LinkWalk LinkKind = iota
+	// LinkJump jumps over to the platform.
+	LinkJump
+	// LinkFall runs off the edge and drops onto a platform below.
+	LinkFall
+)
+
+func (k LinkKind) String() string {
+	switch k {
+	case LinkWalk:
+		return "walk"
+	case LinkJump:
+		return "jump"
+	case LinkFall:
+		return "fall"
+	}
+	return "unknown"
+}
+
+const (
+	defaultStepHeight = 4.0  // Height difference an agent walks over
+	defaultWalkGap    = 2.0  // Gap between platforms an agent walks over
+	jumpCost          = 32.0 // Extra cost of a jump over running the same distance
+	fallCost          = 8.0  // Extra cost of a fall over running the same distance
+)
+
+// Config describes the agents a graph is built for.
+type Config struct {
+	Arc      physics.JumpArc     // How the agents jump
+	BodySize interfaces.Vector2D // The size of the agents
+	// StepHeight and WalkGap are how much higher or lower and how far away a
+	// platform can be for the agents to walk onto it; zero takes the
+	// defaults.
+	StepHeight float64
+	WalkGap    float64
+}
+
+// Link connects two platforms. Points are where the feet of an agent are:
+// the middle of its body, on top of the platform.
+type Link struct {
+	ID      int
+	Kind    LinkKind
+	From    int // Index of the platform the link starts on
+	To      int // Index of the platform the link ends on
+	Takeoff interfaces.Vector2D
+	Landing interfaces.Vector2D
+	Cost    float64
+}
+
+// Graph is where agents can go between the platforms of a level. The
+// platforms are its nodes and the walk, jump and fall links between them
+// its edges, each checked against the jump of the agents.
+type Graph struct {
+	config    Config
+	platforms []interfaces.Rect
+	links     [][]Link
+	count     int
+}
+
+// NewGraph creates a graph without platforms for agents described by config.
+func NewGraph(config Config) *Graph {
+	if config.StepHeight <= 0 {
+		config.StepHeight = defaultStepHeight
+	}
+	if config.WalkGap <= 0 {
+		config.WalkGap = defaultWalkGap
+	}
+	return &Graph{config: config}
+}
+
+// Config returns the agents the graph is built for.
+func (g *Graph) Config() Config {
+	return g.config
+}
+
+// Rebuild links the given platforms, replacing those of the last build.
+func (g *Graph) Rebuild(platforms []interfaces.Rect) {
+	g.platforms = append([]interfaces.Rect(nil), platforms...)
+	g.links = make([][]Link, len(platforms))
+	g.count = 0
+	for from := range platforms {
+		for to := range platforms {
+			if from == to {
+				continue
+			}
+			if link, ok := g.link(from, to); ok {
+				link.ID = g.count
+				g.count++
+				g.links[from] = append(g.links[from], link)
+			}
+		}
+	}
+}
+
+// Platforms returns the platforms of the last build.
+func (g *Graph) Platforms() []interfaces.Rect {
+	return g.platforms
+}
+
+// LinksFrom returns the links starting on a platform.
+func (g *Graph) LinksFrom(platform int) []Link {
+	if platform < 0 || platform >= len(g.links) {
+		return nil
+	}
+	return g.links[platform]
+}
+
+// Links returns every link of the graph.
+func (g *Graph) Links() []Link {
+	links := make([]Link, 0, g.count)
+	for _, from := range g.links {
+		links = append(links, from...)
+	}
+	return links
+}
+
+// PlatformUnder returns the highest platform at or below point, e.g. the one
+// an agent stands on or falls onto.
+func (g *Graph) PlatformUnder(point interfaces.Vector2D) (int, bool) {
+	found := -1
+	for i, platform := range g.platforms {
+		if point.X < platform.Position.X || point.X > platform.Position.X+platform.Size.X {
+			continue
+		}
+		if platform.Position.Y < point.Y-g.config.StepHeight {
+			continue
+		}
+		if found < 0 || platform.Position.Y < g.platforms[found].Position.Y {
+			found = i
+		}
+	}
+	return found, found >= 0
+}
+
+// standRange returns how far left and right the feet of an agent can go on
+// a platform without falling off.
+func (g *Graph) standRange(platform int) (float64, float64) {
+	rect := g.platforms[platform]
+	half := g.config.BodySize.X / 2
+	if rect.Size.X <= g.config.BodySize.X {
+		middle := rect.Position.X + rect.Size.X/2
+		return middle, middle
+	}
+	return rect.Position.X + half, rect.Position.X + rect.Size.X - half
+}
+
+// link returns the cheapest way from a platform to another: walking when
+// they are side by side, or else jumping or falling.
+func (g *Graph) link(from, to int) (Link, bool) {
+	a, b := g.platforms[from], g.platforms[to]
+	dy := b.Position.Y - a.Position.Y
+	if math.Abs(dy) <= g.config.StepHeight && physics.HorizontalGap(a, b) <= g.config.WalkGap {
+		loA, hiA := g.standRange(from)
+		loB, hiB := g.standRange(to)
+		edge := hiA
+		if b.Position.X+b.Size.X/2 < a.Position.X+a.Size.X/2 {
+			edge = loA
+		}
+		return g.newLink(LinkWalk, from, to, edge, clamp(edge, loB, hiB), 0), true
+	}
+
+	var best Link
+	found := false
+	for _, right := range []bool{true, false} {
+		if link, ok := g.fall(from, to, right); ok && (!found || link.Cost < best.Cost) {
+			best, found = link, true
+		}
+	}
+	if link, ok := g.jump(from, to); ok && (!found || link.Cost < best.Cost) {
+		best, found = link, true
+	}
+	return best, found
+}
+
+// fall returns the link that runs off the right (or left) edge of a
+// platform and drops onto a platform below, which has to stick out past
+// that edge.
+func (g *Graph) fall(from, to int, right bool) (Link, bool) {
+	a, b := g.platforms[from], g.platforms[to]
+	if b.Position.Y-a.Position.Y <= g.config.StepHeight {
+		return Link{}, false
+	}
+	loA, hiA := g.standRange(from)
+	loB, hiB := g.standRange(to)
+	// Where the feet are once the whole body is off the edge
+	edge, off := hiA, a.Position.X+a.Size.X+g.config.BodySize.X/2
+	if !right {
+		edge, off = loA, a.Position.X-g.config.BodySize.X/2
+	}
+	if (right && hiB < off) || (!right && loB > off) {
+		return Link{}, false
+	}
+	landing := clamp(off, loB, hiB)
+	arc := g.config.Arc
+	arc.JumpVelocity = 0
+	reach, ok := arc.Reach(b.Position.Y - a.Position.Y)
+	if !ok || math.Abs(landing-off) > reach {
+		return Link{}, false
+	}
+	return g.newLink(LinkFall, from, to, edge, landing, fallCost), true
+}
+
+// jump returns the link that jumps from a platform across a gap onto
+// another, or up onto one above. Jumping up, the agent takes off beside the
+// platform so as not to hit it from below.
+func (g *Graph) jump(from, to int) (Link, bool) {
+	a, b := g.platforms[from], g.platforms[to]
+	loA, hiA := g.standRange(from)
+	loB, hiB := g.standRange(to)
+	var takeoff, landing float64
+	switch {
+	case physics.HorizontalGap(a, b) > 0:
+		// Across the gap, from the nearest edge to the nearest edge
+		takeoff, landing = hiA, loB
+		if b.Position.X < a.Position.X {
+			takeoff, landing = loA, hiB
+		}
+	case b.Position.Y < a.Position.Y:
+		// Up from beside the platform onto its nearest end
+		clearance := g.config.BodySize.X/2 + g.config.WalkGap
+		takeoff, landing = b.Position.X-clearance, loB
+		if takeoff < loA {
+			takeoff, landing = b.Position.X+b.Size.X+clearance, hiB
+		}
+		if takeoff < loA || takeoff > hiA {
+			return Link{}, false
+		}
+	default:
+		// Below: falling does it
+		return Link{}, false
+	}
+	dy := b.Position.Y - a.Position.Y
+	if -dy > g.config.Arc.MaxHeight() {
+		return Link{}, false
+	}
+	reach, ok := g.config.Arc.Reach(dy)
+	if !ok || math.Abs(landing-takeoff) > reach {
+		return Link{}, false
+	}
+	return g.newLink(LinkJump, from, to, takeoff, landing, jumpCost), true
+}
+
+// newLink creates a link between the feet positions at x on both platforms,
+// costing the distance between them plus extra.
+func (g *Graph) newLink(kind LinkKind, from, to int, takeoffX, landingX, extra float64) Link {
+	takeoff := interfaces.Vector2D{X: takeoffX, Y: g.platforms[from].Position.Y}
+	landing := interfaces.Vector2D{X: landingX, Y: g.platforms[to].Position.Y}
+	return Link{
+		Kind:    kind,
+		From:    from,
+		To:      to,
+		Takeoff: takeoff,
+		Landing: landing,
+		Cost:    distance(takeoff, landing) + extra,
+	}
+}
+
+func clamp(x, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
+
+func distance(a, b interfaces.Vector2D) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
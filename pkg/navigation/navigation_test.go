@@ -0,0 +1,152 @@
+package navigation
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNavigation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Navigation Suite")
+}
+
+// platform returns the rectangle of a platform 20 pixels thick.
+func platform(x, y, width float64) interfaces.Rect {
+	return interfaces.Rect{Position: interfaces.Vector2D{X: x, Y: y}, Size: interfaces.Vector2D{X: width, Y: 20}}
+}
+
+// newTestGraph builds a graph for 20 pixel wide agents which jump up to 100
+// pixels high and 200 pixels far on level ground.
+func newTestGraph(platforms ...interfaces.Rect) *Graph {
+	graph := NewGraph(Config{
+		Arc:      physics.JumpArc{Gravity: 200, JumpVelocity: 200, RunVelocity: 100},
+		BodySize: interfaces.Vector2D{X: 20, Y: 20},
+	})
+	graph.Rebuild(platforms)
+	return graph
+}
+
+// kinds returns the kind of every link from one platform to another.
+func kinds(graph *Graph) map[[2]int]LinkKind {
+	found := make(map[[2]int]LinkKind)
+	for _, link := range graph.Links() {
+		found[[2]int{link.From, link.To}] = link.Kind
+	}
+	return found
+}
+
+var _ = Describe("Graph", func() {
+	It("should walk between platforms side by side", func() {
+		graph := newTestGraph(platform(0, 300, 100), platform(100, 300, 100))
+		Expect(kinds(graph)).To(Equal(map[[2]int]LinkKind{{0, 1}: LinkWalk, {1, 0}: LinkWalk}))
+		Expect(graph.LinksFrom(0)[0].Takeoff).To(Equal(interfaces.Vector2D{X: 90, Y: 300}))
+		Expect(graph.LinksFrom(0)[0].Landing).To(Equal(interfaces.Vector2D{X: 110, Y: 300}))
+	})
+
+	It("should jump gaps and up only within the jump", func() {
+		graph := newTestGraph(
+			platform(0, 300, 100),
+			platform(250, 300, 100), // 150 pixels across
+			platform(600, 300, 100), // 250 pixels across
+			platform(0, 220, 40),    // 80 pixels up, at the left end
+			platform(0, 100, 100),   // 200 pixels up
+		)
+		found := kinds(graph)
+		Expect(found).To(HaveKeyWithValue([2]int{0, 1}, LinkJump))
+		Expect(found).To(HaveKeyWithValue([2]int{1, 0}, LinkJump))
+		Expect(found).NotTo(HaveKey([2]int{1, 2}))
+		Expect(found).To(HaveKeyWithValue([2]int{0, 3}, LinkJump))
+		Expect(found).NotTo(HaveKey([2]int{0, 4}))
+
+		for _, link := range graph.LinksFrom(0) {
+			if link.To == 3 {
+				// Beside the platform above, so as not to bump into it
+				Expect(link.Takeoff.X).To(Equal(52.0))
+				Expect(link.Landing.X).To(Equal(30.0))
+			}
+		}
+	})
+
+	It("should fall off edges onto platforms below sticking out", func() {
+		graph := newTestGraph(
+			platform(100, 100, 100),
+			platform(0, 300, 400),  // Wider, below
+			platform(120, 200, 60), // Right under the first one
+		)
+		found := kinds(graph)
+		Expect(found).To(HaveKeyWithValue([2]int{0, 1}, LinkFall))
+		Expect(found).NotTo(HaveKey([2]int{0, 2}))
+		Expect(found).To(HaveKeyWithValue([2]int{2, 1}, LinkFall))
+	})
+
+	It("should tell which platform a point is over", func() {
+		graph := newTestGraph(platform(0, 300, 100), platform(0, 200, 50))
+		under, ok := graph.PlatformUnder(interfaces.Vector2D{X: 20, Y: 150})
+		Expect(ok).To(BeTrue())
+		Expect(under).To(Equal(1))
+		under, ok = graph.PlatformUnder(interfaces.Vector2D{X: 80, Y: 150})
+		Expect(ok).To(BeTrue())
+		Expect(under).To(Equal(0))
+		_, ok = graph.PlatformUnder(interfaces.Vector2D{X: 200, Y: 150})
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("FindPath", func() {
+	It("should plan the runs and jumps up a staircase", func() {
+		graph := newTestGraph(
+			platform(0, 300, 100),
+			platform(150, 230, 100),
+			platform(300, 160, 100),
+			platform(600, 160, 100), // Out of reach
+		)
+		plan, ok := graph.FindPath(interfaces.Vector2D{X: 20, Y: 300}, interfaces.Vector2D{X: 380, Y: 100})
+		Expect(ok).To(BeTrue())
+		Expect(plan.Steps).To(Equal([]Step{
+			{Kind: StepMove, To: interfaces.Vector2D{X: 90, Y: 300}},
+			{Kind: StepJump, At: interfaces.Vector2D{X: 90, Y: 300}, To: interfaces.Vector2D{X: 160, Y: 230}},
+			{Kind: StepMove, To: interfaces.Vector2D{X: 240, Y: 230}},
+			{Kind: StepJump, At: interfaces.Vector2D{X: 240, Y: 230}, To: interfaces.Vector2D{X: 310, Y: 160}},
+			{Kind: StepMove, To: interfaces.Vector2D{X: 380, Y: 160}},
+		}))
+		Expect(plan.Goal()).To(Equal(interfaces.Vector2D{X: 380, Y: 160}))
+
+		_, ok = graph.FindPath(interfaces.Vector2D{X: 20, Y: 300}, interfaces.Vector2D{X: 650, Y: 100})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should rather walk around than jump", func() {
+		graph := newTestGraph(
+			platform(0, 300, 100),
+			platform(100, 300, 100),
+			platform(200, 300, 100),
+			platform(0, 240, 300), // Right above, reached by jumping from the left end
+		)
+		plan, ok := graph.FindPath(interfaces.Vector2D{X: 20, Y: 300}, interfaces.Vector2D{X: 280, Y: 300})
+		Expect(ok).To(BeTrue())
+		for _, link := range plan.Links {
+			Expect(link.Kind).To(Equal(LinkWalk))
+		}
+	})
+
+	It("should lead an agent through its steps", func() {
+		graph := newTestGraph(platform(0, 300, 100), platform(150, 230, 100))
+		plan, ok := graph.FindPath(interfaces.Vector2D{X: 20, Y: 300}, interfaces.Vector2D{X: 200, Y: 230})
+		Expect(ok).To(BeTrue())
+
+		x, jump, ok := plan.Next(interfaces.Vector2D{X: 20, Y: 300}, true, 2)
+		Expect([]any{x, jump, ok}).To(Equal([]any{90.0, false, true}))
+		x, jump, ok = plan.Next(interfaces.Vector2D{X: 89, Y: 300}, true, 2)
+		Expect([]any{x, jump, ok}).To(Equal([]any{160.0, true, true}))
+		x, jump, ok = plan.Next(interfaces.Vector2D{X: 120, Y: 250}, false, 2)
+		Expect([]any{x, jump, ok}).To(Equal([]any{160.0, false, true}))
+		x, jump, ok = plan.Next(interfaces.Vector2D{X: 160, Y: 230}, true, 2)
+		Expect([]any{x, jump, ok}).To(Equal([]any{200.0, false, true}))
+		_, _, ok = plan.Next(interfaces.Vector2D{X: 200, Y: 230}, true, 2)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,191 @@
+package navigation
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// StepKind is what an agent does in a step of a plan.
+type StepKind int
+
+const (
+	// StepMove runs to a point, off an edge if need be.
+	StepMove StepKind = iota
+	// StepJump jumps at a point toward another.
+	StepJump
+)
+
+func (k StepKind) String() string {
+	if k == StepJump {
+		return "jump"
+	}
+	return "move"
+}
+
+// Step is one thing an agent does to follow a plan, in terms of its input:
+// where to run to, and where to jump. Points are where the feet of the agent
+// are.
+type Step struct {
+	Kind StepKind
+	At   interfaces.Vector2D // Where a jump takes off
+	To   interfaces.Vector2D // Where a move, or a jump, ends
+}
+
+// Plan is the way to a point found by FindPath.
+type Plan struct {
+	Steps []Step
+	Links []Link
+	Cost  float64
+}
+
+// FindPath finds the cheapest way from one point to another with A*. The
+// points are where the feet of the agent are, or any point above the
+// platform they are on. It reports false when either point is not over a
+// platform or there is no way between them.
+func (g *Graph) FindPath(from, to interfaces.Vector2D) (*Plan, bool) {
+	start, ok := g.PlatformUnder(from)
+	if !ok {
+		return nil, false
+	}
+	goalPlatform, ok := g.PlatformUnder(to)
+	if !ok {
+		return nil, false
+	}
+	lo, hi := g.standRange(goalPlatform)
+	goal := interfaces.Vector2D{X: clamp(to.X, lo, hi), Y: g.platforms[goalPlatform].Position.Y}
+	lo, hi = g.standRange(start)
+	origin := interfaces.Vector2D{X: clamp(from.X, lo, hi), Y: g.platforms[start].Position.Y}
+
+	// Search states are where the agent arrives on a platform: at the start,
+	// at the end of a link or at the goal, keyed by link ID, startKey or
+	// goalKey.
+	const startKey, goalKey = -1, -2
+	states := []searchState{{key: startKey, platform: start, position: origin, previous: -1}}
+	best := map[int]float64{startKey: 0}
+	open := &searchQueue{states: &states}
+	heap.Push(open, 0)
+	for open.Len() > 0 {
+		index := heap.Pop(open).(int)
+		current := states[index]
+		if current.cost > best[current.key] {
+			continue
+		}
+		if current.key == goalKey {
+			return g.plan(states, current), true
+		}
+		push := func(state searchState) {
+			if cost, seen := best[state.key]; seen && cost <= state.cost {
+				return
+			}
+			best[state.key] = state.cost
+			state.estimate = state.cost + distance(state.position, goal)
+			states = append(states, state)
+			heap.Push(open, len(states)-1)
+		}
+		if current.platform == goalPlatform {
+			push(searchState{key: goalKey, platform: goalPlatform, position: goal, cost: current.cost + math.Abs(goal.X-current.position.X), previous: index})
+		}
+		for _, link := range g.links[current.platform] {
+			cost := current.cost + math.Abs(link.Takeoff.X-current.position.X) + link.Cost
+			push(searchState{key: link.ID, platform: link.To, position: link.Landing, cost: cost, link: link, previous: index})
+		}
+	}
+	return nil, false
+}
+
+// plan turns the states leading to the goal into the steps of a plan.
+func (g *Graph) plan(states []searchState, goal searchState) *Plan {
+	var links []Link
+	for state := goal; state.previous >= 0; state = states[state.previous] {
+		if state.key >= 0 {
+			links = append(links, state.link)
+		}
+	}
+	for i, j := 0, len(links)-1; i < j; i, j = i+1, j-1 {
+		links[i], links[j] = links[j], links[i]
+	}
+
+	plan := &Plan{Links: links, Cost: goal.cost}
+	for _, link := range links {
+		plan.move(link.Takeoff)
+		if link.Kind == LinkJump {
+			plan.Steps = append(plan.Steps, Step{Kind: StepJump, At: link.Takeoff, To: link.Landing})
+		} else {
+			plan.Steps = append(plan.Steps, Step{Kind: StepMove, To: link.Landing})
+		}
+	}
+	plan.move(goal.position)
+	return plan
+}
+
+// move adds a step running to point, unless the last step ends there.
+func (p *Plan) move(point interfaces.Vector2D) {
+	if len(p.Steps) > 0 && p.Steps[len(p.Steps)-1].To == point {
+		return
+	}
+	p.Steps = append(p.Steps, Step{Kind: StepMove, To: point})
+}
+
+// Next returns where an agent with its feet at feet runs to along the plan,
+// and whether it jumps now, dropping the steps it is done with. An agent
+// within tolerance of a point is at it. It reports false once the plan is
+// done.
+func (p *Plan) Next(feet interfaces.Vector2D, onGround bool, tolerance float64) (x float64, jump bool, ok bool) {
+	for len(p.Steps) > 0 {
+		step := p.Steps[0]
+		switch step.Kind {
+		case StepMove:
+			if math.Abs(feet.X-step.To.X) > tolerance {
+				return step.To.X, false, true
+			}
+			p.Steps = p.Steps[1:]
+		case StepJump:
+			if math.Abs(feet.X-step.At.X) > tolerance || !onGround {
+				return step.At.X, false, true
+			}
+			// Steer toward the landing while in the air
+			p.Steps[0] = Step{Kind: StepMove, To: step.To}
+			return step.To.X, true, true
+		}
+	}
+	return feet.X, false, false
+}
+
+// Goal returns where the plan ends.
+func (p *Plan) Goal() interfaces.Vector2D {
+	if len(p.Steps) == 0 {
+		return interfaces.Vector2D{}
+	}
+	return p.Steps[len(p.Steps)-1].To
+}
+
+// searchState is a point an agent arrives at during the search.
+type searchState struct {
+	key      int
+	platform int
+	position interfaces.Vector2D
+	cost     float64
+	estimate float64 // cost plus the straight distance left to the goal
+	link     Link    // The link the agent arrived by
+	previous int     // Index of the state the agent came from
+}
+
+// searchQueue orders the indices of states by their estimate.
+type searchQueue struct {
+	states  *[]searchState
+	indices []int
+}
+
+func (q *searchQueue) Len() int { return len(q.indices) }
+func (q *searchQueue) Less(i, j int) bool {
+	return (*q.states)[q.indices[i]].estimate < (*q.states)[q.indices[j]].estimate
+}
+func (q *searchQueue) Swap(i, j int) { q.indices[i], q.indices[j] = q.indices[j], q.indices[i] }
+func (q *searchQueue) Push(x any)    { q.indices = append(q.indices, x.(int)) }
+func (q *searchQueue) Pop() any {
+	last := q.indices[len(q.indices)-1]
+	q.indices = q.indices[:len(q.indices)-1]
+	return last
+}
@@ -0,0 +1,626 @@
+package pet
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// What the pet is doing, e.g. to pick its animation.
+const (
+	StateIdle   = "idle"
+	StateFollow = "follow"
+	StateWander = "wander"
+	StateStay   = "stay"
+	StateGoTo   = "goTo"
+	StateFetch  = "fetch"
+	StateCarry  = "carry"
+	StateAttack = "attack"
+)
+
+// CommandKind is an order the player gives the pet.
+type CommandKind int
+
+const (
+	// CommandFollow follows the player around, wandering about while the
+	// player stands still. The pet does this unless told otherwise.
+	CommandFollow CommandKind = iota
+	// CommandStay stays where the pet is.
+	CommandStay
+	// CommandGoTo goes to a point and stays there.
+	CommandGoTo
+	// CommandFetch picks up the nearest item and brings it to the player.
+	CommandFetch
+	// CommandAttack runs at the nearest enemy and bites it.
+	CommandAttack
+)
+
+func (k CommandKind) String() string {
+	switch k {
+	case CommandFollow:
+		return "follow"
+	case CommandStay:
+		return "stay"
+	case CommandGoTo:
+		return "go to"
+	case CommandFetch:
+		return "fetch"
+	case CommandAttack:
+		return "attack"
+	}
+	return "unknown"
+}
+
+// Command is an order for the pet.
+type Command struct {
+	Kind  CommandKind
+	Point interfaces.Vector2D // Where the feet of the pet go on CommandGoTo
+}
+
+// ActionKind is something the pet does to the level, which the game carries
+// out.
+type ActionKind int
+
+const (
+	// ActionNone does nothing.
+	ActionNone ActionKind = iota
+	// ActionPick picks up the item at Target.
+	ActionPick
+	// ActionDeliver hands the item the pet carries over to the player.
+	ActionDeliver
+	// ActionBite bites the enemy at Target.
+	ActionBite
+	// ActionSync jumped to the player, at Target.
+	ActionSync
+)
+
+// Action is what the pet did in an update.
+type Action struct {
+	Kind   ActionKind
+	Target interfaces.Rect
+}
+
+const (
+	defaultRunVelocity    = 100.0 // Running speed in pixels per second
+	defaultJumpVelocity   = 80.0  // Take-off speed of a jump
+	defaultAcceleration   = 400.0 // Change of speed in pixels per second squared
+	defaultFollowDistance = 40.0  // How far behind the player the pet keeps
+	defaultSlowRadius     = 60.0  // Distance to the target within which the pet slows down
+	defaultReach          = 320.0 // Distance within which the pet finds items and enemies
+	defaultWanderRadius   = 48.0  // How far from its spot an idle pet wanders
+	defaultIdleDelay      = 2.0   // Seconds the player stands still before the pet wanders
+	defaultCollectRadius  = 160.0 // Distance within which a continuously deploying pet fetches items
+	defaultGuardRadius    = 120.0 // Distance to the player within which a guardian pet attacks enemies
+	defaultSyncDistance   = 240.0 // How far from the player the pet is left behind
+	defaultSyncDelay      = 1.5   // Seconds left behind before a syncing pet jumps to the player
+	defaultWarnRadius     = 96.0  // Distance to the player within which the pet warns about hazards
+	arriveDistance        = 4.0   // Gap to a point the pet stops at
+	climbHeight           = 20.0  // How far above the pet a target has to be to jump up to it
+	groundTolerance       = 2.0   // Gap between feet and a platform that still counts as standing on it
+	minLookAhead          = 8.0   // How far the pet looks ahead besides the distance it needs to stop
+	touchDistance         = 4.0   // Gap to an item, enemy or the player that counts as touching it
+	trackDistance         = 32.0  // How far an item or enemy moves in a frame and is still the same one
+	biteInterval          = 0.5   // Seconds between bites
+	wanderJitter          = 3.0   // How fast the heading of a wandering pet changes, in radians per second
+	replanInterval        = 0.5   // Seconds between finding the way to a target again
+	replanDistance        = 32.0  // How far a target moves before the way to it is found again
+	assistInterval        = 0.5   // Seconds between looking around for items and enemies unasked
+)
+
+// Configuration holds the configurable settings for the Pet. Fields left at
+// zero take the defaults.
+type Configuration struct {
+	ImageScale     float64
+	RunVelocity    float64
+	JumpVelocity   float64
+	Gravity        float64  // Gravity of the physics engine, to tell how far a jump goes
+	Acceleration   float64  // How quickly the pet changes speed, in pixels per second squared
+	FollowDistance float64  // How far behind the player the pet keeps
+	SlowRadius     float64  // Distance to its target within which the pet slows down
+	Reach          float64  // Distance within which the pet finds items to fetch and enemies to attack
+	WanderRadius   float64  // How far from the player an idle pet wanders
+	IdleDelay      float64  // Seconds the player stands still before the pet wanders about
+	CollectRadius  float64  // Distance within which the pet fetches items with Continuous Deployment
+	GuardRadius    float64  // Distance to the player within which the pet attacks enemies with Repository Guardian
+	SyncDistance   float64  // How far from the player the pet jumps back to it with Sync Assistance
+	SyncDelay      float64  // Seconds the pet is left that far behind before it jumps back
+	WarnRadius     float64  // Distance to the player within which the pet warns about hazards
+	Abilities      []string // Abilities the pet gains as it levels up, in order; nil takes DefaultAbilities
+	Thresholds     []int    // Items brought to the player for each level; nil takes DefaultThresholds
+	Seed           int64    // Seeds where the pet wanders
+}
+
+// withDefaults returns the configuration with the defaults in the fields
+// left at zero.
+func (c Configuration) withDefaults() Configuration {
+	defaults := []struct {
+		field *float64
+		value float64
+	}{
+		{&c.RunVelocity, defaultRunVelocity},
+		{&c.JumpVelocity, defaultJumpVelocity},
+		{&c.Acceleration, defaultAcceleration},
+		{&c.FollowDistance, defaultFollowDistance},
+		{&c.SlowRadius, defaultSlowRadius},
+		{&c.Reach, defaultReach},
+		{&c.WanderRadius, defaultWanderRadius},
+		{&c.IdleDelay, defaultIdleDelay},
+		{&c.CollectRadius, defaultCollectRadius},
+		{&c.GuardRadius, defaultGuardRadius},
+		{&c.SyncDistance, defaultSyncDistance},
+		{&c.SyncDelay, defaultSyncDelay},
+		{&c.WarnRadius, defaultWarnRadius},
+	}
+	for _, d := range defaults {
+		if *d.field <= 0 {
+			*d.field = d.value
+		}
+	}
+	return c
+}
+
+// Navigation returns how a navigation graph is built for a pet of the given
+// size tuned by the configuration.
+func (c Configuration) Navigation(size interfaces.Vector2D) navigation.Config {
+	c = c.withDefaults()
+	return navigation.Config{
+		Arc:      physics.NewJumpArc(c.Gravity, c.JumpVelocity, c.RunVelocity),
+		BodySize: size,
+	}
+}
+
+// Brain decides where the pet goes and what it does, and steers its body
+// there: it only sets how fast the body runs and when it jumps, and the
+// physics engine moves it. As the pet grows it also helps the player
+// unasked, with the abilities it has gained.
+type Brain struct {
+	body     *physics.RigidBody
+	config   Configuration
+	senses   interfaces.PetSenses
+	growth   *Growth
+	wander   *Wander
+	graph    *navigation.Graph
+	plan     *navigation.Plan
+	replanIn float64
+	command  Command
+	state    string
+	target   interfaces.Vector2D // Where the feet of the pet go
+	prey     interfaces.Rect     // The item fetched or the enemy attacked
+	carrying bool
+	owner    interfaces.Vector2D // Where the middle of the player was last
+	facing   float64             // Which way the player went last
+	still    float64             // Seconds the player has not moved
+	biteIn   float64
+	assistIn float64
+	apart    float64         // Seconds the pet has been left far behind the player
+	warning  interfaces.Rect // The hazard the pet warns about
+	warned   bool
+}
+
+// NewBrain creates the brain of a pet with body, which knows about the level
+// through senses.
+func NewBrain(body *physics.RigidBody, config Configuration, senses interfaces.PetSenses) *Brain {
+	config = config.withDefaults()
+	return &Brain{
+		body:   body,
+		config: config,
+		senses: senses,
+		growth: NewGrowth(config.Abilities, config.Thresholds),
+		wander: NewWander(config.WanderRadius, config.RunVelocity/3, wanderJitter, config.Seed),
+		state:  StateIdle,
+		target: feetOf(physics.BodyRect(body)),
+		facing: 1,
+	}
+}
+
+// SetGraph lets the pet find its way to targets on other platforms.
+func (b *Brain) SetGraph(graph *navigation.Graph) {
+	b.graph = graph
+	b.plan = nil
+}
+
+// Plan returns the way the pet is following to its target, or nil.
+func (b *Brain) Plan() *navigation.Plan {
+	return b.plan
+}
+
+// State returns what the pet is doing.
+func (b *Brain) State() string {
+	return b.state
+}
+
+// Target returns where the feet of the pet are going.
+func (b *Brain) Target() interfaces.Vector2D {
+	return b.target
+}
+
+// Carrying reports whether the pet carries an item to the player.
+func (b *Brain) Carrying() bool {
+	return b.carrying
+}
+
+// Growth returns how far the pet has grown and which abilities it has.
+func (b *Brain) Growth() *Growth {
+	return b.growth
+}
+
+// Warning returns the hazard nearest the player the pet warns about, and
+// reports false when there is none near.
+func (b *Brain) Warning() (interfaces.Rect, bool) {
+	return b.warning, b.warned
+}
+
+// Command gives the pet an order, which it follows until it is done or told
+// otherwise; it drops what it carries. Going to a point goes to the platform
+// under it. Fetching and attacking go for the nearest item or enemy within
+// reach, and report false when there is none.
+func (b *Brain) Command(command Command) bool {
+	switch command.Kind {
+	case CommandStay:
+		command.Point = feetOf(physics.BodyRect(b.body))
+	case CommandGoTo:
+		command.Point = b.ground(command.Point)
+	case CommandFetch, CommandAttack:
+		rects := b.senses.Items(center(physics.BodyRect(b.body)), b.config.Reach)
+		if command.Kind == CommandAttack {
+			rects = b.senses.Enemies()
+		}
+		prey, ok := nearest(rects, center(physics.BodyRect(b.body)), b.config.Reach)
+		if !ok {
+			return false
+		}
+		b.prey = prey
+	}
+	b.command = command
+	b.carrying = false
+	b.plan = nil
+	return true
+}
+
+// Reset forgets the way the pet was following, e.g. after it was moved.
+func (b *Brain) Reset() {
+	b.plan, b.replanIn, b.apart = nil, 0, 0
+}
+
+// Update follows the current command for a frame, given the area of the
+// player, and returns what the pet did to the level.
+func (b *Brain) Update(deltaTime float64, owner interfaces.Rect) Action {
+	b.watch(owner, deltaTime)
+	b.warn(owner)
+	b.assist(owner, deltaTime)
+	b.biteIn -= deltaTime
+
+	var action Action
+	switch b.command.Kind {
+	case CommandStay:
+		b.state, b.target = StateStay, b.command.Point
+	case CommandGoTo:
+		b.state, b.target = StateGoTo, b.command.Point
+		if b.arrived() {
+			b.command.Kind = CommandStay
+			b.state = StateStay
+		}
+	case CommandFetch:
+		action = b.fetch(owner)
+	case CommandAttack:
+		action = b.attack()
+	}
+	// Done with the command, or lost what it went after
+	if b.command.Kind == CommandFollow {
+		b.follow(owner)
+	}
+	if action.Kind == ActionNone && b.sync(owner, deltaTime) {
+		return Action{Kind: ActionSync, Target: physics.BodyRect(b.body)}
+	}
+
+	if b.state == StateWander {
+		b.roam(deltaTime)
+	} else {
+		b.move(deltaTime)
+	}
+	return action
+}
+
+// watch keeps track of which way the player goes and how long it stands
+// still.
+func (b *Brain) watch(owner interfaces.Rect, deltaTime float64) {
+	middle := center(owner)
+	dx := middle.X - b.owner.X
+	switch {
+	case math.Abs(dx) > 0.5:
+		b.facing = math.Copysign(1, dx)
+		b.still = 0
+	case math.Abs(middle.Y-b.owner.Y) > 0.5:
+		b.still = 0
+	default:
+		b.still += deltaTime
+	}
+	b.owner = middle
+}
+
+// warn finds the hazard nearest the player within the warning radius.
+func (b *Brain) warn(owner interfaces.Rect) {
+	b.warning, b.warned = interfaces.Rect{}, false
+	closest := b.config.WarnRadius
+	for _, hazard := range b.senses.Hazards() {
+		if d := gap(owner, hazard); d <= closest {
+			b.warning, b.warned, closest = hazard, true, d
+		}
+	}
+}
+
+// assist puts the abilities of the pet to use while it follows the player,
+// looking around every so often: a guardian attacks enemies near the player,
+// and a continuously deploying pet fetches items near itself.
+func (b *Brain) assist(owner interfaces.Rect, deltaTime float64) {
+	b.assistIn -= deltaTime
+	if b.command.Kind != CommandFollow || b.assistIn > 0 {
+		return
+	}
+	b.assistIn = assistInterval
+	if b.growth.Has(AbilityRepositoryGuardian) {
+		if enemy, ok := nearest(b.senses.Enemies(), center(owner), b.config.GuardRadius); ok {
+			b.prey, b.command, b.plan = enemy, Command{Kind: CommandAttack}, nil
+			return
+		}
+	}
+	if b.growth.Has(AbilityContinuousDeployment) {
+		middle := center(physics.BodyRect(b.body))
+		if item, ok := nearest(b.senses.Items(middle, b.config.CollectRadius), middle, b.config.CollectRadius); ok {
+			b.prey, b.command, b.plan = item, Command{Kind: CommandFetch}, nil
+		}
+	}
+}
+
+// sync jumps behind the player once the pet has been left far behind it for
+// a while, e.g. on another platform, and reports whether it did.
+func (b *Brain) sync(owner interfaces.Rect, deltaTime float64) bool {
+	body := physics.BodyRect(b.body)
+	spot := Behind(owner, b.facing, b.config.FollowDistance)
+	heading := b.state == StateFollow || b.state == StateCarry
+	if !heading || distance(feetOf(body), spot) <= b.config.SyncDistance || !b.growth.Has(AbilitySyncAssistance) {
+		b.apart = 0
+		return false
+	}
+	b.apart += deltaTime
+	if b.apart < b.config.SyncDelay {
+		return false
+	}
+	b.body.Teleport(interfaces.Vector2D{X: spot.X - body.Size.X/2, Y: spot.Y - body.Size.Y})
+	b.Reset()
+	return true
+}
+
+// follow keeps behind the player, and wanders about its spot once the player
+// has stood still for a while.
+func (b *Brain) follow(owner interfaces.Rect) {
+	b.target = Behind(owner, b.facing, b.config.FollowDistance)
+	feet := feetOf(physics.BodyRect(b.body))
+	near := math.Abs(feet.X-b.target.X) <= b.config.WanderRadius && math.Abs(feet.Y-b.target.Y) <= climbHeight
+	switch {
+	case near && b.still >= b.config.IdleDelay:
+		b.state = StateWander
+	case b.arrived():
+		b.state = StateIdle
+	default:
+		b.state = StateFollow
+	}
+}
+
+// fetch runs to the item and picks it up, then brings it to the player.
+func (b *Brain) fetch(owner interfaces.Rect) Action {
+	body := physics.BodyRect(b.body)
+	if b.carrying {
+		b.state, b.target = StateCarry, feetOf(owner)
+		if !touching(body, owner) {
+			return Action{}
+		}
+		b.carrying = false
+		b.command = Command{Kind: CommandFollow}
+		b.growth.Collect()
+		return Action{Kind: ActionDeliver, Target: owner}
+	}
+	item, ok := nearest(b.senses.Items(center(b.prey), trackDistance), center(b.prey), trackDistance)
+	if !ok {
+		b.command = Command{Kind: CommandFollow}
+		return Action{}
+	}
+	b.prey = item
+	b.state, b.target = StateFetch, feetOf(item)
+	if !touching(body, item) {
+		return Action{}
+	}
+	b.carrying = true
+	b.state, b.target = StateCarry, feetOf(owner)
+	b.plan = nil
+	return Action{Kind: ActionPick, Target: item}
+}
+
+// attack runs at the enemy and bites it every so often while touching it.
+func (b *Brain) attack() Action {
+	enemy, ok := nearest(b.senses.Enemies(), center(b.prey), trackDistance)
+	if !ok {
+		b.command = Command{Kind: CommandFollow}
+		return Action{}
+	}
+	b.prey = enemy
+	b.state, b.target = StateAttack, feetOf(enemy)
+	if !touching(physics.BodyRect(b.body), enemy) || b.biteIn > 0 {
+		return Action{}
+	}
+	b.biteIn = biteInterval
+	return Action{Kind: ActionBite, Target: enemy}
+}
+
+// ground returns the point on top of the highest platform at or below
+// point, or point itself when there is none.
+func (b *Brain) ground(point interfaces.Vector2D) interfaces.Vector2D {
+	top := math.Inf(1)
+	for _, platform := range b.senses.Platforms() {
+		if point.X < platform.Position.X || point.X > platform.Position.X+platform.Size.X || platform.Position.Y < point.Y-groundTolerance {
+			continue
+		}
+		top = math.Min(top, platform.Position.Y)
+	}
+	if math.IsInf(top, 1) {
+		return point
+	}
+	return interfaces.Vector2D{X: point.X, Y: top}
+}
+
+// arrived reports whether the feet of the pet are at the target.
+func (b *Brain) arrived() bool {
+	feet := feetOf(physics.BodyRect(b.body))
+	return math.Abs(feet.X-b.target.X) <= arriveDistance && math.Abs(feet.Y-b.target.Y) <= climbHeight
+}
+
+// move runs to the target, following the way through the graph when it is
+// on another platform. On its own platform the pet slows down as it
+// arrives, jumps over low obstacles, stops at high ones and at ledges it
+// would fall off for nothing, and jumps up to a target right above it.
+func (b *Brain) move(deltaTime float64) {
+	body := physics.BodyRect(b.body)
+	feet := feetOf(body)
+	if x, jump, ok := b.navigate(deltaTime); ok {
+		// Full speed along the way, for the jumps to reach
+		b.steer(Arrive(feet.X, x, b.config.RunVelocity, arriveDistance, arriveDistance), deltaTime)
+		if jump {
+			b.jump()
+		}
+		return
+	}
+
+	desired := Arrive(feet.X, b.target.X, b.config.RunVelocity, b.config.SlowRadius, arriveDistance)
+	jump := false
+	if b.body.OnGround && desired != 0 {
+		ahead := b.lookAhead(body, desired)
+		below := b.target.Y-feet.Y > climbHeight && ahead.Drop <= b.target.Y-feet.Y+groundTolerance
+		switch {
+		case ahead.Jump:
+			jump = true
+		case ahead.Blocked, ahead.Ledge && !below:
+			desired = 0
+		}
+	}
+	if feet.Y-b.target.Y > climbHeight && math.Abs(feet.X-b.target.X) <= body.Size.X {
+		jump = true
+	}
+	b.steer(desired, deltaTime)
+	if jump {
+		b.jump()
+	}
+}
+
+// roam wanders about the target, turning around at obstacles and ledges.
+func (b *Brain) roam(deltaTime float64) {
+	b.plan = nil
+	body := physics.BodyRect(b.body)
+	desired := b.wander.Velocity(feetOf(body).X, b.target.X, deltaTime)
+	if b.body.OnGround {
+		if ahead := b.lookAhead(body, desired); ahead.Blocked || ahead.Ledge {
+			b.wander.Turn()
+			desired = 0
+		}
+	}
+	b.steer(desired, deltaTime)
+}
+
+// lookAhead checks what lies ahead of the pet running toward direction, as
+// far as it needs to stop.
+func (b *Brain) lookAhead(body interfaces.Rect, direction float64) Avoidance {
+	platforms := b.senses.Platforms()
+	solids := append(append([]interfaces.Rect(nil), b.senses.Obstacles()...), platforms...)
+	speed := b.body.Velocity.X
+	distance := minLookAhead + speed*speed/(2*b.config.Acceleration)
+	jumpHeight := physics.NewJumpArc(b.config.Gravity, b.config.JumpVelocity, b.config.RunVelocity).MaxHeight()
+	return Avoid(body, direction, distance, jumpHeight-groundTolerance, solids, platforms)
+}
+
+// navigate follows the way through the graph to a target on another
+// platform, finding it again every so often or once the target moves away
+// from its end. It reports false when there is no graph, the target is on
+// the same platform or there is no way to it.
+func (b *Brain) navigate(deltaTime float64) (float64, bool, bool) {
+	if b.graph == nil {
+		return 0, false, false
+	}
+	feet := feetOf(physics.BodyRect(b.body))
+	b.replanIn -= deltaTime
+	moved := b.plan != nil && distance(b.plan.Goal(), b.target) > replanDistance
+	if b.body.OnGround && (b.replanIn <= 0 || moved) {
+		b.replanIn = replanInterval
+		b.plan = nil
+		if plan, ok := b.graph.FindPath(feet, b.target); ok && len(plan.Links) > 0 {
+			b.plan = plan
+		}
+	}
+	if b.plan == nil {
+		return 0, false, false
+	}
+	x, jump, ok := b.plan.Next(feet, b.body.OnGround, arriveDistance)
+	if !ok {
+		b.plan = nil
+		return 0, false, false
+	}
+	return x, jump, true
+}
+
+// steer changes the running speed toward desired.
+func (b *Brain) steer(desired, deltaTime float64) {
+	b.body.Velocity.X = Approach(b.body.Velocity.X, desired, b.config.Acceleration, deltaTime)
+}
+
+// jump takes off from the ground.
+func (b *Brain) jump() {
+	if !b.body.OnGround {
+		return
+	}
+	b.body.Velocity.Y = -b.config.JumpVelocity
+	b.body.OnGround = false
+}
+
+// nearest returns the rectangle whose middle is closest to point, within
+// radius of it.
+func nearest(rects []interfaces.Rect, point interfaces.Vector2D, radius float64) (interfaces.Rect, bool) {
+	best, found := interfaces.Rect{}, false
+	for _, rect := range rects {
+		d := distance(center(rect), point)
+		if d <= radius && (!found || d < distance(center(best), point)) {
+			best, found = rect, true
+		}
+	}
+	return best, found
+}
+
+// touching reports whether two rectangles are within touchDistance of each
+// other; the physics engine keeps solid bodies from overlapping.
+func touching(a, b interfaces.Rect) bool {
+	a.Position.X -= touchDistance
+	a.Position.Y -= touchDistance
+	a.Size.X += 2 * touchDistance
+	a.Size.Y += 2 * touchDistance
+	return overlaps(a, b)
+}
+
+// feetOf returns the middle of the bottom of rect.
+func feetOf(rect interfaces.Rect) interfaces.Vector2D {
+	return interfaces.Vector2D{X: rect.Position.X + rect.Size.X/2, Y: rect.Position.Y + rect.Size.Y}
+}
+
+// gap returns the distance between the closest points of two rectangles, 0
+// when they overlap.
+func gap(a, b interfaces.Rect) float64 {
+	dx := math.Max(0, math.Max(b.Position.X-(a.Position.X+a.Size.X), a.Position.X-(b.Position.X+b.Size.X)))
+	dy := math.Max(0, math.Max(b.Position.Y-(a.Position.Y+a.Size.Y), a.Position.Y-(b.Position.Y+b.Size.Y)))
+	return math.Hypot(dx, dy)
+}
+
+func center(rect interfaces.Rect) interfaces.Vector2D {
+	return interfaces.Vector2D{X: rect.Position.X + rect.Size.X/2, Y: rect.Position.Y + rect.Size.Y/2}
+}
+
+func distance(a, b interfaces.Vector2D) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
@@ -0,0 +1,104 @@
+package pet
+
+import "sync"
+
+// ItemName is the item the pet is, whose abilities it gains as it grows.
+const ItemName = "ArgoCD Pet"
+
+// Abilities of the ArgoCD Pet item, and what the pet does once it has them.
+const (
+	// AbilityContinuousDeployment fetches items near the pet without being
+	// told to.
+	AbilityContinuousDeployment = "Continuous Deployment"
+	// AbilitySyncAssistance jumps back to the player once it is left far
+	// behind.
+	AbilitySyncAssistance = "Sync Assistance"
+	// AbilityRepositoryGuardian attacks enemies that come near the player.
+	AbilityRepositoryGuardian = "Repository Guardian"
+)
+
+// DefaultAbilities are the abilities of the ArgoCD Pet item, in the order the
+// pet gains them.
+var DefaultAbilities = []string{AbilityContinuousDeployment, AbilitySyncAssistance, AbilityRepositoryGuardian}
+
+// DefaultThresholds are how many items the pet brings to the player before it
+// reaches level 2, 3 and 4.
+var DefaultThresholds = []int{1, 3, 6}
+
+// Growth counts the items the pet brought to the player and levels it up,
+// one ability at a time. It is safe to use from event handlers.
+type Growth struct {
+	mu         sync.Mutex
+	abilities  []string
+	thresholds []int
+	items      int
+	unlocked   int
+}
+
+// NewGrowth creates the growth of a pet that gains abilities in order, each
+// once it has brought the number of items of thresholds at the same index.
+// Nil abilities or thresholds take the defaults.
+func NewGrowth(abilities []string, thresholds []int) *Growth {
+	if abilities == nil {
+		abilities = DefaultAbilities
+	}
+	if thresholds == nil {
+		thresholds = DefaultThresholds
+	}
+	return &Growth{abilities: abilities, thresholds: thresholds}
+}
+
+// Collect counts an item brought to the player, and reports whether the pet
+// leveled up with it.
+func (g *Growth) Collect() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.items++
+	leveled := false
+	for g.unlocked < len(g.abilities) && g.unlocked < len(g.thresholds) && g.items >= g.thresholds[g.unlocked] {
+		g.unlocked++
+		leveled = true
+	}
+	return leveled
+}
+
+// UnlockAll gains every ability at once, and reports whether the pet leveled
+// up with it.
+func (g *Growth) UnlockAll() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	leveled := g.unlocked < len(g.abilities)
+	g.unlocked = len(g.abilities)
+	return leveled
+}
+
+// Level returns the level of the pet, starting at 1 without abilities.
+func (g *Growth) Level() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.unlocked + 1
+}
+
+// Items returns how many items the pet brought to the player.
+func (g *Growth) Items() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.items
+}
+
+// Unlocked returns the abilities the pet has gained, in order.
+func (g *Growth) Unlocked() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.abilities[:g.unlocked]...)
+}
+
+// Has reports whether the pet has gained ability.
+func (g *Growth) Has(ability string) bool {
+	for _, unlocked := range g.Unlocked() {
+		if unlocked == ability {
+			return true
+		}
+	}
+	return false
+}
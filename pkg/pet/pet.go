@@ -1,110 +1,132 @@
 package pet
 
 import (
+	"image/color"
 	"log"
+	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/joaorufino/gopher-game/internal/interfaces"
 	"github.com/joaorufino/gopher-game/pkg/animation"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
 	"github.com/joaorufino/gopher-game/pkg/particle"
 	"github.com/joaorufino/gopher-game/pkg/physics"
 )
 
-// Pet represents the pet character that follows the player.
+// Pet represents the pet character that follows the player. Its Brain
+// steers it and follows the commands of the player.
 type Pet struct {
 	Position            interfaces.Vector2D
-	velocity            interfaces.Vector2D
 	animations          map[string]*animation.Animation
 	currentAnimation    string
 	lastAnimationUpdate time.Time
-	onGround            bool
 	particleSystem      *particle.ParticleSystem
 	resourceManager     interfaces.ResourceManager
 	config              *Configuration
 	player              interfaces.Player
+	brain               *Brain
+	action              Action
+	level               int
+	leveledUp           bool
+	facingLeft          bool
 	RigidBody           *physics.RigidBody
 }
 
-// Configuration holds the configurable settings for the Pet.
-type Configuration struct {
-	ImageScale   float64
-	RunVelocity  float64
-	JumpVelocity float64
-}
-
-// NewPet initializes a new pet instance.
-func NewPet(startX, startY float64, resourceManager interfaces.ResourceManager, config *Configuration, physicsEngine interfaces.PhysicsEngine, player interfaces.Player) *Pet {
+// NewPet initializes a new pet instance, which knows about the level through
+// senses.
+func NewPet(startX, startY float64, resourceManager interfaces.ResourceManager, config *Configuration, physicsEngine interfaces.PhysicsEngine, player interfaces.Player, senses interfaces.PetSenses) *Pet {
 	frameCounts := map[string]int{
 		"idle": 1,
-		"run":  1,
-		"jump": 1,
+		"run":  6,
+		"jump": 6,
+		"pick": 2,
 	}
-	animations, size := animation.LoadAnimations(resourceManager, "/images/player/normal", frameCounts)
+	animations, size := animation.LoadAnimations(resourceManager, "/images/player/argocd", frameCounts)
 	size.X = size.X * config.ImageScale
 	size.Y = size.Y * config.ImageScale
 
+	body := physics.NewRigidBody(interfaces.Vector2D{X: startX, Y: startY}, size, 500, false, "pet")
 	pet := &Pet{
 		Position:            interfaces.Vector2D{X: startX, Y: startY},
-		velocity:            interfaces.Vector2D{X: 0, Y: 0},
 		animations:          animations,
 		currentAnimation:    "idle",
 		lastAnimationUpdate: time.Now(),
-		onGround:            true,
 		particleSystem:      particle.NewParticleSystem(100),
 		resourceManager:     resourceManager,
 		config:              config,
 		player:              player,
-		RigidBody:           physics.NewRigidBody(interfaces.Vector2D{X: startX, Y: startY}, size, 500, false, "pet"),
+		brain:               NewBrain(body, *config, senses),
+		level:               1,
+		RigidBody:           body,
 	}
 	physicsEngine.AddRigidBody(pet.RigidBody)
 	return pet
 }
 
+// Update lets the brain steer the pet after the player, or wherever it was
+// told to go. The physics engine moves the body.
 func (p *Pet) Update(deltaTime float64) error {
-	p.followPlayer()
+	owner := interfaces.Rect{Position: p.player.GetPosition(), Size: p.player.GetSize()}
+	p.action = p.brain.Update(deltaTime, owner)
+	level := p.brain.Growth().Level()
+	p.leveledUp, p.level = level > p.level, level
+	p.celebrate()
 	if err := p.animations[p.currentAnimation].Update(deltaTime); err != nil {
 		log.Printf("animation update error: %v", err)
 	}
 
-	p.updatePosition(deltaTime)
 	p.Position = p.RigidBody.GetPosition()
 	p.updateAnimationState()
 	p.particleSystem.Update(deltaTime)
 	return nil
 }
 
-func (p *Pet) followPlayer() {
-	playerPos := p.player.GetPosition()
-	pPos := p.Position
-
-	if playerPos.X > pPos.X+30 {
-		p.RigidBody.Velocity.X = p.config.RunVelocity
-	} else if playerPos.X+30 < pPos.X {
-		p.RigidBody.Velocity.X = -p.config.RunVelocity
-	} else {
-		p.RigidBody.Velocity.X = 0
+// celebrate puffs particles when the pet bites an enemy, hands an item over
+// or jumps to the player, and a ring of them when it levels up.
+func (p *Pet) celebrate() {
+	from := center(physics.BodyRect(p.RigidBody))
+	if p.leveledUp {
+		for i := 0; i < 12; i++ {
+			angle := float64(i) * math.Pi / 6
+			velocity := interfaces.Vector2D{X: math.Cos(angle) * 80, Y: math.Sin(angle) * 80}
+			p.particleSystem.AddParticle(from, velocity, 0.8, 4, color.RGBA{120, 220, 255, 255})
+		}
 	}
-
-	if playerPos.Y+30 > pPos.Y {
-		p.RigidBody.Velocity.Y = p.config.JumpVelocity
-	} else if playerPos.Y+30 < pPos.Y {
-		p.RigidBody.Velocity.Y = -p.config.JumpVelocity
-	} else {
-		p.RigidBody.Velocity.Y = 0
+	var cl color.RGBA
+	switch p.action.Kind {
+	case ActionBite:
+		cl = color.RGBA{255, 80, 80, 255}
+	case ActionDeliver:
+		cl = color.RGBA{255, 215, 0, 255}
+	case ActionSync:
+		cl = color.RGBA{120, 220, 255, 255}
+	default:
+		return
+	}
+	for _, velocity := range []interfaces.Vector2D{{X: -40, Y: -60}, {X: 0, Y: -80}, {X: 40, Y: -60}} {
+		p.particleSystem.AddParticle(from, velocity, 0.5, 3, cl)
 	}
 }
 
-func (p *Pet) updatePosition(deltaTime float64) {
-	p.RigidBody.Update(deltaTime)
-}
-
+// updateAnimationState picks the animation of what the pet is doing: jumping
+// in the air, holding what it carries or bites, running or standing.
 func (p *Pet) updateAnimationState() {
-	if p.RigidBody.Velocity.X != 0 {
-		p.currentAnimation = "run"
-	} else if !p.RigidBody.OnGround {
+	velocity := p.RigidBody.Velocity.X
+	if velocity < -1 {
+		p.facingLeft = true
+	} else if velocity > 1 {
+		p.facingLeft = false
+	}
+	switch {
+	case !p.RigidBody.OnGround:
 		p.currentAnimation = "jump"
-	} else {
+	case p.brain.Carrying(), p.action.Kind == ActionBite:
+		p.currentAnimation = "pick"
+	case velocity < -1 || velocity > 1:
+		p.currentAnimation = "run"
+	default:
 		p.currentAnimation = "idle"
 	}
 }
@@ -113,20 +135,92 @@ func (p *Pet) Draw(screen *ebiten.Image, cam interfaces.Camera) error {
 	offsetX, offsetY := cam.GetOffset()
 
 	petOpts := &ebiten.DrawImageOptions{}
-	petOpts.GeoM.Scale(p.config.ImageScale, p.config.ImageScale)
+	if p.facingLeft {
+		petOpts.GeoM.Scale(-p.config.ImageScale, p.config.ImageScale)
+		petOpts.GeoM.Translate(p.RigidBody.Size.X, 0)
+	} else {
+		petOpts.GeoM.Scale(p.config.ImageScale, p.config.ImageScale)
+	}
 	petOpts.GeoM.Translate(p.Position.X-offsetX, p.Position.Y-offsetY)
 	p.animations[p.currentAnimation].Draw(screen, petOpts)
 
+	p.drawWarning(screen, offsetX, offsetY)
 	p.particleSystem.Draw(screen, cam)
 	return nil
 }
 
+// drawWarning outlines the hazard the pet warns about, and raises a red
+// exclamation mark over the pet.
+func (p *Pet) drawWarning(screen *ebiten.Image, offsetX, offsetY float64) {
+	hazard, ok := p.brain.Warning()
+	if !ok {
+		return
+	}
+	warn := color.RGBA{255, 60, 60, 255}
+	vector.StrokeRect(screen, float32(hazard.Position.X-offsetX), float32(hazard.Position.Y-offsetY),
+		float32(hazard.Size.X), float32(hazard.Size.Y), 2, warn, false)
+	x := float32(p.Position.X + p.RigidBody.Size.X/2 - offsetX)
+	y := float32(p.Position.Y - offsetY)
+	vector.DrawFilledRect(screen, x-1.5, y-22, 3, 12, warn, false)
+	vector.DrawFilledRect(screen, x-1.5, y-7, 3, 3, warn, false)
+}
+
+// Command gives the pet an order; see Brain.Command.
+func (p *Pet) Command(command Command) bool {
+	return p.brain.Command(command)
+}
+
+// Action returns what the pet did to the level in the last update, for the
+// game to carry out.
+func (p *Pet) Action() Action {
+	return p.action
+}
+
+// Growth returns how far the pet has grown and which abilities it has.
+func (p *Pet) Growth() *Growth {
+	return p.brain.Growth()
+}
+
+// LeveledUp reports whether the pet leveled up in the last update.
+func (p *Pet) LeveledUp() bool {
+	return p.leveledUp
+}
+
+// Warning returns the hazard near the player the pet warns about; see
+// Brain.Warning.
+func (p *Pet) Warning() (interfaces.Rect, bool) {
+	return p.brain.Warning()
+}
+
+// State returns what the pet is doing.
+func (p *Pet) State() string {
+	return p.brain.State()
+}
+
+// Carrying reports whether the pet carries an item to the player.
+func (p *Pet) Carrying() bool {
+	return p.brain.Carrying()
+}
+
+// SetGraph lets the pet find its way to other platforms.
+func (p *Pet) SetGraph(graph *navigation.Graph) {
+	p.brain.SetGraph(graph)
+}
+
+// Plan returns the way the pet is following, or nil.
+func (p *Pet) Plan() *navigation.Plan {
+	return p.brain.Plan()
+}
+
 func (p *Pet) GetPosition() interfaces.Vector2D {
 	return p.RigidBody.GetPosition()
 }
 
+// SetPosition moves the pet to po and stops it.
 func (p *Pet) SetPosition(po interfaces.Vector2D) {
+	p.RigidBody.Teleport(po)
 	p.Position = po
+	p.brain.Reset()
 }
 
 func (p *Pet) GetCurrentAnimation() string {
@@ -0,0 +1,393 @@
+package pet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/navigation"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPet(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pet Suite")
+}
+
+const frame = 1.0 / 60
+
+func rect(x, y, w, h float64) interfaces.Rect {
+	return interfaces.Rect{Position: interfaces.Vector2D{X: x, Y: y}, Size: interfaces.Vector2D{X: w, Y: h}}
+}
+
+// fakeSenses puts the platforms, obstacles, items, enemies and hazards
+// wherever a test needs them.
+type fakeSenses struct {
+	platforms []interfaces.Rect
+	obstacles []interfaces.Rect
+	items     []interfaces.Rect
+	enemies   []interfaces.Rect
+	hazards   []interfaces.Rect
+}
+
+func (f *fakeSenses) Platforms() []interfaces.Rect { return f.platforms }
+func (f *fakeSenses) Obstacles() []interfaces.Rect { return f.obstacles }
+func (f *fakeSenses) Enemies() []interfaces.Rect   { return f.enemies }
+func (f *fakeSenses) Hazards() []interfaces.Rect   { return f.hazards }
+
+func (f *fakeSenses) Items(point interfaces.Vector2D, radius float64) []interfaces.Rect {
+	var items []interfaces.Rect
+	for _, item := range f.items {
+		if distance(center(item), point) <= radius {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var _ = Describe("Steering", func() {
+	It("should arrive at full speed and slow down close to the target", func() {
+		Expect(Arrive(0, 200, 100, 60, 4)).To(Equal(100.0))
+		Expect(Arrive(0, -32, 100, 60, 4)).To(BeNumerically("~", -100*32.0/60, 1e-9))
+		Expect(Arrive(0, 3, 100, 60, 4)).To(BeZero())
+	})
+
+	It("should change speed by at most the acceleration", func() {
+		Expect(Approach(0, 100, 400, 0.1)).To(Equal(40.0))
+		Expect(Approach(90, 100, 400, 0.1)).To(Equal(100.0))
+		Expect(Approach(0, -100, 400, 0.1)).To(Equal(-40.0))
+	})
+
+	It("should keep behind the leader", func() {
+		leader := rect(100, 160, 20, 40)
+		Expect(Behind(leader, 1, 40)).To(Equal(interfaces.Vector2D{X: 70, Y: 200}))
+		Expect(Behind(leader, -1, 40)).To(Equal(interfaces.Vector2D{X: 150, Y: 200}))
+	})
+
+	It("should jump over low obstacles, stop at high ones and see ledges", func() {
+		body := rect(92, 180, 16, 20)
+		ground := []interfaces.Rect{rect(0, 200, 300, 20)}
+		low := rect(120, 190, 10, 10)
+		high := rect(120, 50, 10, 150)
+
+		ahead := Avoid(body, 1, 20, 30, []interfaces.Rect{low}, ground)
+		Expect(ahead).To(Equal(Avoidance{Blocked: true, Jump: true, Drop: 0}))
+		ahead = Avoid(body, 1, 20, 30, []interfaces.Rect{high}, ground)
+		Expect(ahead.Blocked).To(BeTrue())
+		Expect(ahead.Jump).To(BeFalse())
+		Expect(Avoid(body, -1, 20, 30, []interfaces.Rect{low}, ground).Blocked).To(BeFalse())
+
+		edge := rect(284, 180, 16, 20)
+		ahead = Avoid(edge, 1, 20, 30, nil, ground)
+		Expect(ahead.Ledge).To(BeTrue())
+		Expect(math.IsInf(ahead.Drop, 1)).To(BeTrue())
+		ahead = Avoid(edge, 1, 20, 30, nil, append(ground, rect(300, 260, 200, 20)))
+		Expect(ahead.Ledge).To(BeTrue())
+		Expect(ahead.Drop).To(Equal(60.0))
+	})
+
+	It("should wander back and forth around its anchor", func() {
+		wander := NewWander(48, 30, 3, 1)
+		x, lowest, highest := 100.0, 100.0, 100.0
+		for i := 0; i < 3600; i++ {
+			x += wander.Velocity(x, 100, frame) * frame
+			lowest, highest = math.Min(lowest, x), math.Max(highest, x)
+		}
+		Expect(lowest).To(BeNumerically(">=", 100-48-1))
+		Expect(highest).To(BeNumerically("<=", 100+48+1))
+		Expect(highest - lowest).To(BeNumerically(">", 20))
+	})
+})
+
+var _ = Describe("Growth", func() {
+	It("should gain the abilities of the item one level at a time", func() {
+		growth := NewGrowth(nil, nil)
+		Expect(growth.Level()).To(Equal(1))
+		Expect(growth.Unlocked()).To(BeEmpty())
+
+		Expect(growth.Collect()).To(BeTrue())
+		Expect(growth.Level()).To(Equal(2))
+		Expect(growth.Has(AbilityContinuousDeployment)).To(BeTrue())
+		Expect(growth.Has(AbilitySyncAssistance)).To(BeFalse())
+
+		Expect(growth.Collect()).To(BeFalse())
+		Expect(growth.Collect()).To(BeTrue())
+		Expect(growth.Unlocked()).To(Equal([]string{AbilityContinuousDeployment, AbilitySyncAssistance}))
+		for i := 0; i < 10; i++ {
+			growth.Collect()
+		}
+		Expect(growth.Level()).To(Equal(4))
+		Expect(growth.Items()).To(Equal(13))
+	})
+
+	It("should gain every ability at once", func() {
+		growth := NewGrowth([]string{"Dig"}, []int{5})
+		Expect(growth.UnlockAll()).To(BeTrue())
+		Expect(growth.UnlockAll()).To(BeFalse())
+		Expect(growth.Level()).To(Equal(2))
+		Expect(growth.Has("Dig")).To(BeTrue())
+	})
+})
+
+var _ = Describe("Brain", func() {
+	var (
+		senses *fakeSenses
+		body   *physics.RigidBody
+		brain  *Brain
+		owner  interfaces.Rect
+	)
+
+	// place puts the pet with its feet at x on the ground.
+	place := func(x float64, config Configuration) {
+		body = physics.NewRigidBody(interfaces.Vector2D{X: x - 8, Y: 180}, interfaces.Vector2D{X: 16, Y: 20}, 500, false, "pet")
+		body.OnGround = true
+		config.Gravity = 9.8
+		brain = NewBrain(body, config, senses)
+	}
+	// run updates the pet for some seconds on flat ground and returns what
+	// it did.
+	run := func(seconds float64) []Action {
+		var actions []Action
+		for t := 0.0; t < seconds; t += frame {
+			if action := brain.Update(frame, owner); action.Kind != ActionNone {
+				actions = append(actions, action)
+			}
+			body.Position.X += body.Velocity.X * frame
+		}
+		return actions
+	}
+	feet := func() float64 {
+		return body.Position.X + body.Size.X/2
+	}
+
+	BeforeEach(func() {
+		senses = &fakeSenses{platforms: []interfaces.Rect{rect(0, 200, 1000, 20)}}
+		owner = rect(300, 160, 20, 40)
+	})
+
+	It("should catch up with the player smoothly and wait behind it", func() {
+		place(100, Configuration{IdleDelay: 60})
+		previous, turns := 0.0, 0
+		for t := 0.0; t < 5; t += frame {
+			brain.Update(frame, owner)
+			Expect(math.Abs(body.Velocity.X - previous)).To(BeNumerically("<=", defaultAcceleration*frame+1e-9))
+			if body.Velocity.X*previous < 0 {
+				turns++
+			}
+			previous = body.Velocity.X
+			body.Position.X += body.Velocity.X * frame
+		}
+		Expect(turns).To(BeZero())
+		Expect(feet()).To(BeNumerically("~", 310-defaultFollowDistance, arriveDistance))
+		Expect(body.Velocity.X).To(BeZero())
+		Expect(brain.State()).To(Equal(StateIdle))
+
+		// Once the player walks back the other way the pet keeps behind it
+		owner.Position.X = 250
+		run(3)
+		Expect(feet()).To(BeNumerically("~", 260+defaultFollowDistance, arriveDistance))
+	})
+
+	It("should wander about while the player stands still", func() {
+		place(270, Configuration{IdleDelay: 1})
+		run(1.5)
+		Expect(brain.State()).To(Equal(StateWander))
+		lowest, highest := feet(), feet()
+		for i := 0; i < 10; i++ {
+			run(1)
+			lowest, highest = math.Min(lowest, feet()), math.Max(highest, feet())
+		}
+		Expect(highest - lowest).To(BeNumerically(">", 10))
+		Expect(lowest).To(BeNumerically(">=", 270-defaultWanderRadius-10))
+		Expect(highest).To(BeNumerically("<=", 270+defaultWanderRadius+10))
+
+		owner.Position.X = 600
+		run(0.1)
+		Expect(brain.State()).To(Equal(StateFollow))
+	})
+
+	It("should stay until told to follow again", func() {
+		place(100, Configuration{})
+		Expect(brain.Command(Command{Kind: CommandStay})).To(BeTrue())
+		run(2)
+		Expect(brain.State()).To(Equal(StateStay))
+		Expect(feet()).To(Equal(100.0))
+
+		brain.Command(Command{Kind: CommandFollow})
+		run(0.5)
+		Expect(brain.State()).To(Equal(StateFollow))
+		Expect(body.Velocity.X).To(BeNumerically(">", 0))
+	})
+
+	It("should go to a point on the platform under it and stay there", func() {
+		place(100, Configuration{})
+		Expect(brain.Command(Command{Kind: CommandGoTo, Point: interfaces.Vector2D{X: 500, Y: 120}})).To(BeTrue())
+		Expect(brain.Target()).To(Equal(interfaces.Vector2D{X: 100, Y: 200}))
+		run(0.1)
+		Expect(brain.State()).To(Equal(StateGoTo))
+		Expect(brain.Target()).To(Equal(interfaces.Vector2D{X: 500, Y: 200}))
+		run(6)
+		Expect(brain.State()).To(Equal(StateStay))
+		Expect(feet()).To(BeNumerically("~", 500, arriveDistance))
+	})
+
+	It("should fetch an item and bring it to the player", func() {
+		place(100, Configuration{})
+		owner = rect(30, 160, 20, 40)
+		Expect(brain.Command(Command{Kind: CommandFetch})).To(BeFalse())
+
+		item := rect(300, 184, 16, 16)
+		senses.items = []interfaces.Rect{item}
+		Expect(brain.Command(Command{Kind: CommandFetch})).To(BeTrue())
+		actions := run(4)
+		Expect(actions).To(Equal([]Action{{Kind: ActionPick, Target: item}}))
+		Expect(brain.Carrying()).To(BeTrue())
+		Expect(brain.State()).To(Equal(StateCarry))
+
+		// The game takes the item off the map
+		senses.items = nil
+		actions = run(5)
+		Expect(actions).To(Equal([]Action{{Kind: ActionDeliver, Target: owner}}))
+		Expect(brain.Carrying()).To(BeFalse())
+		Expect(brain.State()).NotTo(Equal(StateCarry))
+	})
+
+	It("should drop what it carries when told to do something else", func() {
+		place(100, Configuration{})
+		senses.items = []interfaces.Rect{rect(104, 184, 16, 16)}
+		brain.Command(Command{Kind: CommandFetch})
+		Expect(run(0.1)).To(HaveLen(1))
+		Expect(brain.Carrying()).To(BeTrue())
+		brain.Command(Command{Kind: CommandStay})
+		Expect(brain.Carrying()).To(BeFalse())
+	})
+
+	It("should bite an enemy every so often until it is gone", func() {
+		place(100, Configuration{})
+		enemy := rect(300, 168, 32, 32)
+		senses.enemies = []interfaces.Rect{enemy}
+		Expect(brain.Command(Command{Kind: CommandAttack})).To(BeTrue())
+		Expect(brain.State()).To(Equal(StateIdle))
+		run(0.1)
+		Expect(brain.State()).To(Equal(StateAttack))
+		actions := run(4)
+		Expect(len(actions)).To(BeNumerically(">=", 2))
+		Expect(actions[0]).To(Equal(Action{Kind: ActionBite, Target: enemy}))
+
+		senses.enemies = nil
+		run(0.1)
+		Expect(brain.State()).NotTo(Equal(StateAttack))
+	})
+
+	It("should not attack enemies out of reach", func() {
+		place(100, Configuration{Reach: 100})
+		senses.enemies = []interfaces.Rect{rect(600, 168, 32, 32)}
+		Expect(brain.Command(Command{Kind: CommandAttack})).To(BeFalse())
+	})
+
+	It("should jump over low obstacles in the way", func() {
+		place(100, Configuration{})
+		senses.obstacles = []interfaces.Rect{rect(150, 190, 10, 10)}
+		for i := 0; i < 60 && body.Velocity.Y == 0; i++ {
+			run(frame)
+		}
+		Expect(body.Velocity.Y).To(Equal(-defaultJumpVelocity))
+		Expect(body.Position.X + body.Size.X).To(BeNumerically("<", 150))
+	})
+
+	It("should stop at a ledge rather than fall off it", func() {
+		senses.platforms = []interfaces.Rect{rect(0, 200, 300, 20), rect(400, 200, 400, 20)}
+		owner = rect(600, 160, 20, 40)
+		place(100, Configuration{})
+		run(5)
+		Expect(body.Velocity.X).To(BeZero())
+		Expect(feet()).To(BeNumerically(">", 250))
+		Expect(feet()).To(BeNumerically("<", 300))
+	})
+
+	It("should jump its way to a player on another platform", func() {
+		senses.platforms = []interfaces.Rect{rect(0, 200, 300, 20), rect(400, 200, 400, 20)}
+		owner = rect(600, 160, 20, 40)
+		place(100, Configuration{})
+		graph := navigation.NewGraph(Configuration{Gravity: 9.8}.Navigation(body.Size))
+		graph.Rebuild(senses.platforms)
+		brain.SetGraph(graph)
+
+		run(frame)
+		Expect(brain.Plan()).NotTo(BeNil())
+		Expect(brain.Plan().Links).To(HaveLen(1))
+		Expect(brain.Plan().Links[0].Kind).To(Equal(navigation.LinkJump))
+		Expect(body.Velocity.X).To(BeNumerically(">", 0))
+
+		// At the edge it takes off
+		body.Teleport(interfaces.Vector2D{X: 284, Y: 180})
+		body.Velocity.X = defaultRunVelocity
+		run(frame)
+		Expect(body.Velocity.Y).To(Equal(-defaultJumpVelocity))
+	})
+
+	It("should warn about the hazard nearest the player", func() {
+		place(100, Configuration{})
+		senses.hazards = []interfaces.Rect{rect(600, 190, 40, 10), rect(360, 190, 40, 10), rect(340, 190, 10, 10)}
+		run(frame)
+		hazard, ok := brain.Warning()
+		Expect(ok).To(BeTrue())
+		Expect(hazard).To(Equal(rect(340, 190, 10, 10)))
+
+		senses.hazards = senses.hazards[:1]
+		run(frame)
+		_, ok = brain.Warning()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should fetch items near it unasked with Continuous Deployment", func() {
+		place(100, Configuration{})
+		senses.items = []interfaces.Rect{rect(200, 184, 16, 16)}
+		run(1)
+		Expect(brain.State()).NotTo(Equal(StateFetch))
+
+		brain.Growth().UnlockAll()
+		actions := run(3)
+		Expect(actions).NotTo(BeEmpty())
+		Expect(actions[0]).To(Equal(Action{Kind: ActionPick, Target: senses.items[0]}))
+	})
+
+	It("should level up with the items it brings to the player", func() {
+		place(100, Configuration{Thresholds: []int{1}})
+		owner = rect(30, 160, 20, 40)
+		senses.items = []interfaces.Rect{rect(200, 184, 16, 16)}
+		brain.Command(Command{Kind: CommandFetch})
+		run(3)
+		senses.items = nil
+		run(5)
+		Expect(brain.Growth().Items()).To(Equal(1))
+		Expect(brain.Growth().Unlocked()).To(Equal([]string{AbilityContinuousDeployment}))
+	})
+
+	It("should jump back to the player when left behind with Sync Assistance", func() {
+		senses.platforms = []interfaces.Rect{rect(0, 200, 300, 20), rect(400, 200, 400, 20)}
+		owner = rect(700, 160, 20, 40)
+		place(100, Configuration{Abilities: []string{AbilitySyncAssistance}})
+		brain.Growth().UnlockAll()
+		actions := run(defaultSyncDelay + 0.5)
+		Expect(actions).To(HaveLen(1))
+		Expect(actions[0].Kind).To(Equal(ActionSync))
+		Expect(feet()).To(BeNumerically("~", 710-defaultFollowDistance, 1))
+		Expect(body.Position.Y + body.Size.Y).To(Equal(200.0))
+	})
+
+	It("should attack enemies near the player with Repository Guardian", func() {
+		place(100, Configuration{})
+		enemy := rect(500, 168, 32, 32)
+		senses.enemies = []interfaces.Rect{enemy}
+		brain.Growth().UnlockAll()
+		run(1)
+		Expect(brain.State()).To(Equal(StateFollow))
+
+		owner.Position.X = 420
+		run(0.6)
+		Expect(brain.State()).To(Equal(StateAttack))
+	})
+})
@@ -0,0 +1,142 @@
+package pet
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// The pet runs and jumps like the player, so its steering is horizontal: the
+// behaviors below return how fast it wants to run, and gravity does the rest.
+
+// Arrive returns the speed that takes x to target: full speed far away,
+// slowing down within slowRadius and stopping within stopRadius of it.
+func Arrive(x, target, maxSpeed, slowRadius, stopRadius float64) float64 {
+	dx := target - x
+	distance := math.Abs(dx)
+	if distance <= stopRadius {
+		return 0
+	}
+	speed := maxSpeed
+	if distance < slowRadius {
+		speed = maxSpeed * distance / slowRadius
+	}
+	return math.Copysign(speed, dx)
+}
+
+// Approach changes speed toward desired by at most maxAcceleration over
+// deltaTime, so the pet speeds up and slows down smoothly.
+func Approach(speed, desired, maxAcceleration, deltaTime float64) float64 {
+	step := maxAcceleration * deltaTime
+	switch {
+	case desired > speed+step:
+		return speed + step
+	case desired < speed-step:
+		return speed - step
+	}
+	return desired
+}
+
+// Behind returns where feet go to follow a leader at offset pixels behind
+// it, facing right when facing is positive and left otherwise. Feet are the
+// middle of the bottom of a body.
+func Behind(leader interfaces.Rect, facing, offset float64) interfaces.Vector2D {
+	feet := interfaces.Vector2D{X: leader.Position.X + leader.Size.X/2, Y: leader.Position.Y + leader.Size.Y}
+	if facing < 0 {
+		feet.X += offset
+	} else {
+		feet.X -= offset
+	}
+	return feet
+}
+
+// Avoidance is what lies ahead of a running body.
+type Avoidance struct {
+	Blocked bool    // Something solid is in the way
+	Jump    bool    // It is low enough to jump over
+	Ledge   bool    // There is no ground to run onto
+	Drop    float64 // How far below the feet the ground ahead is
+}
+
+// Avoid looks lookAhead pixels ahead of body, running in direction (its
+// sign), for solid rectangles in the way and for a ledge: no platform under
+// the far end of the look. Obstacles no higher than jumpHeight above the feet
+// can be jumped over.
+func Avoid(body interfaces.Rect, direction, lookAhead, jumpHeight float64, solids, platforms []interfaces.Rect) Avoidance {
+	var result Avoidance
+	if direction == 0 {
+		return result
+	}
+	feet := body.Position.Y + body.Size.Y
+	ahead := interfaces.Rect{
+		Position: interfaces.Vector2D{X: body.Position.X + body.Size.X, Y: body.Position.Y},
+		Size:     interfaces.Vector2D{X: lookAhead, Y: body.Size.Y - groundTolerance},
+	}
+	if direction < 0 {
+		ahead.Position.X = body.Position.X - lookAhead
+	}
+	result.Jump = true
+	for _, solid := range solids {
+		if !overlaps(ahead, solid) {
+			continue
+		}
+		result.Blocked = true
+		result.Jump = result.Jump && feet-solid.Position.Y <= jumpHeight
+	}
+	result.Jump = result.Blocked && result.Jump
+
+	front := ahead.Position.X + ahead.Size.X
+	if direction < 0 {
+		front = ahead.Position.X
+	}
+	result.Drop = math.Inf(1)
+	for _, platform := range platforms {
+		if front < platform.Position.X || front > platform.Position.X+platform.Size.X || platform.Position.Y < feet-groundTolerance {
+			continue
+		}
+		result.Drop = math.Min(result.Drop, platform.Position.Y-feet)
+	}
+	result.Ledge = result.Drop > groundTolerance
+	return result
+}
+
+// Wander picks a run speed that drifts back and forth around an anchor,
+// changing direction now and then.
+type Wander struct {
+	Radius float64 // How far from the anchor the pet wanders
+	Speed  float64 // How fast it wanders
+	Jitter float64 // How much the heading changes per second, in radians
+	angle  float64
+	rng    *rand.Rand
+}
+
+// NewWander creates a wander driven by a generator seeded with seed.
+func NewWander(radius, speed, jitter float64, seed int64) *Wander {
+	rng := rand.New(rand.NewSource(seed))
+	return &Wander{Radius: radius, Speed: speed, Jitter: jitter, angle: rng.Float64() * 2 * math.Pi, rng: rng}
+}
+
+// Velocity returns how fast to run from x, wandering around anchor. The
+// heading turns by a random amount every call, and turns back toward the
+// anchor once x strays past the radius.
+func (w *Wander) Velocity(x, anchor, deltaTime float64) float64 {
+	w.angle += (w.rng.Float64()*2 - 1) * w.Jitter * deltaTime
+	speed := math.Cos(w.angle) * w.Speed
+	if math.Abs(x-anchor) > w.Radius && (x-anchor)*speed > 0 {
+		// Heading away from the anchor: turn around
+		w.Turn()
+		speed = -speed
+	}
+	return speed
+}
+
+// Turn reverses the heading, e.g. at a wall.
+func (w *Wander) Turn() {
+	w.angle = math.Pi - w.angle
+}
+
+func overlaps(a, b interfaces.Rect) bool {
+	return a.Position.X < b.Position.X+b.Size.X && b.Position.X < a.Position.X+a.Size.X &&
+		a.Position.Y < b.Position.Y+b.Size.Y && b.Position.Y < a.Position.Y+a.Size.Y
+}
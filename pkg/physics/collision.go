@@ -1,5 +1,9 @@
 package physics
 
+// oneWayTolerance is how far into a one-way platform a body may have been at
+// the previous step and still land on it.
+const oneWayTolerance = 2.0
+
 // CheckCollisionOnX checks if two rigid bodies are colliding on the X axis.
 func CheckCollisionOnX(a, b *RigidBody) bool {
 	return a.Position.X < b.Position.X+b.Size.X &&
@@ -45,6 +49,16 @@ func ResolveCollision(a, b *RigidBody) {
 		movable, static = b, a
 	}
 
+	// One-way platforms only hold up bodies coming down onto them from above
+	if static.IsOneWay {
+		if movable.Velocity.Y >= 0 && movable.previousPosition.Y+movable.Size.Y <= static.Position.Y+oneWayTolerance {
+			movable.Position.Y = static.Position.Y - movable.Size.Y
+			movable.Velocity.Y = 0
+			movable.OnGround = true
+		}
+		return
+	}
+
 	// Check if the movable body can push the static body
 	if static.IsPushable {
 		visited := make(map[*RigidBody]bool)
@@ -0,0 +1,118 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+)
+
+// The gravity of the physics engine and the speeds of the player. The game
+// is set up with them, and levellint checks that levels can be played with
+// them.
+const (
+	EngineGravity      = 9.8
+	PlayerJumpVelocity = 100.0
+	PlayerRunVelocity  = 200.0
+)
+
+// MulAdd returns a*b + c. Go may fuse a multiply and an add into one
+// instruction on some architectures and not on others (e.g. WASM), which
+// rounds differently; rounding the product and c on their own keeps seeded
+// levels and jump checks the same everywhere.
+func MulAdd(a, b, c float64) float64 {
+	return float64(a*b) + float64(c)
+}
+
+// EffectiveGravity returns the downward acceleration a falling body really
+// experiences: the engine applies its own gravity force and RigidBody.Update
+// adds GRAVITY on top of it while the body is airborne.
+func EffectiveGravity(engineGravity float64) float64 {
+	return engineGravity + GRAVITY
+}
+
+// JumpArc models the ballistic trajectory of a jumping body. All values are in
+// pixels and seconds, with y growing downwards like the rest of the game.
+type JumpArc struct {
+	Gravity      float64
+	JumpVelocity float64
+	RunVelocity  float64
+}
+
+// NewJumpArc creates a jump model from the engine gravity and the body's speeds.
+func NewJumpArc(engineGravity, jumpVelocity, runVelocity float64) JumpArc {
+	return JumpArc{
+		Gravity:      EffectiveGravity(engineGravity),
+		JumpVelocity: jumpVelocity,
+		RunVelocity:  runVelocity,
+	}
+}
+
+// MaxHeight returns how far above its take-off point a jump can rise.
+func (j JumpArc) MaxHeight() float64 {
+	if j.Gravity <= 0 {
+		return math.Inf(1)
+	}
+	return j.JumpVelocity * j.JumpVelocity / (2 * j.Gravity)
+}
+
+// AirTime returns how long a jump lasts until it comes down dy below the
+// take-off point (negative dy means above it). It reports false when the
+// height can never be reached.
+func (j JumpArc) AirTime(dy float64) (float64, bool) {
+	if j.Gravity <= 0 {
+		return 0, false
+	}
+	discriminant := MulAdd(j.JumpVelocity, j.JumpVelocity, 2*j.Gravity*dy)
+	if discriminant < 0 {
+		return 0, false
+	}
+	return (j.JumpVelocity + math.Sqrt(discriminant)) / j.Gravity, true
+}
+
+// Reach returns the horizontal distance a jump covers before landing dy below
+// the take-off point.
+func (j JumpArc) Reach(dy float64) (float64, bool) {
+	t, ok := j.AirTime(dy)
+	if !ok {
+		return 0, false
+	}
+	return t * j.RunVelocity, true
+}
+
+// HeightAt returns the height above the take-off point after travelling dx
+// horizontally at full run speed.
+func (j JumpArc) HeightAt(dx float64) float64 {
+	if j.RunVelocity <= 0 {
+		return 0
+	}
+	t := math.Abs(dx) / j.RunVelocity
+	return MulAdd(j.JumpVelocity, t, -j.Gravity*t*t/2)
+}
+
+// CanReach reports whether a body standing on top of from can jump (or fall)
+// onto the top of to.
+func (j JumpArc) CanReach(from, to core.Rect) bool {
+	dy := to.Position.Y - from.Position.Y
+	if -dy > j.MaxHeight() {
+		return false
+	}
+	reach, ok := j.Reach(dy)
+	return ok && HorizontalGap(from, to) <= reach
+}
+
+// HorizontalGap returns the horizontal distance between two rectangles, or 0
+// when they overlap on the X axis.
+func HorizontalGap(a, b core.Rect) float64 {
+	switch {
+	case a.Position.X+a.Size.X < b.Position.X:
+		return b.Position.X - (a.Position.X + a.Size.X)
+	case b.Position.X+b.Size.X < a.Position.X:
+		return a.Position.X - (b.Position.X + b.Size.X)
+	}
+	return 0
+}
+
+// BodyRect returns the rectangle occupied by a rigid body.
+func BodyRect(rb *RigidBody) core.Rect {
+	return core.Rect{Position: rb.Position, Size: rb.Size}
+}
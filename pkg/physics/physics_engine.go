@@ -1,30 +1,30 @@
 package physics
 
 import (
-	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/internal/core"
 )
 
 type PhysicsEngine struct {
-	RigidBodies  []interfaces.RigidBody
-	gravity      interfaces.Vector2D
+	RigidBodies  []core.RigidBody
+	gravity      core.Vector2D
 	floorY       float64
-	eventManager interfaces.EventManager
+	eventManager core.EventManager
 }
 
-func NewPhysicsEngine(eventManager interfaces.EventManager, gravity interfaces.Vector2D, floorY float64) *PhysicsEngine {
+func NewPhysicsEngine(eventManager core.EventManager, gravity core.Vector2D, floorY float64) *PhysicsEngine {
 	return &PhysicsEngine{
-		RigidBodies:  make([]interfaces.RigidBody, 0),
+		RigidBodies:  make([]core.RigidBody, 0),
 		gravity:      gravity,
 		floorY:       floorY,
 		eventManager: eventManager,
 	}
 }
 
-func (pe *PhysicsEngine) AddRigidBody(rb interfaces.RigidBody) {
+func (pe *PhysicsEngine) AddRigidBody(rb core.RigidBody) {
 	pe.RigidBodies = append(pe.RigidBodies, rb)
 }
 
-func (pe *PhysicsEngine) RemoveRigidBody(rb interfaces.RigidBody) {
+func (pe *PhysicsEngine) RemoveRigidBody(rb core.RigidBody) {
 	for i, r := range pe.RigidBodies {
 		if r == rb {
 			pe.RigidBodies = append(pe.RigidBodies[:i], pe.RigidBodies[i+1:]...)
@@ -33,18 +33,19 @@ func (pe *PhysicsEngine) RemoveRigidBody(rb interfaces.RigidBody) {
 	}
 }
 
-func (pe *PhysicsEngine) DetectCollision(rb1, rb2 interfaces.RigidBody) bool {
+func (pe *PhysicsEngine) DetectCollision(rb1, rb2 core.RigidBody) bool {
 	return CheckCollisionOnX(rb1.(*RigidBody), rb2.(*RigidBody)) &&
 		CheckCollisionOnY(rb1.(*RigidBody), rb2.(*RigidBody))
 }
 
-func (pe *PhysicsEngine) ResolveCollision(rb1, rb2 interfaces.RigidBody) {
+func (pe *PhysicsEngine) ResolveCollision(rb1, rb2 core.RigidBody) {
 	if rb1.GetCanPick() && rb2.GetPickable() {
-		pe.eventManager.Dispatch(interfaces.Event{
-			Type:     interfaces.EventItemEquipped,
+		pe.eventManager.Dispatch(core.Event{
+			Type:     core.EventItemEquipped,
 			Priority: 1,
 			Payload: map[string]interface{}{
 				"itemName": rb2.GetIdentifier(),
+				"picker":   rb1.GetIdentifier(),
 			},
 		})
 		pe.RemoveRigidBody(rb2)
@@ -56,7 +57,7 @@ func (pe *PhysicsEngine) Update(deltaTime float64) {
 	for _, rb := range pe.RigidBodies {
 		if !rb.(*RigidBody).IsStatic {
 			// Apply gravity
-			rb.(*RigidBody).ApplyForce(interfaces.Vector2D{
+			rb.(*RigidBody).ApplyForce(core.Vector2D{
 				X: 0,
 				Y: pe.gravity.Y * rb.(*RigidBody).Mass,
 			})
@@ -69,7 +70,7 @@ func (pe *PhysicsEngine) Update(deltaTime float64) {
 			rb.Update(deltaTime)
 
 			// Reset acceleration
-			rb.(*RigidBody).Acceleration = interfaces.Vector2D{X: 0, Y: 0}
+			rb.(*RigidBody).Acceleration = core.Vector2D{X: 0, Y: 0}
 		}
 	}
 
@@ -108,9 +109,33 @@ func (pe *PhysicsEngine) Update(deltaTime float64) {
 				}
 			}
 		}
+		rb.(*RigidBody).previousPosition = rb.(*RigidBody).Position
 	}
 }
 
-func (pe *PhysicsEngine) GetRigidBodies() []interfaces.RigidBody {
+// Gravity returns the gravity the engine applies to every body.
+func (pe *PhysicsEngine) Gravity() core.Vector2D {
+	return pe.gravity
+}
+
+// FloorY returns the height of the floor that stops every falling body.
+func (pe *PhysicsEngine) FloorY() float64 {
+	return pe.floorY
+}
+
+func (pe *PhysicsEngine) GetRigidBodies() []core.RigidBody {
 	return pe.RigidBodies
 }
+
+// QueryArea returns the rigid bodies overlapping area.
+func (pe *PhysicsEngine) QueryArea(area core.Rect) []core.RigidBody {
+	var found []core.RigidBody
+	for _, rb := range pe.RigidBodies {
+		position, size := rb.GetPosition(), rb.GetSize()
+		if position.X < area.Position.X+area.Size.X && area.Position.X < position.X+size.X &&
+			position.Y < area.Position.Y+area.Size.Y && area.Position.Y < position.Y+size.Y {
+			found = append(found, rb)
+		}
+	}
+	return found
+}
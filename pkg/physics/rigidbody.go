@@ -1,7 +1,7 @@
 package physics
 
 import (
-	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/internal/core"
 )
 
 const GRAVITY = 9.8
@@ -9,32 +9,40 @@ const GRAVITY = 9.8
 // RigidBody represents the physical properties of an entity.
 type RigidBody struct {
 	Identifier      string
-	Position        interfaces.Vector2D `json:"position"`
-	Velocity        interfaces.Vector2D `json:"velocity"`
-	Acceleration    interfaces.Vector2D `json:"acceleration"`
-	Mass            float64             `json:"mass"`
-	Size            interfaces.Vector2D `json:"size"`
+	Position        core.Vector2D `json:"position"`
+	Velocity        core.Vector2D `json:"velocity"`
+	Acceleration    core.Vector2D `json:"acceleration"`
+	Mass            float64       `json:"mass"`
+	Size            core.Vector2D `json:"size"`
 	IsStatic        bool
 	OnGround        bool
 	IsCollidable    bool
 	IsPushable      bool
 	IsPickable      bool
 	CanPick         bool
+	IsOneWay        bool
 	CollidingBodies []*RigidBody
+	// Friction, when above zero, is the share of its horizontal velocity the
+	// body keeps every step it spends on the ground, e.g. a rolling ball.
+	Friction float64
+
+	// previousPosition is where the body was at the end of the last physics step.
+	previousPosition core.Vector2D
 }
 
 // NewRigidBody creates a new RigidBody.
-func NewRigidBody(position, size interfaces.Vector2D, mass float64, isStatic bool, identifier string) *RigidBody {
+func NewRigidBody(position, size core.Vector2D, mass float64, isStatic bool, identifier string) *RigidBody {
 	return &RigidBody{
-		Identifier:      identifier,
-		Position:        position,
-		Size:            size,
-		Mass:            mass,
-		IsStatic:        isStatic,
-		IsCollidable:    true,
-		IsPushable:      false,
-		OnGround:        false,
-		CollidingBodies: []*RigidBody{},
+		Identifier:       identifier,
+		Position:         position,
+		Size:             size,
+		Mass:             mass,
+		IsStatic:         isStatic,
+		IsCollidable:     true,
+		IsPushable:       false,
+		OnGround:         false,
+		CollidingBodies:  []*RigidBody{},
+		previousPosition: position,
 	}
 }
 
@@ -82,7 +90,7 @@ func (rb *RigidBody) SetPushable(b bool) {
 }
 
 // ApplyForce applies a force to the rigid body.
-func (rb *RigidBody) ApplyForce(force interfaces.Vector2D) {
+func (rb *RigidBody) ApplyForce(force core.Vector2D) {
 	if rb.IsStatic {
 		return
 	}
@@ -101,38 +109,51 @@ func (rb *RigidBody) Update(deltaTime float64) {
 		rb.Velocity.Y += GRAVITY * deltaTime
 	}
 
+	if rb.Friction > 0 && rb.OnGround {
+		rb.Velocity.X *= rb.Friction
+	}
+
 	// Update the position based on the velocity
 	rb.Position.X += rb.Velocity.X * deltaTime
 	rb.Position.Y += rb.Velocity.Y * deltaTime
 }
 
 // GetPosition returns the current position of the rigid body.
-func (rb *RigidBody) GetPosition() interfaces.Vector2D {
+func (rb *RigidBody) GetPosition() core.Vector2D {
 	return rb.Position
 }
 
 // SetPosition sets the position of the rigid body.
-func (rb *RigidBody) SetPosition(position interfaces.Vector2D) {
+func (rb *RigidBody) SetPosition(position core.Vector2D) {
+	rb.Position = position
+}
+
+// Teleport moves the body to position and stops it. The body counts as having
+// been there all along, so collisions do not treat the jump as movement.
+func (rb *RigidBody) Teleport(position core.Vector2D) {
 	rb.Position = position
+	rb.previousPosition = position
+	rb.Velocity = core.Vector2D{}
+	rb.Acceleration = core.Vector2D{}
 }
 
 // GetVelocity returns the current velocity of the rigid body.
-func (rb *RigidBody) GetVelocity() interfaces.Vector2D {
+func (rb *RigidBody) GetVelocity() core.Vector2D {
 	return rb.Velocity
 }
 
 // SetVelocity sets the velocity of the rigid body.
-func (rb *RigidBody) SetVelocity(velocity interfaces.Vector2D) {
+func (rb *RigidBody) SetVelocity(velocity core.Vector2D) {
 	rb.Velocity = velocity
 }
 
 // GetSize returns the size of the rigid body.
-func (rb *RigidBody) GetSize() interfaces.Vector2D {
+func (rb *RigidBody) GetSize() core.Vector2D {
 	return rb.Size
 }
 
 // SetSize sets the size of the rigid body.
-func (rb *RigidBody) SetSize(size interfaces.Vector2D) {
+func (rb *RigidBody) SetSize(size core.Vector2D) {
 	rb.Size = size
 }
 
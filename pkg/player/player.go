@@ -1,6 +1,7 @@
 package player
 
 import (
+	"fmt"
 	"image/color"
 	"log"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/joaorufino/gopher-game/internal/interfaces"
 	"github.com/joaorufino/gopher-game/pkg/animation"
+	"github.com/joaorufino/gopher-game/pkg/health"
 	"github.com/joaorufino/gopher-game/pkg/particle"
 	"github.com/joaorufino/gopher-game/pkg/physics"
 )
@@ -30,8 +32,18 @@ type Player struct {
 	EventManager        interfaces.EventManager
 	rotation            float64
 	gameWidth           float64 // Add gameWidth to constrain movement
+	Health              *health.Health
+	knockedBack         float64 // Time left before input moves the player again
+	index               int     // Which of the players sharing the screen this is
 }
 
+const (
+	// defaultKnockback is the knockback of hits that do not set one.
+	defaultKnockback = 250
+	// knockbackDuration is how long a hit takes control away from the player.
+	knockbackDuration = 0.25
+)
+
 // Configuration holds the configurable settings for the Player.
 type Configuration struct {
 	ScreenWidth  int
@@ -40,10 +52,18 @@ type Configuration struct {
 	JumpVelocity float64
 	RunVelocity  float64
 	ImageScale   float64
+	Health       health.Config
 }
 
 // NewPlayer initializes a new player instance.
 func NewPlayer(startX, startY float64, resourceManager interfaces.ResourceManager, config *Configuration, physicsEngine interfaces.PhysicsEngine, event interfaces.EventManager, gameWidth float64) *Player {
+	return NewLocalPlayer(0, startX, startY, resourceManager, config, physicsEngine, event, gameWidth)
+}
+
+// NewLocalPlayer initializes one of the players sharing the screen, counted
+// from 0. It only listens to the input events of its own InputHandler, see
+// interfaces.PlayerEvent.
+func NewLocalPlayer(index int, startX, startY float64, resourceManager interfaces.ResourceManager, config *Configuration, physicsEngine interfaces.PhysicsEngine, event interfaces.EventManager, gameWidth float64) *Player {
 	frameCounts := map[string]int{
 		"idle": 1,
 		"run":  1,
@@ -67,9 +87,11 @@ func NewPlayer(startX, startY float64, resourceManager interfaces.ResourceManage
 		resourceManager:     resourceManager,
 		config:              config,
 		canFly:              false, // Initialize without the cloud item
-		RigidBody:           physics.NewRigidBody(interfaces.Vector2D{X: startX, Y: startY}, size, 1000, false, "player"),
+		RigidBody:           physics.NewRigidBody(interfaces.Vector2D{X: startX, Y: startY}, size, 1000, false, Identifier(index)),
 		EventManager:        event,
 		gameWidth:           gameWidth, // Set gameWidth
+		Health:              health.NewHealth(config.Health, event),
+		index:               index,
 	}
 	player.RigidBody.SetCanPick(true)
 	// Add the player's rigid body to the physics engine
@@ -78,11 +100,23 @@ func NewPlayer(startX, startY float64, resourceManager interfaces.ResourceManage
 	// Register input handlers for the player
 	player.registerInputHandlers()
 
-	// For soccer game, player represents blue team (no need to set tag as identifier is set in constructor)
-
 	return player
 }
 
+// Identifier returns the identifier of the body of the player with the given
+// index: "player" for the first one, "player2" for the second and so on.
+func Identifier(index int) string {
+	if index == 0 {
+		return "player"
+	}
+	return fmt.Sprintf("player%d", index+1)
+}
+
+// Index returns which of the players sharing the screen this is.
+func (p *Player) Index() int {
+	return p.index
+}
+
 // Update updates the player's state.
 func (p *Player) Update(deltaTime float64) error {
 	if err := p.animations[p.currentAnimation].Update(deltaTime); err != nil {
@@ -92,6 +126,8 @@ func (p *Player) Update(deltaTime float64) error {
 	p.updatePosition(deltaTime)
 	p.Position = p.RigidBody.GetPosition() // Sync player position with rigid body position
 	p.particleSystem.Update(deltaTime)
+	p.Health.Update(deltaTime)
+	p.knockedBack = max(p.knockedBack-deltaTime, 0)
 	return nil
 }
 
@@ -125,14 +161,22 @@ func (p *Player) Draw(screen *ebiten.Image, cam interfaces.Camera) error {
 	playerOpts.GeoM.Scale(p.config.ImageScale, p.config.ImageScale)
 	playerOpts.GeoM.Translate(p.Position.X-offsetX, p.Position.Y-offsetY)
 
-	p.animations[p.currentAnimation].Draw(screen, playerOpts)
+	// Every other player gets a tint to tell them apart
+	if p.index > 0 {
+		playerOpts.ColorScale.Scale(1, 0.6, 0.6, 1)
+	}
+
+	// The player blinks while invulnerable
+	if p.Health.Visible() {
+		p.animations[p.currentAnimation].Draw(screen, playerOpts)
+	}
 
 	// Draw the particle system
 	p.particleSystem.Draw(screen, cam)
 
-	// Draw item icons
-	iconX, iconY := 10.0, 10.0 // Starting position for icons
-	iconSpacing := 5.0         // Space between icons
+	// Draw item icons, one row per player
+	iconX, iconY := 10.0, 10.0+40*float64(p.index) // Starting position for icons
+	iconSpacing := 5.0                             // Space between icons
 	for _, icon := range p.itemIcons {
 		iconOpts := &ebiten.DrawImageOptions{}
 		iconOpts.GeoM.Scale(0.5, 0.5) // Adjust scale as necessary
@@ -155,14 +199,20 @@ func (p *Player) EquipItem(item interfaces.Item) {
 	}
 	p.currentAnimation = "pick"
 
-	p.animations, _ = animation.LoadAnimations(p.resourceManager, item.GetImagePath(), frameCounts)
+	// The story items of the levels have no image or icon of their own, and
+	// leave the look of the player alone
+	if item.GetImagePath() != "" {
+		p.animations, _ = animation.LoadAnimations(p.resourceManager, item.GetImagePath(), frameCounts)
+	}
 
 	// Load and add the item icon
-	icon, err := p.resourceManager.LoadImage(item.GetIconPath())
-	if err != nil {
-		log.Printf("failed to load item icon: %v", err)
-	} else {
-		p.itemIcons = append(p.itemIcons, icon)
+	if item.GetIconPath() != "" {
+		icon, err := p.resourceManager.LoadImage(item.GetIconPath())
+		if err != nil {
+			log.Printf("failed to load item icon: %v", err)
+		} else {
+			p.itemIcons = append(p.itemIcons, icon)
+		}
 	}
 
 	for _, ability := range item.GetAbilities() {
@@ -170,14 +220,51 @@ func (p *Player) EquipItem(item interfaces.Item) {
 			p.canFly = true
 		}
 	}
+	for damageType, amount := range item.GetResistances() {
+		p.Health.AddResistance(damageType, amount)
+	}
+}
+
+// GetHealth returns the player's health.
+func (p *Player) GetHealth() interfaces.Health {
+	return p.Health
+}
+
+// Damage hurts the player and knocks it away from where the hit came from.
+func (p *Player) Damage(hit interfaces.Hit) float64 {
+	amount := p.Health.TakeDamage(hit)
+	if amount <= 0 {
+		return 0
+	}
+	knockback := hit.Knockback
+	if knockback == 0 {
+		knockback = defaultKnockback
+	}
+	direction := 1.0
+	if hit.From.X > p.Position.X+p.RigidBody.Size.X/2 {
+		direction = -1
+	}
+	p.RigidBody.Velocity = interfaces.Vector2D{X: direction * knockback, Y: -knockback / 2}
+	p.RigidBody.OnGround = false
+	p.knockedBack = knockbackDuration
+	p.particleSystem.AddParticle(p.Position, interfaces.Vector2D{X: direction * 50, Y: -50}, 0.5, 4, color.RGBA{255, 60, 60, 255})
+	return amount
 }
 
 func (p *Player) GetPosition() interfaces.Vector2D {
 	return p.RigidBody.GetPosition()
 }
 
+// GetSize returns the size of the player's body.
+func (p *Player) GetSize() interfaces.Vector2D {
+	return p.RigidBody.GetSize()
+}
+
+// SetPosition moves the player to po and stops it.
 func (p *Player) SetPosition(po interfaces.Vector2D) {
+	p.RigidBody.Teleport(po)
 	p.Position = po
+	p.knockedBack = 0
 }
 
 // registerInputHandlers registers input handlers for the player.
@@ -185,22 +272,22 @@ func (p *Player) registerInputHandlers() {
 	if !p.RigidBody.OnGround && !p.canFly {
 		p.currentAnimation = "jump"
 	}
-	p.EventManager.RegisterHandler("NoKeyPressed", func(event interfaces.Event) {
+	p.EventManager.RegisterHandler(interfaces.PlayerEvent(p.index, "NoKeyPressed"), func(event interfaces.Event) {
 		p.handleStatic()
 	})
-	p.EventManager.RegisterHandler("KeyPressed_32", func(event interfaces.Event) {
+	p.EventManager.RegisterHandler(interfaces.PlayerEvent(p.index, "KeyPressed_32"), func(event interfaces.Event) {
 		p.handleJump()
 	})
-	p.EventManager.RegisterHandler("KeyPressed_87", func(event interfaces.Event) {
+	p.EventManager.RegisterHandler(interfaces.PlayerEvent(p.index, "KeyPressed_87"), func(event interfaces.Event) {
 		p.handleMoveUp()
 	})
-	p.EventManager.RegisterHandler("KeyPressed_65", func(event interfaces.Event) {
+	p.EventManager.RegisterHandler(interfaces.PlayerEvent(p.index, "KeyPressed_65"), func(event interfaces.Event) {
 		p.handleMoveLeft()
 	})
-	p.EventManager.RegisterHandler("KeyPressed_83", func(event interfaces.Event) {
+	p.EventManager.RegisterHandler(interfaces.PlayerEvent(p.index, "KeyPressed_83"), func(event interfaces.Event) {
 		p.handleMoveDown()
 	})
-	p.EventManager.RegisterHandler("KeyPressed_68", func(event interfaces.Event) {
+	p.EventManager.RegisterHandler(interfaces.PlayerEvent(p.index, "KeyPressed_68"), func(event interfaces.Event) {
 		p.handleMoveRight()
 	})
 	p.EventManager.RegisterHandler(interfaces.EventItemEquipped, p.handleEquipItemEvent)
@@ -216,6 +303,10 @@ func (p *Player) handleEquipItemEvent(event interfaces.Event) {
 	if !ok {
 		return
 	}
+	// Only the player who picked the item up equips it
+	if picker, ok := payload["picker"].(string); ok && picker != p.RigidBody.GetIdentifier() {
+		return
+	}
 
 	item, err := p.resourceManager.GetItem(itemName)
 	if err != nil {
@@ -226,6 +317,9 @@ func (p *Player) handleEquipItemEvent(event interfaces.Event) {
 }
 
 func (p *Player) handleJump() {
+	if p.knockedBack > 0 {
+		return
+	}
 	if p.RigidBody.OnGround {
 		p.currentAnimation = "jump"
 		p.RigidBody.Velocity.Y = -p.config.JumpVelocity
@@ -240,6 +334,9 @@ func (p *Player) handleMoveUp() {
 }
 
 func (p *Player) handleMoveLeft() {
+	if p.knockedBack > 0 {
+		return
+	}
 	if p.RigidBody.OnGround {
 		p.currentAnimation = "run"
 		p.rotation = 0
@@ -254,6 +351,9 @@ func (p *Player) handleMoveDown() {
 }
 
 func (p *Player) handleMoveRight() {
+	if p.knockedBack > 0 {
+		return
+	}
 	if p.RigidBody.OnGround {
 		p.currentAnimation = "run"
 		p.rotation = 0
@@ -264,6 +364,9 @@ func (p *Player) handleMoveRight() {
 }
 
 func (p *Player) handleStatic() {
+	if p.knockedBack > 0 {
+		return
+	}
 	if p.RigidBody.OnGround {
 		p.currentAnimation = "idle"
 		p.rotation = 0
@@ -0,0 +1,263 @@
+package score
+
+import (
+	"math"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+)
+
+// Phase is a stage of a match.
+type Phase string
+
+const (
+	// PhasePreMatch waits for the match to start.
+	PhasePreMatch Phase = "PreMatch"
+	// PhaseKickoff pauses with both teams in formation before play starts
+	// or resumes.
+	PhaseKickoff    Phase = "Kickoff"
+	PhaseFirstHalf  Phase = "FirstHalf"
+	PhaseHalftime   Phase = "Halftime"
+	PhaseSecondHalf Phase = "SecondHalf"
+	PhaseExtraTime  Phase = "ExtraTime"
+	// PhaseGoal pauses the match to celebrate a goal.
+	PhaseGoal Phase = "Goal"
+	// PhasePenalties settles a draw with a penalty shootout.
+	PhasePenalties Phase = "Penalties"
+	PhaseFullTime  Phase = "FullTime"
+)
+
+// Rules set how long every part of a match lasts, in seconds.
+type Rules struct {
+	HalfLength      float64
+	HalftimeLength  float64
+	ExtraTimeLength float64 // Played after a draw; 0 goes straight to penalties
+	Penalties       bool    // Whether a draw is settled with a shootout
+	PenaltyRounds   int     // Penalties each team takes before sudden death
+	KickoffPause    float64
+	CelebrationTime float64
+}
+
+// DefaultRules returns the rules of soccer mode.
+func DefaultRules() Rules {
+	return Rules{
+		HalfLength:      45,
+		HalftimeLength:  5,
+		ExtraTimeLength: 30,
+		Penalties:       true,
+		PenaltyRounds:   5,
+		KickoffPause:    2,
+		CelebrationTime: 3,
+	}
+}
+
+// penaltyScore counts the penalties of a team in a shootout.
+type penaltyScore struct {
+	taken  int
+	scored int
+}
+
+// SetRules changes the rules of the next match.
+func (sm *ScoreManager) SetRules(rules Rules) {
+	sm.rules = rules
+}
+
+// SetEventManager reports every phase change to eventManager as an
+// EventMatchPhaseChanged.
+func (sm *ScoreManager) SetEventManager(eventManager interfaces.EventManager) {
+	sm.eventManager = eventManager
+}
+
+// Start starts a new match from the first kickoff.
+func (sm *ScoreManager) Start() {
+	sm.Teams[0].Score = 0
+	sm.Teams[1].Score = 0
+	sm.penalties = [2]penaltyScore{}
+	sm.penaltyTurn = 0
+	sm.kickoffTeam = 0
+	sm.played = 0
+	sm.ActiveMatch = true
+	sm.startPeriod(PhaseFirstHalf, sm.rules.HalfLength)
+}
+
+// Reset waits for a new match to start.
+func (sm *ScoreManager) Reset() {
+	sm.ActiveMatch = false
+	sm.MatchTime = 0
+	sm.enter(PhasePreMatch, 0)
+}
+
+// Phase returns the stage the match is at.
+func (sm *ScoreManager) Phase() Phase {
+	return sm.phase
+}
+
+// InPlay reports whether the ball is in play and the clock running.
+func (sm *ScoreManager) InPlay() bool {
+	switch sm.phase {
+	case PhaseFirstHalf, PhaseSecondHalf, PhaseExtraTime:
+		return true
+	}
+	return false
+}
+
+// Played returns how many seconds the ball has been in play this match.
+func (sm *ScoreManager) Played() float64 {
+	return sm.played
+}
+
+// KickoffTeam returns the team kicking off next.
+func (sm *ScoreManager) KickoffTeam() int {
+	return sm.kickoffTeam
+}
+
+// Update runs the clock for deltaTime seconds, moving on to the next phase
+// when the current one is over.
+func (sm *ScoreManager) Update(deltaTime float64) {
+	switch sm.phase {
+	case PhaseKickoff:
+		if sm.countDown(deltaTime) {
+			sm.enter(sm.period, 0)
+		}
+	case PhaseGoal:
+		if sm.countDown(deltaTime) {
+			sm.enter(PhaseKickoff, sm.rules.KickoffPause)
+		}
+	case PhaseHalftime:
+		if sm.countDown(deltaTime) {
+			sm.startPeriod(PhaseSecondHalf, sm.rules.HalfLength)
+		}
+	case PhaseFirstHalf, PhaseSecondHalf, PhaseExtraTime:
+		sm.played += math.Min(deltaTime, sm.periodLeft)
+		sm.periodLeft = math.Max(sm.periodLeft-deltaTime, 0)
+		sm.MatchTime = int(math.Ceil(sm.periodLeft))
+		if sm.periodLeft == 0 {
+			sm.endPeriod()
+		}
+	}
+}
+
+// TakePenalty records the penalty of the team whose turn it is, ending the
+// shootout once it is decided.
+func (sm *ScoreManager) TakePenalty(scored bool) {
+	if sm.phase != PhasePenalties {
+		return
+	}
+	team := &sm.penalties[sm.penaltyTurn]
+	team.taken++
+	if scored {
+		team.scored++
+	}
+	sm.penaltyTurn = 1 - sm.penaltyTurn
+	if sm.shootoutDecided() {
+		sm.finish()
+	}
+}
+
+// PenaltyTaker returns the team taking the next penalty.
+func (sm *ScoreManager) PenaltyTaker() int {
+	return sm.penaltyTurn
+}
+
+// GetPenalties returns the penalties a team scored and took in the shootout.
+func (sm *ScoreManager) GetPenalties(teamIndex int) (scored, taken int) {
+	if teamIndex < 0 || teamIndex >= len(sm.penalties) {
+		return 0, 0
+	}
+	return sm.penalties[teamIndex].scored, sm.penalties[teamIndex].taken
+}
+
+// Winner returns the team that won the match, by goals or else penalties,
+// once it is over.
+func (sm *ScoreManager) Winner() (int, bool) {
+	if sm.phase != PhaseFullTime {
+		return 0, false
+	}
+	if home, away := sm.GetScore(0), sm.GetScore(1); home != away {
+		return boolIndex(away > home), true
+	}
+	if home, away := sm.penalties[0].scored, sm.penalties[1].scored; home != away {
+		return boolIndex(away > home), true
+	}
+	return 0, false
+}
+
+// startPeriod kicks a period of length seconds off.
+func (sm *ScoreManager) startPeriod(period Phase, length float64) {
+	sm.period = period
+	sm.periodLeft = length
+	sm.MatchTime = int(math.Ceil(length))
+	sm.enter(PhaseKickoff, sm.rules.KickoffPause)
+}
+
+// endPeriod moves on once the clock of a period runs out.
+func (sm *ScoreManager) endPeriod() {
+	draw := sm.GetScore(0) == sm.GetScore(1)
+	switch {
+	case sm.phase == PhaseFirstHalf:
+		// The other team kicks the second half off
+		sm.kickoffTeam = 1
+		sm.enter(PhaseHalftime, sm.rules.HalftimeLength)
+	case draw && sm.phase == PhaseSecondHalf && sm.rules.ExtraTimeLength > 0:
+		sm.kickoffTeam = 0
+		sm.startPeriod(PhaseExtraTime, sm.rules.ExtraTimeLength)
+	case draw && sm.rules.Penalties:
+		sm.penaltyTurn = 0
+		sm.enter(PhasePenalties, 0)
+	default:
+		sm.finish()
+	}
+}
+
+// shootoutDecided reports whether one team has won the shootout: the other
+// cannot catch up within the rounds, or after a round of sudden death.
+func (sm *ScoreManager) shootoutDecided() bool {
+	home, away := sm.penalties[0], sm.penalties[1]
+	rounds := sm.rules.PenaltyRounds
+	if home.taken == away.taken && home.taken >= rounds {
+		return home.scored != away.scored
+	}
+	homeLeft := max(rounds-home.taken, 0)
+	awayLeft := max(rounds-away.taken, 0)
+	return home.scored+homeLeft < away.scored || away.scored+awayLeft < home.scored
+}
+
+// finish blows the final whistle.
+func (sm *ScoreManager) finish() {
+	sm.ActiveMatch = false
+	sm.MatchTime = 0
+	sm.enter(PhaseFullTime, 0)
+}
+
+// countDown runs a pause and reports whether it is over.
+func (sm *ScoreManager) countDown(deltaTime float64) bool {
+	sm.pause -= deltaTime
+	return sm.pause <= 0
+}
+
+// enter moves the match to phase, pausing for pause seconds if the phase is
+// a pause.
+func (sm *ScoreManager) enter(phase Phase, pause float64) {
+	previous := sm.phase
+	sm.phase = phase
+	sm.pause = pause
+	if sm.eventManager == nil || previous == phase {
+		return
+	}
+	sm.eventManager.Dispatch(interfaces.Event{
+		Type:     interfaces.EventMatchPhaseChanged,
+		Priority: 1,
+		Payload: map[string]interface{}{
+			"phase":    string(phase),
+			"previous": string(previous),
+			"home":     sm.GetScore(0),
+			"away":     sm.GetScore(1),
+		},
+	})
+}
+
+func boolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -1,5 +1,7 @@
 package score
 
+import "github.com/joaorufino/gopher-game/internal/interfaces"
+
 type TeamScore struct {
 	Name  string
 	Score int
@@ -8,7 +10,18 @@ type TeamScore struct {
 type ScoreManager struct {
 	Teams       [2]*TeamScore
 	ActiveMatch bool
-	MatchTime   int // In seconds
+	MatchTime   int // Seconds left in the current period
+
+	rules        Rules
+	eventManager interfaces.EventManager
+	phase        Phase
+	period       Phase   // The period the next kickoff starts or resumes
+	periodLeft   float64 // Seconds left in the current period
+	played       float64 // Seconds the ball has been in play this match
+	pause        float64 // Seconds left of a kickoff, goal or halftime pause
+	kickoffTeam  int
+	penalties    [2]penaltyScore
+	penaltyTurn  int
 }
 
 func NewScoreManager() *ScoreManager {
@@ -19,13 +32,20 @@ func NewScoreManager() *ScoreManager {
 		},
 		ActiveMatch: false,
 		MatchTime:   0,
+		rules:       DefaultRules(),
+		phase:       PhasePreMatch,
 	}
 }
 
+// AddGoal scores a goal for the team while the ball is in play, pausing the
+// match to celebrate before the other team kicks off.
 func (sm *ScoreManager) AddGoal(teamIndex int) {
-	if teamIndex >= 0 && teamIndex < len(sm.Teams) {
-		sm.Teams[teamIndex].Score++
+	if teamIndex < 0 || teamIndex >= len(sm.Teams) || !sm.InPlay() {
+		return
 	}
+	sm.Teams[teamIndex].Score++
+	sm.kickoffTeam = 1 - teamIndex
+	sm.enter(PhaseGoal, sm.rules.CelebrationTime)
 }
 
 func (sm *ScoreManager) GetScore(teamIndex int) int {
@@ -48,31 +68,26 @@ func (sm *ScoreManager) SetTeamName(teamIndex int, name string) {
 	}
 }
 
+// StartMatch starts a match of matchDuration seconds, split into two halves,
+// from the first kickoff.
 func (sm *ScoreManager) StartMatch(matchDuration int) {
-	sm.Teams[0].Score = 0
-	sm.Teams[1].Score = 0
-	sm.ActiveMatch = true
-	sm.MatchTime = matchDuration
+	sm.rules.HalfLength = float64(matchDuration) / 2
+	sm.Start()
 }
 
+// UpdateMatchTime runs the match for deltaSeconds and reports whether it is
+// still going.
 func (sm *ScoreManager) UpdateMatchTime(deltaSeconds int) bool {
-	if !sm.ActiveMatch {
-		return false
-	}
-
-	sm.MatchTime -= deltaSeconds
-	if sm.MatchTime <= 0 {
-		sm.MatchTime = 0
-		sm.ActiveMatch = false
-		return false
-	}
-	return true
+	sm.Update(float64(deltaSeconds))
+	return sm.ActiveMatch
 }
 
 func (sm *ScoreManager) GetMatchTime() int {
 	return sm.MatchTime
 }
 
+// IsMatchActive reports whether a match is going on, from its first kickoff
+// until full time.
 func (sm *ScoreManager) IsMatchActive() bool {
 	return sm.ActiveMatch
 }
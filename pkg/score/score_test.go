@@ -0,0 +1,157 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestScore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Score Suite")
+}
+
+type recordedEvents struct {
+	events []interfaces.Event
+}
+
+func (r *recordedEvents) RegisterHandler(interfaces.EventType, interfaces.EventHandler) {}
+func (r *recordedEvents) Dispatch(event interfaces.Event)                               { r.events = append(r.events, event) }
+func (r *recordedEvents) Wait()                                                         {}
+
+// phases returns the phases the match went through, in order.
+func (r *recordedEvents) phases() []string {
+	var phases []string
+	for _, event := range r.events {
+		phases = append(phases, event.Payload.(map[string]interface{})["phase"].(string))
+	}
+	return phases
+}
+
+var _ = Describe("ScoreManager", func() {
+	var (
+		events *recordedEvents
+		match  *ScoreManager
+	)
+
+	// play runs the match for seconds, one second at a time.
+	play := func(seconds int) {
+		for i := 0; i < seconds; i++ {
+			match.Update(1)
+		}
+	}
+
+	BeforeEach(func() {
+		events = &recordedEvents{}
+		match = NewScoreManager()
+		match.SetEventManager(events)
+		match.SetRules(Rules{
+			HalfLength:      10,
+			HalftimeLength:  3,
+			ExtraTimeLength: 5,
+			Penalties:       true,
+			PenaltyRounds:   3,
+			KickoffPause:    1,
+			CelebrationTime: 2,
+		})
+	})
+
+	It("should wait for the match to start", func() {
+		Expect(match.Phase()).To(Equal(PhasePreMatch))
+		match.AddGoal(0)
+		play(100)
+		Expect(match.GetScore(0)).To(Equal(0))
+		Expect(match.IsMatchActive()).To(BeFalse())
+	})
+
+	It("should play two halves with halftime in between", func() {
+		match.Start()
+		Expect(match.Phase()).To(Equal(PhaseKickoff))
+		Expect(match.InPlay()).To(BeFalse())
+
+		play(1)
+		Expect(match.Phase()).To(Equal(PhaseFirstHalf))
+		play(4)
+		Expect(match.GetMatchTime()).To(Equal(6))
+
+		match.AddGoal(1)
+		play(6)
+		play(10)
+		play(3)
+		Expect(match.KickoffTeam()).To(Equal(1))
+		play(1 + 10)
+
+		Expect(match.Phase()).To(Equal(PhaseFullTime))
+		Expect(events.phases()).To(Equal([]string{
+			"Kickoff", "FirstHalf", "Goal", "Kickoff", "FirstHalf", "Halftime", "Kickoff", "SecondHalf", "FullTime",
+		}))
+		winner, won := match.Winner()
+		Expect(won).To(BeTrue())
+		Expect(winner).To(Equal(1))
+		Expect(match.IsMatchActive()).To(BeFalse())
+	})
+
+	It("should stop the clock while celebrating a goal", func() {
+		match.Start()
+		play(3)
+		match.AddGoal(0)
+		Expect(match.Phase()).To(Equal(PhaseGoal))
+		Expect(match.KickoffTeam()).To(Equal(1))
+
+		match.AddGoal(0)
+		play(3)
+		Expect(match.GetScore(0)).To(Equal(1))
+		Expect(match.GetMatchTime()).To(Equal(8))
+		Expect(match.Played()).To(Equal(2.0))
+	})
+
+	It("should settle a draw with extra time and penalties", func() {
+		match.Start()
+		play(1 + 10 + 3 + 1 + 10)
+		Expect(match.Phase()).To(Equal(PhaseKickoff))
+		play(1 + 5)
+		Expect(match.Phase()).To(Equal(PhasePenalties))
+
+		// Home scores all three, away misses the second
+		for _, scored := range []bool{true, true, true, false} {
+			match.TakePenalty(scored)
+		}
+		Expect(match.Phase()).To(Equal(PhasePenalties))
+		match.TakePenalty(true)
+		Expect(match.Phase()).To(Equal(PhaseFullTime))
+		scored, taken := match.GetPenalties(0)
+		Expect(scored).To(Equal(3))
+		Expect(taken).To(Equal(3))
+		winner, won := match.Winner()
+		Expect(won).To(BeTrue())
+		Expect(winner).To(Equal(0))
+	})
+
+	It("should go to sudden death when the shootout is level", func() {
+		match.SetRules(Rules{HalfLength: 1, Penalties: true, PenaltyRounds: 1})
+		match.Start()
+		// Every pause and half takes a single update
+		play(5)
+		Expect(match.Phase()).To(Equal(PhasePenalties))
+
+		match.TakePenalty(true)
+		match.TakePenalty(true)
+		Expect(match.Phase()).To(Equal(PhasePenalties))
+		match.TakePenalty(false)
+		Expect(match.PenaltyTaker()).To(Equal(1))
+		match.TakePenalty(true)
+		winner, _ := match.Winner()
+		Expect(winner).To(Equal(1))
+	})
+
+	It("should end in a draw without extra time or penalties", func() {
+		match.SetRules(Rules{HalfLength: 1})
+		match.Start()
+		play(10)
+		Expect(match.Phase()).To(Equal(PhaseFullTime))
+		_, won := match.Winner()
+		Expect(won).To(BeFalse())
+	})
+})
@@ -0,0 +1,246 @@
+// Package spawner brings enemies into a level over time: at a steady rate,
+// or in waves the player has to clear one after another, keeping at most a
+// number of them alive at once.
+package spawner
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+)
+
+const (
+	defaultRate     = 1.0 // Enemies spawned per second
+	defaultMaxAlive = 3   // Enemies of a spawner alive at once
+)
+
+// Condition is what a spawner waits for before it starts. With neither a
+// trigger nor a logic object it starts with the level.
+type Condition struct {
+	// Trigger is the name of a trigger of the level the player has to enter.
+	Trigger string `json:"trigger,omitempty"`
+	// Logic is the id of a logic object of the level that has to turn on.
+	Logic string `json:"logic,omitempty"`
+	// Delay is how many seconds the spawner waits once the condition is met.
+	Delay float64 `json:"delay,omitempty"`
+}
+
+// Wave is a number of enemies the player has to defeat before the next wave
+// comes.
+type Wave struct {
+	Count int `json:"count"`
+	// Rate is how many enemies of the wave spawn per second; zero keeps the
+	// rate of the spawner.
+	Rate float64 `json:"rate,omitempty"`
+	// Delay is how many seconds the player rests before the wave.
+	Delay float64 `json:"delay,omitempty"`
+}
+
+// Config describes a spawner. Fields left at zero take the defaults.
+type Config struct {
+	Name string
+	// Area is where enemies spawn, anywhere an enemy of Size fits.
+	Area core.Rect
+	Size core.Vector2D
+	// Rate is how many enemies spawn per second.
+	Rate float64
+	// MaxAlive is how many enemies of the spawner are alive at once.
+	MaxAlive int
+	// Waves are spawned one after another; without waves the spawner keeps
+	// spawning for as long as the level lasts.
+	Waves []Wave
+	After Condition
+	Seed  int64
+}
+
+// Spawner decides when and where enemies spawn. The game creates them and
+// tells the spawner about them with Add, and about their end with Remove.
+type Spawner struct {
+	config  Config
+	senses  core.SpawnSenses
+	rng     *rand.Rand
+	started bool
+	active  bool    // A wave, or the endless spawning, is under way
+	wait    float64 // Seconds until the next wave
+	spawnIn float64 // Seconds until the next enemy
+	wave    int     // Index of the current wave
+	spawned int     // Enemies spawned in the current wave
+	alive   map[core.AIAgent]bool
+}
+
+// New creates a spawner that learns whether its condition is met through
+// senses.
+func New(config Config, senses core.SpawnSenses) *Spawner {
+	if config.Rate <= 0 {
+		config.Rate = defaultRate
+	}
+	if config.MaxAlive <= 0 {
+		config.MaxAlive = defaultMaxAlive
+	}
+	return &Spawner{
+		config: config,
+		senses: senses,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+		alive:  map[core.AIAgent]bool{},
+	}
+}
+
+// Name returns the name of the spawner.
+func (s *Spawner) Name() string {
+	return s.config.Name
+}
+
+// Started reports whether the condition of the spawner has been met.
+func (s *Spawner) Started() bool {
+	return s.started
+}
+
+// Done reports whether every wave has been cleared. A spawner without waves
+// is never done.
+func (s *Spawner) Done() bool {
+	return len(s.config.Waves) > 0 && s.wave >= len(s.config.Waves)
+}
+
+// Wave returns the number of the wave under way or coming next, from 1, or
+// 0 for a spawner without waves.
+func (s *Spawner) Wave() int {
+	if s.Done() {
+		return len(s.config.Waves)
+	}
+	if len(s.config.Waves) == 0 {
+		return 0
+	}
+	return s.wave + 1
+}
+
+// Alive returns how many enemies of the spawner are alive.
+func (s *Spawner) Alive() int {
+	return len(s.alive)
+}
+
+// Add tells the spawner about an enemy it spawned.
+func (s *Spawner) Add(agent core.AIAgent) {
+	s.alive[agent] = true
+}
+
+// Owns reports whether the enemy was spawned by the spawner and is alive.
+func (s *Spawner) Owns(agent core.AIAgent) bool {
+	return s.alive[agent]
+}
+
+// Remove tells the spawner an enemy of its own is gone, and reports whether
+// it was. A defeated enemy counts toward clearing its wave; one that was
+// only taken away, e.g. for being off screen, spawns again.
+func (s *Spawner) Remove(agent core.AIAgent, defeated bool) bool {
+	if !s.alive[agent] {
+		return false
+	}
+	delete(s.alive, agent)
+	if !defeated && s.active && s.spawned > 0 {
+		s.spawned--
+	}
+	return true
+}
+
+// Update advances the spawner by deltaTime seconds. It returns where the
+// enemies to spawn go, by their top left corner, and the wave events to
+// dispatch.
+func (s *Spawner) Update(deltaTime float64) ([]core.Vector2D, []core.Event) {
+	if !s.started {
+		if !s.conditionMet() {
+			return nil, nil
+		}
+		s.started = true
+		s.wait = s.config.After.Delay + s.rest()
+	}
+	if s.Done() {
+		return nil, nil
+	}
+
+	var events []core.Event
+	if !s.active {
+		s.wait -= deltaTime
+		if s.wait > 0 {
+			return nil, nil
+		}
+		s.active, s.spawned, s.spawnIn = true, 0, 0
+		if len(s.config.Waves) > 0 {
+			events = append(events, s.event(core.EventWaveStarted))
+		}
+	}
+
+	var spawns []core.Vector2D
+	s.spawnIn -= deltaTime
+	for s.spawnIn <= 0 && len(s.alive)+len(spawns) < s.config.MaxAlive && s.left() > 0 {
+		spawns = append(spawns, s.position())
+		s.spawned++
+		s.spawnIn += 1 / s.rate()
+	}
+	// Spawns do not pile up while the spawner is full
+	s.spawnIn = math.Max(s.spawnIn, 0)
+
+	if len(s.config.Waves) > 0 && s.left() == 0 && len(s.alive) == 0 && len(spawns) == 0 {
+		events = append(events, s.event(core.EventWaveCleared))
+		s.wave++
+		s.active = false
+		s.wait = s.rest()
+		if s.Done() {
+			events = append(events, s.event(core.EventWavesComplete))
+		}
+	}
+	return spawns, events
+}
+
+// conditionMet reports whether the player entered the trigger and the logic
+// object is on, for those the spawner waits for.
+func (s *Spawner) conditionMet() bool {
+	after := s.config.After
+	if after.Trigger != "" && (s.senses == nil || !s.senses.InTrigger(after.Trigger)) {
+		return false
+	}
+	if after.Logic != "" && (s.senses == nil || !s.senses.LogicOn(after.Logic)) {
+		return false
+	}
+	return true
+}
+
+// left returns how many enemies the current wave still has to spawn.
+func (s *Spawner) left() int {
+	if len(s.config.Waves) == 0 {
+		return math.MaxInt32
+	}
+	return s.config.Waves[s.wave].Count - s.spawned
+}
+
+// rate returns how many enemies spawn per second in the current wave.
+func (s *Spawner) rate() float64 {
+	if len(s.config.Waves) > 0 && s.config.Waves[s.wave].Rate > 0 {
+		return s.config.Waves[s.wave].Rate
+	}
+	return s.config.Rate
+}
+
+// rest returns the seconds before the current wave.
+func (s *Spawner) rest() float64 {
+	if s.Done() || len(s.config.Waves) == 0 {
+		return 0
+	}
+	return s.config.Waves[s.wave].Delay
+}
+
+// position picks where in the area an enemy spawns.
+func (s *Spawner) position() core.Vector2D {
+	area := s.config.Area
+	return core.Vector2D{
+		X: area.Position.X + s.rng.Float64()*math.Max(area.Size.X-s.config.Size.X, 0),
+		Y: area.Position.Y + s.rng.Float64()*math.Max(area.Size.Y-s.config.Size.Y, 0),
+	}
+}
+
+func (s *Spawner) event(kind core.EventType) core.Event {
+	return core.Event{
+		Type:    kind,
+		Payload: map[string]interface{}{"spawner": s.config.Name, "wave": s.Wave(), "waves": len(s.config.Waves)},
+	}
+}
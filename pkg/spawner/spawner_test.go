@@ -0,0 +1,152 @@
+package spawner
+
+import (
+	"testing"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSpawner(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Spawner Suite")
+}
+
+const frame = 1.0 / 60
+
+// fakeSenses sets which triggers the player is in and which logic objects
+// are on.
+type fakeSenses struct {
+	triggers map[string]bool
+	logic    map[string]bool
+}
+
+func (f *fakeSenses) InTrigger(name string) bool { return f.triggers[name] }
+func (f *fakeSenses) LogicOn(id string) bool     { return f.logic[id] }
+
+// fakeAgent stands in for a spawned enemy.
+type fakeAgent struct {
+	core.AIAgent
+	id int
+}
+
+var _ = Describe("Spawner", func() {
+	var (
+		senses *fakeSenses
+		agents []*fakeAgent
+		events []core.Event
+	)
+
+	area := core.Rect{Position: core.Vector2D{X: 100, Y: 50}, Size: core.Vector2D{X: 200, Y: 100}}
+
+	// run updates the spawner for some seconds, adding the enemies it
+	// spawns, and returns how many it spawned.
+	run := func(spawner *Spawner, seconds float64) int {
+		spawned := 0
+		for t := 0.0; t < seconds; t += frame {
+			positions, happened := spawner.Update(frame)
+			events = append(events, happened...)
+			for range positions {
+				agent := &fakeAgent{id: len(agents)}
+				agents = append(agents, agent)
+				spawner.Add(agent)
+				spawned++
+			}
+		}
+		return spawned
+	}
+	kinds := func() []core.EventType {
+		var types []core.EventType
+		for _, event := range events {
+			types = append(types, event.Type)
+		}
+		return types
+	}
+
+	BeforeEach(func() {
+		senses = &fakeSenses{triggers: map[string]bool{}, logic: map[string]bool{}}
+		agents, events = nil, nil
+	})
+
+	It("should spawn at its rate inside its area up to the enemies alive at once", func() {
+		spawner := New(Config{Area: area, Size: core.Vector2D{X: 32, Y: 32}, Rate: 2, MaxAlive: 3}, senses)
+		for t := 0.0; t < 0.9; t += frame {
+			positions, _ := spawner.Update(frame)
+			for _, position := range positions {
+				Expect(position.X).To(BeNumerically(">=", 100))
+				Expect(position.X + 32).To(BeNumerically("<=", 300))
+				Expect(position.Y).To(BeNumerically(">=", 50))
+				Expect(position.Y + 32).To(BeNumerically("<=", 150))
+				agent := &fakeAgent{id: len(agents)}
+				agents = append(agents, agent)
+				spawner.Add(agent)
+			}
+		}
+		Expect(agents).To(HaveLen(2))
+		Expect(run(spawner, 5)).To(Equal(1))
+		Expect(spawner.Alive()).To(Equal(3))
+
+		Expect(spawner.Remove(agents[0], true)).To(BeTrue())
+		Expect(spawner.Remove(agents[0], true)).To(BeFalse())
+		Expect(run(spawner, frame)).To(Equal(1))
+		Expect(spawner.Done()).To(BeFalse())
+		Expect(events).To(BeEmpty())
+	})
+
+	It("should wait for the player to enter its trigger", func() {
+		spawner := New(Config{Area: area, After: Condition{Trigger: "ambush", Delay: 1}}, senses)
+		Expect(run(spawner, 2)).To(BeZero())
+		Expect(spawner.Started()).To(BeFalse())
+
+		senses.triggers["ambush"] = true
+		Expect(run(spawner, frame)).To(BeZero())
+		Expect(spawner.Started()).To(BeTrue())
+		// It keeps going once the player leaves the trigger
+		senses.triggers["ambush"] = false
+		Expect(run(spawner, 0.9)).To(BeZero())
+		Expect(run(spawner, 0.2)).To(Equal(1))
+	})
+
+	It("should wait for a logic object to turn on", func() {
+		spawner := New(Config{Area: area, After: Condition{Logic: "alarm"}}, senses)
+		Expect(run(spawner, 1)).To(BeZero())
+		senses.logic["alarm"] = true
+		Expect(run(spawner, frame)).To(Equal(1))
+	})
+
+	It("should send the next wave once the player clears the current one", func() {
+		spawner := New(Config{Area: area, Rate: 10, MaxAlive: 5, Waves: []Wave{{Count: 2}, {Count: 3, Delay: 2}}}, senses)
+		Expect(run(spawner, 1)).To(Equal(2))
+		Expect(spawner.Wave()).To(Equal(1))
+		Expect(kinds()).To(Equal([]core.EventType{core.EventWaveStarted}))
+
+		spawner.Remove(agents[0], true)
+		spawner.Remove(agents[1], true)
+		Expect(run(spawner, 1)).To(BeZero())
+		Expect(spawner.Wave()).To(Equal(2))
+		Expect(kinds()).To(Equal([]core.EventType{core.EventWaveStarted, core.EventWaveCleared}))
+
+		Expect(run(spawner, 1.5)).To(Equal(3))
+		Expect(events[2].Payload).To(Equal(map[string]interface{}{"spawner": "", "wave": 2, "waves": 2}))
+		for _, agent := range agents[2:] {
+			spawner.Remove(agent, true)
+		}
+		run(spawner, frame)
+		Expect(spawner.Done()).To(BeTrue())
+		Expect(kinds()[3:]).To(Equal([]core.EventType{core.EventWaveCleared, core.EventWavesComplete}))
+		Expect(events[4].Payload).To(HaveKeyWithValue("wave", 2))
+		Expect(run(spawner, 5)).To(BeZero())
+	})
+
+	It("should spawn an enemy again when it is taken away rather than defeated", func() {
+		spawner := New(Config{Area: area, Rate: 10, Waves: []Wave{{Count: 1}}}, senses)
+		Expect(run(spawner, 1)).To(Equal(1))
+		spawner.Remove(agents[0], false)
+		Expect(run(spawner, 1)).To(Equal(1))
+		Expect(spawner.Done()).To(BeFalse())
+		spawner.Remove(agents[1], true)
+		run(spawner, frame)
+		Expect(spawner.Done()).To(BeTrue())
+	})
+})
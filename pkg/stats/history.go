@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/joaorufino/gopher-game/internal/utils"
+)
+
+// HistoryKey is where the match history is stored: the browser's local
+// storage, or a file in the user's config directory.
+const HistoryKey = "gopher-game-matches"
+
+// historyLimit is how many matches the history keeps.
+const historyLimit = 20
+
+// Match is a match in the history.
+type Match struct {
+	Played  time.Time `json:"played"`
+	Summary Summary   `json:"summary"`
+}
+
+// History lists the last matches played, the latest last.
+type History struct {
+	Matches []Match `json:"matches"`
+}
+
+// ParseHistory reads a history stored as JSON.
+func ParseHistory(data []byte) (*History, error) {
+	history := &History{}
+	if len(data) == 0 {
+		return history, nil
+	}
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to parse match history: %w", err)
+	}
+	return history, nil
+}
+
+// LoadHistory reads the stored match history, which is empty the first time.
+func LoadHistory() (*History, error) {
+	data, err := utils.ReadStorage(HistoryKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHistory(data)
+}
+
+// Add puts a match played at the given time at the end of the history,
+// dropping the oldest ones past the limit.
+func (h *History) Add(played time.Time, summary Summary) {
+	h.Matches = append(h.Matches, Match{Played: played, Summary: summary})
+	if len(h.Matches) > historyLimit {
+		h.Matches = h.Matches[len(h.Matches)-historyLimit:]
+	}
+}
+
+// Record returns how many of the matches in the history team won, drew and
+// lost.
+func (h *History) Record(team string) (won, drawn, lost int) {
+	for _, match := range h.Matches {
+		teams := match.Summary.Teams
+		for i, stats := range teams {
+			if stats.Team != team {
+				continue
+			}
+			switch other := teams[1-i].Score; {
+			case stats.Score > other:
+				won++
+			case stats.Score < other:
+				lost++
+			default:
+				drawn++
+			}
+		}
+	}
+	return won, drawn, lost
+}
+
+// Save stores the history.
+func (h *History) Save() error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to encode match history: %w", err)
+	}
+	return utils.WriteStorage(HistoryKey, data)
+}
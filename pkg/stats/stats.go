@@ -0,0 +1,303 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/kick"
+	"github.com/joaorufino/gopher-game/pkg/score"
+)
+
+// Stats are the numbers of a team or a player over a match.
+type Stats struct {
+	Possession    float64 `json:"possession"` // Seconds in control of the ball
+	Shots         int     `json:"shots"`
+	ShotsOnTarget int     `json:"shotsOnTarget"`
+	Passes        int     `json:"passes"`   // Kicks a teammate kicked next
+	Distance      float64 `json:"distance"` // Pixels run while the ball was in play
+	Goals         int     `json:"goals"`
+}
+
+// add adds the numbers of other to s.
+func (s *Stats) add(other Stats) {
+	s.Possession += other.Possession
+	s.Shots += other.Shots
+	s.ShotsOnTarget += other.ShotsOnTarget
+	s.Passes += other.Passes
+	s.Distance += other.Distance
+	s.Goals += other.Goals
+}
+
+// TeamStats are the numbers of a team over a match.
+type TeamStats struct {
+	Team  string `json:"team"`
+	Score int    `json:"score"`
+	Stats
+}
+
+// PlayerStats are the numbers of a player over a match.
+type PlayerStats struct {
+	Team   string `json:"team"`
+	Player string `json:"player"`
+	Stats
+}
+
+// Goal is a goal on the timeline of a match.
+type Goal struct {
+	Team    string  `json:"team"`    // The team the goal counts for
+	Scorer  string  `json:"scorer"`  // The player who kicked the ball last
+	OwnGoal bool    `json:"ownGoal"` // Whether the scorer plays for the other team
+	Time    float64 `json:"time"`    // Seconds of play before the goal
+}
+
+// Summary sums a match up.
+type Summary struct {
+	Teams    [2]TeamStats  `json:"teams"` // Home first
+	Players  []PlayerStats `json:"players"`
+	Goals    []Goal        `json:"goals"`
+	Duration float64       `json:"duration"` // Seconds the ball was in play
+	Finished bool          `json:"finished"`
+}
+
+// Possession returns the share of the possession of the team with the given
+// index, from 0 to 1.
+func (s Summary) Possession(teamIndex int) float64 {
+	total := s.Teams[0].Possession + s.Teams[1].Possession
+	if total == 0 {
+		return 0.5
+	}
+	return s.Teams[teamIndex].Possession / total
+}
+
+// touch is the last kick of the ball.
+type touch struct {
+	team   string
+	player string
+}
+
+// Collector follows the kicks and goals of a match and adds up the stats of
+// both teams and their players. Kicks and goals come in as events; the game
+// reports the time the ball is in play with Update and where the players run
+// with Track.
+//
+// Events are handled concurrently, so every method locks the collector.
+type Collector struct {
+	mu        sync.Mutex
+	teams     [2]string
+	goals     map[string]interfaces.Rect // The goal each team defends
+	players   map[string]*PlayerStats
+	positions map[string]interfaces.Vector2D
+	last      touch
+	score     [2]int
+	timeline  []Goal
+	played    float64
+	finished  bool
+}
+
+// NewCollector creates a collector for a match between the home and the
+// away team.
+func NewCollector(home, away string) *Collector {
+	c := &Collector{
+		teams: [2]string{home, away},
+		goals: make(map[string]interfaces.Rect),
+	}
+	c.Reset()
+	return c
+}
+
+// SetGoal sets the goal team defends, to tell shots on target apart.
+func (c *Collector) SetGoal(team string, goal interfaces.Rect) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.goals[team] = goal
+}
+
+// RegisterHandlers makes the collector follow the kicks and phases of the
+// match dispatched to eventManager.
+func (c *Collector) RegisterHandlers(eventManager interfaces.EventManager) {
+	eventManager.RegisterHandler(interfaces.EventBallKicked, c.HandleKick)
+	eventManager.RegisterHandler(interfaces.EventMatchPhaseChanged, c.HandlePhase)
+}
+
+// Reset forgets the match so far.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.players = make(map[string]*PlayerStats)
+	c.positions = make(map[string]interfaces.Vector2D)
+	c.last = touch{}
+	c.score = [2]int{}
+	c.timeline = nil
+	c.played = 0
+	c.finished = false
+}
+
+// HandleKick counts a kick reported by an EventBallKicked: a pass for the
+// player who kicked the ball last if a teammate kicked it now, and a shot if
+// the AI meant one or the ball is headed into the goal.
+func (c *Collector) HandleKick(event interfaces.Event) {
+	payload, ok := event.Payload.(map[string]interface{})
+	if !ok {
+		return
+	}
+	team, _ := payload["team"].(string)
+	player, _ := payload["player"].(string)
+	kind, _ := payload["kind"].(string)
+	position, _ := payload["position"].(interfaces.Vector2D)
+	velocity, _ := payload["velocity"].(interfaces.Vector2D)
+	if team == "" || player == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.last.team == team && c.last.player != player {
+		c.player(c.last.team, c.last.player).Passes++
+	}
+	c.last = touch{team: team, player: player}
+
+	stats := c.player(team, player)
+	onTarget := c.onTarget(team, position, velocity)
+	if kind == kick.KindShot || onTarget {
+		stats.Shots++
+		if onTarget {
+			stats.ShotsOnTarget++
+		}
+	}
+}
+
+// HandlePhase follows the match reported by an EventMatchPhaseChanged: it
+// starts over at the first kickoff, puts goals on the timeline and stops at
+// full time.
+func (c *Collector) HandlePhase(event interfaces.Event) {
+	payload, ok := event.Payload.(map[string]interface{})
+	if !ok {
+		return
+	}
+	phase, _ := payload["phase"].(string)
+	previous, _ := payload["previous"].(string)
+	if score.Phase(phase) == score.PhaseKickoff && (score.Phase(previous) == score.PhasePreMatch || score.Phase(previous) == score.PhaseFullTime) {
+		c.Reset()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if played, ok := payload["time"].(float64); ok {
+		c.played = played
+	}
+	switch score.Phase(phase) {
+	case score.PhaseKickoff:
+		// Everyone lines up; that is no running
+		c.positions = make(map[string]interfaces.Vector2D)
+	case score.PhaseGoal:
+		home, _ := payload["home"].(int)
+		away, _ := payload["away"].(int)
+		for i, goals := range [2]int{home, away} {
+			for c.score[i] < goals {
+				c.score[i]++
+				c.addGoal(c.teams[i])
+			}
+		}
+	case score.PhaseFullTime:
+		c.finished = true
+	}
+}
+
+// addGoal puts a goal for team on the timeline, scored by whoever kicked the
+// ball last.
+func (c *Collector) addGoal(team string) {
+	goal := Goal{Team: team, Scorer: c.last.player, Time: c.played}
+	if c.last.player != "" {
+		goal.OwnGoal = c.last.team != team
+		if !goal.OwnGoal {
+			c.player(c.last.team, c.last.player).Goals++
+		}
+	}
+	c.timeline = append(c.timeline, goal)
+}
+
+// Update adds deltaTime seconds of possession to the team and the player
+// that kicked the ball last. Call it only while the ball is in play.
+func (c *Collector) Update(deltaTime float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.last.player == "" {
+		return
+	}
+	c.player(c.last.team, c.last.player).Possession += deltaTime
+}
+
+// Track adds the way player of team ran since it was last tracked to the
+// distance it covered. Call it only while the ball is in play.
+func (c *Collector) Track(team, player string, position interfaces.Vector2D) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if last, ok := c.positions[player]; ok {
+		c.player(team, player).Distance += math.Hypot(position.X-last.X, position.Y-last.Y)
+	}
+	c.positions[player] = position
+}
+
+// Summary sums the match so far up.
+func (c *Collector) Summary() Summary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	summary := Summary{
+		Goals:    append([]Goal(nil), c.timeline...),
+		Duration: c.played,
+		Finished: c.finished,
+	}
+	for i, team := range c.teams {
+		summary.Teams[i] = TeamStats{Team: team, Score: c.score[i]}
+	}
+	for _, stats := range c.players {
+		summary.Players = append(summary.Players, *stats)
+		for i, team := range c.teams {
+			if stats.Team == team {
+				summary.Teams[i].add(stats.Stats)
+			}
+		}
+	}
+	sort.Slice(summary.Players, func(i, j int) bool {
+		a, b := summary.Players[i], summary.Players[j]
+		if a.Team != b.Team {
+			return a.Team < b.Team
+		}
+		return a.Player < b.Player
+	})
+	return summary
+}
+
+// player returns the stats of player, adding it to team the first time.
+func (c *Collector) player(team, player string) *PlayerStats {
+	stats, ok := c.players[player]
+	if !ok {
+		stats = &PlayerStats{Team: team, Player: player}
+		c.players[player] = stats
+	}
+	return stats
+}
+
+// onTarget reports whether a ball kicked by team from position with velocity
+// goes into the goal of the other team, were it to fly straight.
+func (c *Collector) onTarget(team string, position, velocity interfaces.Vector2D) bool {
+	var goal interfaces.Rect
+	found := false
+	for _, other := range c.teams {
+		if other != team {
+			goal, found = c.goals[other]
+		}
+	}
+	if !found || velocity.X == 0 {
+		return false
+	}
+	goalX := goal.Position.X + goal.Size.X/2
+	t := (goalX - position.X) / velocity.X
+	if t <= 0 {
+		return false
+	}
+	y := position.Y + velocity.Y*t
+	return y >= goal.Position.Y && y <= goal.Position.Y+goal.Size.Y
+}
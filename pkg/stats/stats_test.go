@@ -0,0 +1,158 @@
+package stats
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/kick"
+	"github.com/joaorufino/gopher-game/pkg/score"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestStats(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Stats Suite")
+}
+
+var _ = Describe("Collector", func() {
+	var collector *Collector
+
+	kickBall := func(team, player, kind string, position, velocity interfaces.Vector2D) {
+		collector.HandleKick(kick.Event(team, player, kind, position, velocity))
+	}
+	enter := func(phase, previous score.Phase, home, away int, played float64) {
+		collector.HandlePhase(interfaces.Event{
+			Type: interfaces.EventMatchPhaseChanged,
+			Payload: map[string]interface{}{
+				"phase":    string(phase),
+				"previous": string(previous),
+				"home":     home,
+				"away":     away,
+				"time":     played,
+			},
+		})
+	}
+
+	BeforeEach(func() {
+		collector = NewCollector("blue", "red")
+		collector.SetGoal("blue", interfaces.Rect{Position: interfaces.Vector2D{X: 0, Y: 225}, Size: interfaces.Vector2D{X: 20, Y: 150}})
+		collector.SetGoal("red", interfaces.Rect{Position: interfaces.Vector2D{X: 780, Y: 225}, Size: interfaces.Vector2D{X: 20, Y: 150}})
+		enter(score.PhaseKickoff, score.PhasePreMatch, 0, 0, 0)
+	})
+
+	It("should count passes between teammates", func() {
+		kickBall("blue", "blue_6", kick.KindPass, interfaces.Vector2D{X: 300, Y: 100}, interfaces.Vector2D{X: 0, Y: 100})
+		kickBall("blue", "blue_9", kick.KindDribble, interfaces.Vector2D{X: 300, Y: 200}, interfaces.Vector2D{X: 0, Y: 100})
+		kickBall("blue", "blue_9", kick.KindDribble, interfaces.Vector2D{X: 300, Y: 250}, interfaces.Vector2D{X: 0, Y: 100})
+		kickBall("red", "red_4", kick.KindClear, interfaces.Vector2D{X: 300, Y: 300}, interfaces.Vector2D{X: 0, Y: 100})
+
+		summary := collector.Summary()
+		Expect(summary.Teams[0].Passes).To(Equal(1))
+		Expect(summary.Teams[1].Passes).To(Equal(0))
+	})
+
+	It("should tell shots on target from shots wide", func() {
+		kickBall("blue", "blue_9", kick.KindShot, interfaces.Vector2D{X: 600, Y: 300}, interfaces.Vector2D{X: 400, Y: 0})
+		kickBall("blue", "blue_9", kick.KindShot, interfaces.Vector2D{X: 600, Y: 300}, interfaces.Vector2D{X: 400, Y: -400})
+		// A kick of a player into the goal is a shot too
+		kickBall("red", "player2", kick.KindKick, interfaces.Vector2D{X: 200, Y: 300}, interfaces.Vector2D{X: -300, Y: 0})
+		kickBall("red", "player2", kick.KindKick, interfaces.Vector2D{X: 200, Y: 300}, interfaces.Vector2D{X: 300, Y: 0})
+
+		summary := collector.Summary()
+		Expect(summary.Teams[0].Shots).To(Equal(2))
+		Expect(summary.Teams[0].ShotsOnTarget).To(Equal(1))
+		Expect(summary.Teams[1].Shots).To(Equal(1))
+		Expect(summary.Teams[1].ShotsOnTarget).To(Equal(1))
+	})
+
+	It("should give possession to whoever kicked the ball last", func() {
+		collector.Update(1)
+		kickBall("blue", "blue_9", kick.KindDribble, interfaces.Vector2D{}, interfaces.Vector2D{X: 0, Y: 1})
+		collector.Update(3)
+		kickBall("red", "red_4", kick.KindClear, interfaces.Vector2D{}, interfaces.Vector2D{X: 0, Y: 1})
+		collector.Update(1)
+
+		summary := collector.Summary()
+		Expect(summary.Teams[0].Possession).To(Equal(3.0))
+		Expect(summary.Possession(1)).To(BeNumerically("~", 0.25, 0.001))
+	})
+
+	It("should add up the distance players run, but not lining up", func() {
+		collector.Track("blue", "player", interfaces.Vector2D{X: 0, Y: 0})
+		collector.Track("blue", "player", interfaces.Vector2D{X: 30, Y: 40})
+		enter(score.PhaseGoal, score.PhaseFirstHalf, 0, 0, 10)
+		enter(score.PhaseKickoff, score.PhaseGoal, 0, 0, 10)
+		collector.Track("blue", "player", interfaces.Vector2D{X: 500, Y: 500})
+
+		Expect(collector.Summary().Teams[0].Distance).To(Equal(50.0))
+	})
+
+	It("should put goals on the timeline with their scorer", func() {
+		kickBall("red", "red_9", kick.KindShot, interfaces.Vector2D{X: 100, Y: 300}, interfaces.Vector2D{X: -400, Y: 0})
+		enter(score.PhaseGoal, score.PhaseFirstHalf, 0, 1, 12)
+		kickBall("red", "red_2", kick.KindClear, interfaces.Vector2D{X: 700, Y: 300}, interfaces.Vector2D{X: 400, Y: 0})
+		enter(score.PhaseGoal, score.PhaseSecondHalf, 1, 1, 40)
+
+		summary := collector.Summary()
+		Expect(summary.Goals).To(Equal([]Goal{
+			{Team: "red", Scorer: "red_9", Time: 12},
+			{Team: "blue", Scorer: "red_2", OwnGoal: true, Time: 40},
+		}))
+		Expect(summary.Teams[0].Score).To(Equal(1))
+		Expect(summary.Teams[1].Goals).To(Equal(1))
+	})
+
+	It("should start over with a new match", func() {
+		kickBall("blue", "blue_9", kick.KindShot, interfaces.Vector2D{X: 600, Y: 300}, interfaces.Vector2D{X: 400, Y: 0})
+		enter(score.PhaseFullTime, score.PhaseSecondHalf, 0, 0, 90)
+		Expect(collector.Summary().Finished).To(BeTrue())
+
+		enter(score.PhaseKickoff, score.PhaseFullTime, 0, 0, 0)
+		summary := collector.Summary()
+		Expect(summary.Finished).To(BeFalse())
+		Expect(summary.Players).To(BeEmpty())
+	})
+})
+
+var _ = Describe("History", func() {
+	result := func(home, away int) Summary {
+		return Summary{Teams: [2]TeamStats{{Team: "blue", Score: home}, {Team: "red", Score: away}}}
+	}
+
+	It("should keep the last matches", func() {
+		history := &History{}
+		for i := 0; i < historyLimit+5; i++ {
+			history.Add(time.Unix(int64(i), 0), result(i, 0))
+		}
+		Expect(history.Matches).To(HaveLen(historyLimit))
+		Expect(history.Matches[0].Summary.Teams[0].Score).To(Equal(5))
+	})
+
+	It("should count wins, draws and losses", func() {
+		history := &History{}
+		history.Add(time.Unix(0, 0), result(2, 1))
+		history.Add(time.Unix(1, 0), result(0, 0))
+		history.Add(time.Unix(2, 0), result(0, 3))
+
+		won, drawn, lost := history.Record("red")
+		Expect([]int{won, drawn, lost}).To(Equal([]int{1, 1, 1}))
+	})
+
+	It("should read back what it stores", func() {
+		history := &History{}
+		history.Add(time.Unix(0, 0).UTC(), result(2, 1))
+		data, err := json.Marshal(history)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed, err := ParseHistory(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(history))
+
+		empty, err := ParseHistory(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(empty.Matches).To(BeEmpty())
+	})
+})
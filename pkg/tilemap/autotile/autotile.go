@@ -0,0 +1,82 @@
+// Package autotile picks the tile of each terrain cell from its neighbours.
+// It is kept apart from package tilemap, which draws with ebiten, so level
+// files can be read without it.
+package autotile
+
+// Neighbour bits of an autotile mask. A bit is set when the cell on that side
+// belongs to the same terrain.
+const (
+	North = 1 << iota
+	East
+	South
+	West
+)
+
+// Rule replaces the cells of a terrain with the tile matching their
+// neighbours, so a level only needs to mark where the terrain is.
+type Rule struct {
+	// Terrain is the tile id placed in the layer data.
+	Terrain int `json:"terrain"`
+	// Tiles holds the tile id for each neighbour mask; 0 keeps Terrain.
+	Tiles [16]int `json:"tiles"`
+}
+
+// member reports whether gid is the terrain or one of its tiles.
+func (a Rule) member(gid int) bool {
+	if gid == 0 {
+		return false
+	}
+	if gid == a.Terrain {
+		return true
+	}
+	for _, tile := range a.Tiles {
+		if tile == gid {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns a copy of data, a grid width cells wide, with the
+// terrain cells replaced by the tile for their neighbour mask. Cells outside
+// the grid never count as neighbours.
+func Apply(data []int, width int, rules []Rule) []int {
+	result := append([]int(nil), data...)
+	if width <= 0 || len(rules) == 0 {
+		return result
+	}
+	height := len(data) / width
+	at := func(col, row int) int {
+		if col < 0 || row < 0 || col >= width || row >= height {
+			return 0
+		}
+		return data[row*width+col]
+	}
+
+	for i, gid := range data {
+		for _, rule := range rules {
+			if gid != rule.Terrain {
+				continue
+			}
+			col, row := i%width, i/width
+			mask := 0
+			if rule.member(at(col, row-1)) {
+				mask |= North
+			}
+			if rule.member(at(col+1, row)) {
+				mask |= East
+			}
+			if rule.member(at(col, row+1)) {
+				mask |= South
+			}
+			if rule.member(at(col-1, row)) {
+				mask |= West
+			}
+			if tile := rule.Tiles[mask]; tile != 0 {
+				result[i] = tile
+			}
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package autotile
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAutotile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Autotile Suite")
+}
+
+var _ = Describe("Autotiles", func() {
+	// Terrain 1 becomes 10 plus its neighbour mask.
+	var rule Rule
+	BeforeEach(func() {
+		rule = Rule{Terrain: 1}
+		for mask := range rule.Tiles {
+			rule.Tiles[mask] = 10 + mask
+		}
+	})
+
+	It("should pick the tile matching the neighbours", func() {
+		data := []int{
+			0, 1, 0,
+			1, 1, 1,
+			0, 0, 0,
+		}
+		Expect(Apply(data, 3, []Rule{rule})).To(Equal([]int{
+			0, 10 + South, 0,
+			10 + East, 10 + North + East + West, 10 + West,
+			0, 0, 0,
+		}))
+	})
+
+	It("should leave other tiles and the input alone", func() {
+		data := []int{2, 1, 2}
+		Expect(Apply(data, 3, []Rule{rule})).To(Equal([]int{2, 10, 2}))
+		Expect(data).To(Equal([]int{2, 1, 2}))
+	})
+
+	It("should keep the terrain tile when the rule has no tile for the mask", func() {
+		rule.Tiles[East+West] = 0
+		Expect(Apply([]int{1, 1, 1}, 3, []Rule{rule})).To(Equal([]int{10 + East, 1, 10 + West}))
+	})
+})
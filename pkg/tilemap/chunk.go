@@ -0,0 +1,83 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultChunkSize is the width and height of a cached chunk in pixels.
+const DefaultChunkSize = 512
+
+// RenderFunc draws everything inside area, given in world coordinates, onto
+// dst, whose origin is the top left corner of area. It reports whether
+// anything was drawn.
+type RenderFunc func(dst *ebiten.Image, area image.Rectangle) bool
+
+// ChunkCache splits the world into square chunks and keeps each rendered chunk
+// as an image, so static content costs one draw per visible chunk.
+type ChunkCache struct {
+	size   int
+	render RenderFunc
+	// chunks holds every chunk rendered so far; nil marks an empty chunk.
+	chunks map[image.Point]*ebiten.Image
+}
+
+// NewChunkCache creates a cache of chunks size pixels wide drawn by render.
+func NewChunkCache(size int, render RenderFunc) *ChunkCache {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	return &ChunkCache{
+		size:   size,
+		render: render,
+		chunks: make(map[image.Point]*ebiten.Image),
+	}
+}
+
+// Draw draws the chunks in view, rendering the ones not seen before.
+func (c *ChunkCache) Draw(screen *ebiten.Image, offsetX, offsetY float64, colorScale ebiten.ColorScale) {
+	size := float64(c.size)
+	width, height := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+	minX, minY := int(math.Floor(offsetX/size)), int(math.Floor(offsetY/size))
+	maxX, maxY := int(math.Floor((offsetX+width)/size)), int(math.Floor((offsetY+height)/size))
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			chunk := c.chunk(image.Pt(x, y))
+			if chunk == nil {
+				continue
+			}
+			opts := &ebiten.DrawImageOptions{}
+			opts.GeoM.Translate(float64(x*c.size)-offsetX, float64(y*c.size)-offsetY)
+			opts.ColorScale = colorScale
+			screen.DrawImage(chunk, opts)
+		}
+	}
+}
+
+// chunk returns the image of a chunk, rendering it on first use.
+func (c *ChunkCache) chunk(key image.Point) *ebiten.Image {
+	if chunk, ok := c.chunks[key]; ok {
+		return chunk
+	}
+	area := image.Rect(key.X*c.size, key.Y*c.size, (key.X+1)*c.size, (key.Y+1)*c.size)
+	chunk := ebiten.NewImage(c.size, c.size)
+	if !c.render(chunk, area) {
+		chunk.Deallocate()
+		chunk = nil
+	}
+	c.chunks[key] = chunk
+	return chunk
+}
+
+// Invalidate drops every rendered chunk so they are drawn again.
+func (c *ChunkCache) Invalidate() {
+	for key, chunk := range c.chunks {
+		if chunk != nil {
+			chunk.Deallocate()
+		}
+		delete(c.chunks, key)
+	}
+}
@@ -0,0 +1,234 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/joaorufino/gopher-game/internal/interfaces"
+	"github.com/joaorufino/gopher-game/pkg/tilemap/autotile"
+)
+
+// Tileset is a tile sheet image cut into equally sized tiles.
+type Tileset struct {
+	FirstGID   int
+	Image      *ebiten.Image
+	TileWidth  int
+	TileHeight int
+	Columns    int
+	Margin     int
+	Spacing    int
+	// Autotiles use global tile ids.
+	Autotiles []autotile.Rule
+}
+
+// Tile returns the image of the tile with the given id local to the tileset.
+func (t *Tileset) Tile(local int) *ebiten.Image {
+	if t.Image == nil || t.Columns == 0 || local < 0 {
+		return nil
+	}
+	x := t.Margin + (local%t.Columns)*(t.TileWidth+t.Spacing)
+	y := t.Margin + (local/t.Columns)*(t.TileHeight+t.Spacing)
+	rect := image.Rect(x, y, x+t.TileWidth, y+t.TileHeight)
+	if !rect.In(t.Image.Bounds()) {
+		return nil
+	}
+	return t.Image.SubImage(rect).(*ebiten.Image)
+}
+
+// Tile is a single placed tile.
+type Tile struct {
+	GID      int
+	position interfaces.Vector2D
+	image    *ebiten.Image
+}
+
+// GetPosition returns the world position of the tile's top left corner.
+func (t *Tile) GetPosition() interfaces.Vector2D {
+	return t.position
+}
+
+// SetPosition moves the tile. Layers show the new position once their cached
+// chunks are invalidated.
+func (t *Tile) SetPosition(position interfaces.Vector2D) {
+	t.position = position
+}
+
+// Draw draws the tile through the camera.
+func (t *Tile) Draw(screen *ebiten.Image, camera interfaces.Camera) {
+	offsetX, offsetY := camera.GetOffset()
+	t.draw(screen, offsetX, offsetY)
+}
+
+func (t *Tile) draw(screen *ebiten.Image, offsetX, offsetY float64) {
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(t.position.X-offsetX, t.position.Y-offsetY)
+	screen.DrawImage(t.image, opts)
+}
+
+// LayerConfig describes a grid of global tile ids; 0 means an empty cell.
+type LayerConfig struct {
+	Name       string
+	Width      int
+	Height     int
+	TileWidth  int
+	TileHeight int
+	Offset     interfaces.Vector2D
+	Opacity    float64
+	Visible    bool
+	Data       []int
+}
+
+// Layer is a tile layer drawn from cached chunks.
+type Layer struct {
+	Name       string
+	Width      int
+	Height     int
+	TileWidth  int
+	TileHeight int
+	Offset     interfaces.Vector2D
+	Opacity    float64
+	Visible    bool
+	tiles      []*Tile
+	cache      *ChunkCache
+}
+
+// TileAt returns the tile in the given cell, or nil when it is empty.
+func (l *Layer) TileAt(col, row int) interfaces.Tile {
+	if col < 0 || row < 0 || col >= l.Width || row >= l.Height {
+		return nil
+	}
+	if tile := l.tiles[row*l.Width+col]; tile != nil {
+		return tile
+	}
+	return nil
+}
+
+// Draw draws the part of the layer in view.
+func (l *Layer) Draw(screen *ebiten.Image, offsetX, offsetY float64) {
+	if !l.Visible {
+		return
+	}
+	var colorScale ebiten.ColorScale
+	colorScale.ScaleAlpha(float32(l.Opacity))
+	l.cache.Draw(screen, offsetX, offsetY, colorScale)
+}
+
+// renderChunk draws the tiles overlapping area.
+func (l *Layer) renderChunk(dst *ebiten.Image, area image.Rectangle) bool {
+	if l.TileWidth <= 0 || l.TileHeight <= 0 {
+		return false
+	}
+	tw, th := float64(l.TileWidth), float64(l.TileHeight)
+	minCol := int(math.Floor((float64(area.Min.X) - l.Offset.X) / tw))
+	minRow := int(math.Floor((float64(area.Min.Y) - l.Offset.Y) / th))
+	maxCol := int(math.Ceil((float64(area.Max.X) - l.Offset.X) / tw))
+	maxRow := int(math.Ceil((float64(area.Max.Y) - l.Offset.Y) / th))
+
+	drawn := false
+	for row := max(minRow, 0); row < min(maxRow, l.Height); row++ {
+		for col := max(minCol, 0); col < min(maxCol, l.Width); col++ {
+			tile := l.tiles[row*l.Width+col]
+			if tile == nil {
+				continue
+			}
+			tile.draw(dst, float64(area.Min.X), float64(area.Min.Y))
+			drawn = true
+		}
+	}
+	return drawn
+}
+
+// TileMap holds the tilesets and tile layers of a level.
+type TileMap struct {
+	tilesets  []*Tileset
+	layers    []*Layer
+	chunkSize int
+}
+
+// NewTileMap creates an empty tile map rendering chunks chunkSize pixels wide.
+func NewTileMap(tilesets []*Tileset, chunkSize int) *TileMap {
+	return &TileMap{tilesets: tilesets, chunkSize: chunkSize}
+}
+
+// tilesetFor returns the tileset a global tile id belongs to.
+func (m *TileMap) tilesetFor(gid int) *Tileset {
+	var result *Tileset
+	for _, tileset := range m.tilesets {
+		if tileset.FirstGID <= gid && (result == nil || tileset.FirstGID > result.FirstGID) {
+			result = tileset
+		}
+	}
+	return result
+}
+
+// TileImage returns the image of a global tile id, or nil if no tileset has it.
+func (m *TileMap) TileImage(gid int) *ebiten.Image {
+	tileset := m.tilesetFor(gid)
+	if tileset == nil {
+		return nil
+	}
+	return tileset.Tile(gid - tileset.FirstGID)
+}
+
+// AddLayer resolves the autotiles and tile images of a layer and adds it on
+// top of the existing ones.
+func (m *TileMap) AddLayer(config LayerConfig) *Layer {
+	layer := &Layer{
+		Name:       config.Name,
+		Width:      config.Width,
+		Height:     config.Height,
+		TileWidth:  config.TileWidth,
+		TileHeight: config.TileHeight,
+		Offset:     config.Offset,
+		Opacity:    config.Opacity,
+		Visible:    config.Visible,
+		tiles:      make([]*Tile, config.Width*config.Height),
+	}
+	layer.cache = NewChunkCache(m.chunkSize, layer.renderChunk)
+
+	var rules []autotile.Rule
+	for _, tileset := range m.tilesets {
+		rules = append(rules, tileset.Autotiles...)
+	}
+	data := autotile.Apply(config.Data, config.Width, rules)
+	for i := 0; i < len(data) && i < len(layer.tiles); i++ {
+		if data[i] == 0 {
+			continue
+		}
+		tileImage := m.TileImage(data[i])
+		if tileImage == nil {
+			continue
+		}
+		layer.tiles[i] = &Tile{
+			GID:   data[i],
+			image: tileImage,
+			position: interfaces.Vector2D{
+				X: config.Offset.X + float64((i%config.Width)*config.TileWidth),
+				Y: config.Offset.Y + float64((i/config.Width)*config.TileHeight),
+			},
+		}
+	}
+
+	m.layers = append(m.layers, layer)
+	return layer
+}
+
+// Layers returns the layers from bottom to top.
+func (m *TileMap) Layers() []*Layer {
+	return m.layers
+}
+
+// Draw draws every visible layer.
+func (m *TileMap) Draw(screen *ebiten.Image, offsetX, offsetY float64) {
+	for _, layer := range m.layers {
+		layer.Draw(screen, offsetX, offsetY)
+	}
+}
+
+// Invalidate drops the cached chunks of every layer.
+func (m *TileMap) Invalidate() {
+	for _, layer := range m.layers {
+		layer.cache.Invalidate()
+	}
+}
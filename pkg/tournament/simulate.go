@@ -0,0 +1,107 @@
+package tournament
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Simulated matches see goalsPerMatch goals on average, shared between the
+// teams by rating with the home team's boosted by homeAdvantage. Every
+// penalty of a shootout goes in with penaltyChance.
+const (
+	goalsPerMatch = 2.6
+	homeAdvantage = 1.1
+	penaltyChance = 0.75
+	penaltyRounds = 5
+	defaultRating = 50.0
+)
+
+// Simulate returns a result for a fixture between two AI teams: goals drawn
+// from the share of the ratings of each team, and a shootout when a knockout
+// fixture is drawn.
+func (t *Tournament) Simulate(fixture Fixture, rng *rand.Rand) Result {
+	home, away := t.rating(fixture.Home)*homeAdvantage, t.rating(fixture.Away)
+	result := Result{
+		HomeGoals: poisson(goalsPerMatch*home/(home+away), rng),
+		AwayGoals: poisson(goalsPerMatch*away/(home+away), rng),
+	}
+	if result.HomeGoals == result.AwayGoals && !t.AllowsDraws() {
+		result.HomePenalties, result.AwayPenalties = shootout(rng)
+	}
+	return result
+}
+
+// Advance simulates the fixtures between AI teams, a round at a time, until
+// a round where a human team plays, and returns that fixture. It returns
+// false once no human team has a fixture left, simulating the rest of the
+// tournament.
+func (t *Tournament) Advance(rng *rand.Rand) (int, bool) {
+	// Every pass plays a whole round out, or stops at a human team
+	for {
+		next, ok := t.Next()
+		if !ok {
+			return 0, false
+		}
+		round := t.Fixtures[next].Round
+		human := -1
+		for i := range t.Fixtures {
+			fixture := t.Fixtures[i]
+			if fixture.Round != round || fixture.Played() {
+				continue
+			}
+			if t.humanPlays(fixture) {
+				if human < 0 {
+					human = i
+				}
+				continue
+			}
+			// The simulated result always suits the format
+			_ = t.Record(i, t.Simulate(fixture, rng))
+		}
+		if human >= 0 {
+			return human, true
+		}
+	}
+}
+
+// humanPlays reports whether a human team plays the fixture.
+func (t *Tournament) humanPlays(fixture Fixture) bool {
+	home, _ := t.Team(fixture.Home)
+	away, _ := t.Team(fixture.Away)
+	return home.Human || away.Human
+}
+
+// rating returns the rating of a team, or an average one when it has none.
+func (t *Tournament) rating(name string) float64 {
+	if team, ok := t.Team(name); ok && team.Rating > 0 {
+		return team.Rating
+	}
+	return defaultRating
+}
+
+// poisson draws how many goals a team scores when it scores mean goals on
+// average.
+func poisson(mean float64, rng *rand.Rand) int {
+	limit := math.Exp(-mean)
+	goals := 0
+	for p := rng.Float64(); p > limit; p *= rng.Float64() {
+		goals++
+	}
+	return goals
+}
+
+// shootout takes penalties until one team is ahead after the same number of
+// kicks, at least penaltyRounds each.
+func shootout(rng *rand.Rand) (home, away int) {
+	for taken := 1; ; taken++ {
+		if rng.Float64() < penaltyChance {
+			home++
+		}
+		if rng.Float64() < penaltyChance {
+			away++
+		}
+		if taken >= penaltyRounds && home != away {
+			return home, away
+		}
+	}
+}
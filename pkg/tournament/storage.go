@@ -0,0 +1,81 @@
+package tournament
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/joaorufino/gopher-game/internal/utils"
+)
+
+// StorageKey is where the tournament in progress is stored: the browser's
+// local storage, or a file in the user's config directory.
+const StorageKey = "gopher-game-tournament"
+
+// Parse reads a tournament stored as JSON, or returns nil when data is empty.
+func Parse(data []byte) (*Tournament, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var t Tournament
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse tournament: %w", err)
+	}
+	for _, fixture := range t.Fixtures {
+		for _, name := range []string{fixture.Home, fixture.Away} {
+			if _, ok := t.Team(name); !ok {
+				return nil, fmt.Errorf("tournament fixture of unknown team %q", name)
+			}
+		}
+	}
+	return &t, nil
+}
+
+// Load reads the stored tournament, or returns nil when none was started.
+func Load() (*Tournament, error) {
+	data, err := utils.ReadStorage(StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Save stores the tournament, replacing the one stored before.
+func (t *Tournament) Save() error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("failed to encode tournament: %w", err)
+	}
+	return utils.WriteStorage(StorageKey, data)
+}
+
+// ParseTeams reads the teams taking part in tournaments from JSON.
+func ParseTeams(data []byte) ([]Team, error) {
+	var file struct {
+		Teams []Team `json:"teams"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse teams: %w", err)
+	}
+	if len(file.Teams) < 2 {
+		return nil, errors.New("a tournament takes at least two teams")
+	}
+	return file.Teams, nil
+}
+
+// LoadTeams reads the teams taking part in tournaments from a JSON file.
+func LoadTeams(path string) ([]Team, error) {
+	var teams []Team
+	err := utils.LoadData(path, func(data []byte) error {
+		var err error
+		teams, err = ParseTeams(data)
+		return err
+	})
+	if err == nil && teams == nil {
+		err = errors.New("no teams")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams %s: %w", path, err)
+	}
+	return teams, nil
+}
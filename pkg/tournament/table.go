@@ -0,0 +1,77 @@
+package tournament
+
+import "sort"
+
+// Points a league gives for a win and a draw.
+const (
+	PointsWin  = 3
+	PointsDraw = 1
+)
+
+// Standing is the line of a team in the table.
+type Standing struct {
+	Team         string `json:"team"`
+	Played       int    `json:"played"`
+	Won          int    `json:"won"`
+	Drawn        int    `json:"drawn"`
+	Lost         int    `json:"lost"`
+	GoalsFor     int    `json:"goalsFor"`
+	GoalsAgainst int    `json:"goalsAgainst"`
+	Points       int    `json:"points"`
+}
+
+// GoalDifference returns the goals the team scored minus those it conceded.
+func (s Standing) GoalDifference() int {
+	return s.GoalsFor - s.GoalsAgainst
+}
+
+// add counts a fixture where the team scored goalsFor and conceded
+// goalsAgainst, which it won or lost, or drew when it was not decided.
+func (s *Standing) add(goalsFor, goalsAgainst int, won, decided bool) {
+	s.Played++
+	s.GoalsFor += goalsFor
+	s.GoalsAgainst += goalsAgainst
+	switch {
+	case !decided:
+		s.Drawn++
+		s.Points += PointsDraw
+	case won:
+		s.Won++
+		s.Points += PointsWin
+	default:
+		s.Lost++
+	}
+}
+
+// Table returns the standings of every team after the fixtures played,
+// ordered by points, then goal difference, then goals scored. A knockout
+// fixture settled with penalties counts as a win for the team that won the
+// shootout.
+func (t *Tournament) Table() []Standing {
+	standings := make([]Standing, len(t.Teams))
+	index := make(map[string]int, len(t.Teams))
+	for i, team := range t.Teams {
+		standings[i].Team = team.Name
+		index[team.Name] = i
+	}
+	for _, fixture := range t.Fixtures {
+		if fixture.Result == nil {
+			continue
+		}
+		result := fixture.Result
+		winner, decided := result.Winner()
+		standings[index[fixture.Home]].add(result.HomeGoals, result.AwayGoals, winner == 0, decided)
+		standings[index[fixture.Away]].add(result.AwayGoals, result.HomeGoals, winner == 1, decided)
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.GoalDifference() != b.GoalDifference() {
+			return a.GoalDifference() > b.GoalDifference()
+		}
+		return a.GoalsFor > b.GoalsFor
+	})
+	return standings
+}
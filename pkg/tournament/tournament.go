@@ -0,0 +1,321 @@
+package tournament
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Format is how the teams of a tournament meet.
+type Format string
+
+const (
+	// FormatLeague has every team play every other team once; the table
+	// decides the champion.
+	FormatLeague Format = "league"
+	// FormatKnockout pairs the teams in a bracket where the loser of every
+	// fixture is out; the winner of the final is the champion.
+	FormatKnockout Format = "knockout"
+)
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case FormatLeague, FormatKnockout:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown tournament format %q", name)
+}
+
+// Team is a team taking part in tournaments. The stronger its rating, the
+// better its simulated results; a human team plays its fixtures on the field.
+type Team struct {
+	Name   string  `json:"name"`
+	Rating float64 `json:"rating"`
+	Human  bool    `json:"human,omitempty"`
+}
+
+// Result is the score of a fixture, with the shootout that settled a draw in
+// a knockout.
+type Result struct {
+	HomeGoals     int `json:"homeGoals"`
+	AwayGoals     int `json:"awayGoals"`
+	HomePenalties int `json:"homePenalties,omitempty"`
+	AwayPenalties int `json:"awayPenalties,omitempty"`
+}
+
+// Winner returns 0 when the home team won, 1 when the away team did, by goals
+// or else penalties, and false for a draw.
+func (r Result) Winner() (int, bool) {
+	switch {
+	case r.HomeGoals != r.AwayGoals:
+		return boolIndex(r.AwayGoals > r.HomeGoals), true
+	case r.HomePenalties != r.AwayPenalties:
+		return boolIndex(r.AwayPenalties > r.HomePenalties), true
+	}
+	return 0, false
+}
+
+// Fixture is a match between two teams in a round of the tournament.
+type Fixture struct {
+	Round  int     `json:"round"` // Starting at 1
+	Home   string  `json:"home"`
+	Away   string  `json:"away"`
+	Result *Result `json:"result,omitempty"` // Nil until played
+}
+
+// Played reports whether the fixture has a result.
+func (f Fixture) Played() bool {
+	return f.Result != nil
+}
+
+// Winner returns the team that won the fixture, if it was played and not
+// drawn.
+func (f Fixture) Winner() (string, bool) {
+	if f.Result == nil {
+		return "", false
+	}
+	side, ok := f.Result.Winner()
+	if !ok {
+		return "", false
+	}
+	return [2]string{f.Home, f.Away}[side], true
+}
+
+// Tournament is a league or knockout between teams, with the fixtures
+// scheduled so far. A knockout schedules each round once the previous one
+// is over.
+type Tournament struct {
+	Name     string    `json:"name"`
+	Format   Format    `json:"format"`
+	Teams    []Team    `json:"teams"`
+	Fixtures []Fixture `json:"fixtures"`
+}
+
+// New schedules a tournament between teams. A league takes any two teams or
+// more; a knockout takes a power of two and seeds them in the given order.
+func New(name string, format Format, teams []Team) (*Tournament, error) {
+	if len(teams) < 2 {
+		return nil, errors.New("a tournament takes at least two teams")
+	}
+	names := make(map[string]bool)
+	for _, team := range teams {
+		if team.Name == "" {
+			return nil, errors.New("team without a name")
+		}
+		if names[team.Name] {
+			return nil, fmt.Errorf("team %s takes part twice", team.Name)
+		}
+		names[team.Name] = true
+	}
+
+	t := &Tournament{Name: name, Format: format, Teams: append([]Team(nil), teams...)}
+	switch format {
+	case FormatLeague:
+		t.Fixtures = roundRobin(teams)
+	case FormatKnockout:
+		if len(teams)&(len(teams)-1) != 0 {
+			return nil, fmt.Errorf("a knockout takes a power of two teams, not %d", len(teams))
+		}
+		order := bracketOrder(len(teams))
+		for i := 0; i < len(order); i += 2 {
+			t.Fixtures = append(t.Fixtures, Fixture{Round: 1, Home: teams[order[i]].Name, Away: teams[order[i+1]].Name})
+		}
+	default:
+		return nil, fmt.Errorf("unknown tournament format %q", format)
+	}
+	return t, nil
+}
+
+// roundRobin schedules every team against every other once with the circle
+// method: the first team stays put while the others rotate around it. With
+// an odd number of teams one of them rests every round.
+func roundRobin(teams []Team) []Fixture {
+	names := make([]string, 0, len(teams)+1)
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	if len(names)%2 == 1 {
+		names = append(names, "") // The team drawn against nobody rests
+	}
+	n := len(names)
+	var fixtures []Fixture
+	for round := 1; round < n; round++ {
+		for i := 0; i < n/2; i++ {
+			home, away := names[i], names[n-1-i]
+			// Take turns at home
+			if (i == 0 && round%2 == 0) || (i > 0 && i%2 == 1) {
+				home, away = away, home
+			}
+			if home != "" && away != "" {
+				fixtures = append(fixtures, Fixture{Round: round, Home: home, Away: away})
+			}
+		}
+		names = append(names[:1], append([]string{names[n-1]}, names[1:n-1]...)...)
+	}
+	return fixtures
+}
+
+// bracketOrder returns the seeds of a bracket of n teams in the order they
+// are paired, so the best seeds meet last: 1-8, 4-5, 2-7, 3-6 for eight.
+func bracketOrder(n int) []int {
+	order := []int{0}
+	for len(order) < n {
+		size := len(order) * 2
+		next := make([]int, 0, size)
+		for _, seed := range order {
+			next = append(next, seed, size-1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// Rounds returns how many rounds the tournament has in all.
+func (t *Tournament) Rounds() int {
+	if t.Format == FormatKnockout {
+		rounds := 0
+		for n := len(t.Teams); n > 1; n /= 2 {
+			rounds++
+		}
+		return rounds
+	}
+	rounds := 0
+	for _, fixture := range t.Fixtures {
+		rounds = max(rounds, fixture.Round)
+	}
+	return rounds
+}
+
+// RoundName names a round, e.g. "Matchday 3" or "Semi-finals".
+func (t *Tournament) RoundName(round int) string {
+	if t.Format != FormatKnockout {
+		return fmt.Sprintf("Matchday %d", round)
+	}
+	switch t.Rounds() - round {
+	case 0:
+		return "Final"
+	case 1:
+		return "Semi-finals"
+	case 2:
+		return "Quarter-finals"
+	}
+	return fmt.Sprintf("Round %d", round)
+}
+
+// Team returns the team with the given name.
+func (t *Tournament) Team(name string) (Team, bool) {
+	for _, team := range t.Teams {
+		if team.Name == name {
+			return team, true
+		}
+	}
+	return Team{}, false
+}
+
+// AllowsDraws reports whether a fixture can end in a draw, or has to be
+// settled with extra time and penalties.
+func (t *Tournament) AllowsDraws() bool {
+	return t.Format != FormatKnockout
+}
+
+// Next returns the first fixture not played yet.
+func (t *Tournament) Next() (int, bool) {
+	for i, fixture := range t.Fixtures {
+		if !fixture.Played() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Round returns the fixtures of a round, in the order they were scheduled.
+func (t *Tournament) Round(round int) []Fixture {
+	var fixtures []Fixture
+	for _, fixture := range t.Fixtures {
+		if fixture.Round == round {
+			fixtures = append(fixtures, fixture)
+		}
+	}
+	return fixtures
+}
+
+// Record gives the fixture with the given index its result. Once every
+// fixture of a knockout round is played, the winners are drawn against each
+// other in the next round.
+func (t *Tournament) Record(index int, result Result) error {
+	if index < 0 || index >= len(t.Fixtures) {
+		return fmt.Errorf("no fixture %d", index)
+	}
+	fixture := &t.Fixtures[index]
+	if fixture.Played() {
+		return fmt.Errorf("%s against %s was played already", fixture.Home, fixture.Away)
+	}
+	if _, ok := result.Winner(); !ok && !t.AllowsDraws() {
+		return fmt.Errorf("%s against %s needs a winner", fixture.Home, fixture.Away)
+	}
+	fixture.Result = &result
+	if t.Format == FormatKnockout {
+		t.scheduleNextRound(fixture.Round)
+	}
+	return nil
+}
+
+// scheduleNextRound pairs the winners of a finished knockout round, the
+// winners of neighbouring fixtures against each other.
+func (t *Tournament) scheduleNextRound(round int) {
+	fixtures := t.Round(round)
+	if len(fixtures) < 2 || len(t.Round(round+1)) > 0 {
+		return
+	}
+	winners := make([]string, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		winner, ok := fixture.Winner()
+		if !ok {
+			return
+		}
+		winners = append(winners, winner)
+	}
+	for i := 0; i < len(winners); i += 2 {
+		t.Fixtures = append(t.Fixtures, Fixture{Round: round + 1, Home: winners[i], Away: winners[i+1]})
+	}
+}
+
+// Finished reports whether every fixture of the tournament was played.
+func (t *Tournament) Finished() bool {
+	if _, ok := t.Next(); ok {
+		return false
+	}
+	return t.Format != FormatKnockout || len(t.Round(t.Rounds())) == 1
+}
+
+// Champion returns the team that won the tournament once it is over: the
+// top of the table, or the winner of the final.
+func (t *Tournament) Champion() (string, bool) {
+	if !t.Finished() {
+		return "", false
+	}
+	if t.Format == FormatKnockout {
+		return t.Round(t.Rounds())[0].Winner()
+	}
+	return t.Table()[0].Team, true
+}
+
+// Eliminated reports whether a team is out of a knockout.
+func (t *Tournament) Eliminated(team string) bool {
+	if t.Format != FormatKnockout {
+		return false
+	}
+	for _, fixture := range t.Fixtures {
+		if winner, ok := fixture.Winner(); ok && winner != team && (fixture.Home == team || fixture.Away == team) {
+			return true
+		}
+	}
+	return false
+}
+
+func boolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
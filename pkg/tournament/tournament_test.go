@@ -0,0 +1,205 @@
+package tournament
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTournament(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tournament Suite")
+}
+
+// teamsNamed returns teams with the given names, the first one human.
+func teamsNamed(names ...string) []Team {
+	teams := make([]Team, len(names))
+	for i, name := range names {
+		teams[i] = Team{Name: name, Rating: 50, Human: i == 0}
+	}
+	return teams
+}
+
+var _ = Describe("League", func() {
+	It("should have every team play every other once, once a round", func() {
+		for _, count := range []int{2, 3, 4, 5, 8} {
+			names := []string{"A", "B", "C", "D", "E", "F", "G", "H"}[:count]
+			league, err := New("league", FormatLeague, teamsNamed(names...))
+			Expect(err).NotTo(HaveOccurred())
+
+			met := make(map[[2]string]int)
+			perRound := make(map[int]map[string]bool)
+			for _, fixture := range league.Fixtures {
+				pair := [2]string{fixture.Home, fixture.Away}
+				if pair[0] > pair[1] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				met[pair]++
+				if perRound[fixture.Round] == nil {
+					perRound[fixture.Round] = make(map[string]bool)
+				}
+				for _, team := range []string{fixture.Home, fixture.Away} {
+					Expect(perRound[fixture.Round][team]).To(BeFalse(), "%s twice in round %d", team, fixture.Round)
+					perRound[fixture.Round][team] = true
+				}
+			}
+			Expect(league.Fixtures).To(HaveLen(count * (count - 1) / 2))
+			for _, times := range met {
+				Expect(times).To(Equal(1))
+			}
+		}
+	})
+
+	It("should rank the table by points, goal difference and goals", func() {
+		league, err := New("league", FormatLeague, teamsNamed("A", "B", "C"))
+		Expect(err).NotTo(HaveOccurred())
+		results := map[[2]string]Result{
+			{"A", "B"}: {HomeGoals: 1, AwayGoals: 1},
+			{"A", "C"}: {HomeGoals: 3, AwayGoals: 0},
+			{"B", "C"}: {HomeGoals: 2, AwayGoals: 0},
+		}
+		for i, fixture := range league.Fixtures {
+			result, ok := results[[2]string{fixture.Home, fixture.Away}]
+			if !ok {
+				reversed := results[[2]string{fixture.Away, fixture.Home}]
+				result = Result{HomeGoals: reversed.AwayGoals, AwayGoals: reversed.HomeGoals}
+			}
+			Expect(league.Record(i, result)).To(Succeed())
+		}
+
+		table := league.Table()
+		Expect(table).To(Equal([]Standing{
+			{Team: "A", Played: 2, Won: 1, Drawn: 1, GoalsFor: 4, GoalsAgainst: 1, Points: 4},
+			{Team: "B", Played: 2, Won: 1, Drawn: 1, GoalsFor: 3, GoalsAgainst: 1, Points: 4},
+			{Team: "C", Played: 2, Lost: 2, GoalsFor: 0, GoalsAgainst: 5, Points: 0},
+		}))
+		Expect(table[2].GoalDifference()).To(Equal(-5))
+		champion, ok := league.Champion()
+		Expect(ok).To(BeTrue())
+		Expect(champion).To(Equal("A"))
+	})
+
+	It("should not record a fixture twice", func() {
+		league, err := New("league", FormatLeague, teamsNamed("A", "B"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(league.Record(0, Result{})).To(Succeed())
+		Expect(league.Record(0, Result{HomeGoals: 1})).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("Knockout", func() {
+	It("should seed the bracket so the best teams meet last", func() {
+		cup, err := New("cup", FormatKnockout, teamsNamed("1", "2", "3", "4", "5", "6", "7", "8"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cup.Fixtures).To(Equal([]Fixture{
+			{Round: 1, Home: "1", Away: "8"},
+			{Round: 1, Home: "4", Away: "5"},
+			{Round: 1, Home: "2", Away: "7"},
+			{Round: 1, Home: "3", Away: "6"},
+		}))
+		Expect(cup.RoundName(1)).To(Equal("Quarter-finals"))
+		Expect(cup.RoundName(3)).To(Equal("Final"))
+	})
+
+	It("should draw the winners into the next round", func() {
+		cup, err := New("cup", FormatKnockout, teamsNamed("A", "B", "C", "D"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cup.Record(0, Result{HomeGoals: 2, AwayGoals: 0})).To(Succeed())
+		Expect(cup.Round(2)).To(BeEmpty())
+		Expect(cup.Record(1, Result{HomeGoals: 1, AwayGoals: 1, HomePenalties: 3, AwayPenalties: 4})).To(Succeed())
+		Expect(cup.Round(2)).To(Equal([]Fixture{{Round: 2, Home: "A", Away: "C"}}))
+		Expect(cup.Eliminated("B")).To(BeTrue())
+		Expect(cup.Eliminated("C")).To(BeFalse())
+
+		Expect(cup.Record(2, Result{HomeGoals: 0, AwayGoals: 1})).To(Succeed())
+		champion, ok := cup.Champion()
+		Expect(ok).To(BeTrue())
+		Expect(champion).To(Equal("C"))
+	})
+
+	It("should need a winner for every fixture", func() {
+		cup, err := New("cup", FormatKnockout, teamsNamed("A", "B"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cup.Record(0, Result{HomeGoals: 1, AwayGoals: 1})).NotTo(Succeed())
+	})
+
+	It("should take a power of two teams", func() {
+		_, err := New("cup", FormatKnockout, teamsNamed("A", "B", "C"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Advance", func() {
+	It("should simulate the fixtures of AI teams up to the next of the human team", func() {
+		league, err := New("league", FormatLeague, teamsNamed("A", "B", "C", "D"))
+		Expect(err).NotTo(HaveOccurred())
+		rng := rand.New(rand.NewSource(1))
+
+		for round := 1; round <= league.Rounds(); round++ {
+			next, ok := league.Advance(rng)
+			Expect(ok).To(BeTrue())
+			fixture := league.Fixtures[next]
+			Expect(fixture.Round).To(Equal(round))
+			Expect([]string{fixture.Home, fixture.Away}).To(ContainElement("A"))
+			for _, other := range league.Round(round) {
+				Expect(other.Played()).To(Equal(other != fixture))
+			}
+			Expect(league.Record(next, Result{HomeGoals: 1})).To(Succeed())
+		}
+		_, ok := league.Advance(rng)
+		Expect(ok).To(BeFalse())
+		Expect(league.Finished()).To(BeTrue())
+	})
+
+	It("should play a knockout out once the human team is out", func() {
+		cup, err := New("cup", FormatKnockout, teamsNamed("A", "B", "C", "D", "E", "F", "G", "H"))
+		Expect(err).NotTo(HaveOccurred())
+		rng := rand.New(rand.NewSource(2))
+
+		next, ok := cup.Advance(rng)
+		Expect(ok).To(BeTrue())
+		Expect(cup.Record(next, Result{AwayGoals: 2})).To(Succeed())
+		_, ok = cup.Advance(rng)
+		Expect(ok).To(BeFalse())
+		Expect(cup.Finished()).To(BeTrue())
+		Expect(cup.Fixtures).To(HaveLen(7))
+	})
+
+	It("should settle simulated knockout draws with penalties", func() {
+		cup, err := New("cup", FormatKnockout, teamsNamed("A", "B"))
+		Expect(err).NotTo(HaveOccurred())
+		rng := rand.New(rand.NewSource(3))
+		for i := 0; i < 100; i++ {
+			_, ok := cup.Simulate(cup.Fixtures[0], rng).Winner()
+			Expect(ok).To(BeTrue())
+		}
+	})
+})
+
+var _ = Describe("Storage", func() {
+	It("should read back a stored tournament", func() {
+		cup, err := New("cup", FormatKnockout, teamsNamed("A", "B"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cup.Record(0, Result{HomeGoals: 2})).To(Succeed())
+		data, err := json.Marshal(cup)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed, err := Parse(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(cup))
+
+		none, err := Parse(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(none).To(BeNil())
+	})
+
+	It("should load the teams of the game", func() {
+		teams, err := LoadTeams("../../assets/game/teams.json")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = New("cup", FormatKnockout, teams)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,525 @@
+// Package validation checks level and game data files for content mistakes.
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/joaorufino/gopher-game/internal/core"
+	"github.com/joaorufino/gopher-game/pkg/items"
+	"github.com/joaorufino/gopher-game/pkg/levelfile"
+	"github.com/joaorufino/gopher-game/pkg/logic"
+	"github.com/joaorufino/gopher-game/pkg/physics"
+)
+
+// Severity tells how serious an issue is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue describes one problem found in a content file.
+type Issue struct {
+	File     string   `json:"file"`
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+}
+
+// Report collects the issues found during a validation run.
+type Report struct {
+	Files    []string `json:"files"`
+	Errors   int      `json:"errors"`
+	Warnings int      `json:"warnings"`
+	Issues   []Issue  `json:"issues"`
+}
+
+// HasErrors reports whether any issue of error severity was found.
+func (r *Report) HasErrors() bool {
+	return r.Errors > 0
+}
+
+func (r *Report) add(file string, severity Severity, code, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{File: file, Severity: severity, Code: code, Message: fmt.Sprintf(format, args...)})
+	if severity == SeverityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// Config holds the settings of a validation run.
+type Config struct {
+	// LevelsDir and GameDir are relative to the asset root, which must be the
+	// working directory just like when the game runs.
+	LevelsDir string
+	GameDir   string
+	// JumpArc is the player's jump used to check that platforms are reachable.
+	JumpArc physics.JumpArc
+}
+
+// Validator checks the content files under the asset root.
+type Validator struct {
+	config    Config
+	report    *Report
+	items     map[string]items.Item
+	abilities map[string]bool
+	actions   map[string]bool
+}
+
+type abilityEntry struct {
+	Name        string `json:"name"`
+	Image       string `json:"image"`
+	Icon        string `json:"icon"`
+	Description string `json:"description"`
+	Cooldown    int64  `json:"cooldown"`
+	ActionName  string `json:"actionName"`
+}
+
+type actionsFile struct {
+	Actions []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Function    string `json:"function"`
+	} `json:"actions"`
+}
+
+type levelsFile struct {
+	StartingLevel string `json:"startingLevel"`
+	Levels        []struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Abilities   []string `json:"abilities"`
+		Milestone   string   `json:"milestone,omitempty"`
+		File        string   `json:"file,omitempty"`
+	} `json:"levels"`
+}
+
+// NewValidator creates a validator with the given configuration.
+func NewValidator(config Config) *Validator {
+	if config.LevelsDir == "" {
+		config.LevelsDir = "levels"
+	}
+	if config.GameDir == "" {
+		config.GameDir = "game"
+	}
+	return &Validator{
+		config:    config,
+		report:    &Report{Files: []string{}, Issues: []Issue{}},
+		items:     make(map[string]items.Item),
+		abilities: make(map[string]bool),
+		actions:   make(map[string]bool),
+	}
+}
+
+// Run validates the game data files and then every level.
+func (v *Validator) Run() (*Report, error) {
+	v.validateActions(path.Join(v.config.GameDir, "actions.json"))
+	v.validateAbilities(path.Join(v.config.GameDir, "abilities.json"))
+	v.validateItems(path.Join(v.config.GameDir, "items.json"))
+	v.validateLevelList(path.Join(v.config.GameDir, "levels.json"))
+
+	entries, err := os.ReadDir(v.config.LevelsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list levels: %w", err)
+	}
+	for _, entry := range entries {
+		ext := strings.ToLower(path.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".json" && ext != ".tmx") {
+			continue
+		}
+		v.ValidateLevel(path.Join(v.config.LevelsDir, entry.Name()))
+	}
+	return v.report, nil
+}
+
+// decodeStrict decodes a JSON file, warning about fields the game does not
+// know. The game ignores them, so the rest of the file is still decoded and
+// checked.
+func (v *Validator) decodeStrict(file string, target interface{}) bool {
+	v.report.Files = append(v.report.Files, file)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		v.report.add(file, SeverityError, "unreadable", "%v", err)
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(target)
+	if err != nil && strings.HasPrefix(err.Error(), "json: unknown field") {
+		v.report.add(file, SeverityWarning, "unknown-field", "%v", err)
+		err = json.Unmarshal(data, target)
+	}
+	if err != nil {
+		v.report.add(file, SeverityError, "schema", "%v", err)
+		return false
+	}
+	return true
+}
+
+func (v *Validator) validateActions(file string) {
+	var actions actionsFile
+	if !v.decodeStrict(file, &actions) {
+		return
+	}
+	for i, action := range actions.Actions {
+		if action.Name == "" {
+			v.report.add(file, SeverityError, "schema", "action %d has no name", i)
+			continue
+		}
+		v.actions[action.Name] = true
+	}
+}
+
+func (v *Validator) validateAbilities(file string) {
+	var abilities []abilityEntry
+	if !v.decodeStrict(file, &abilities) {
+		return
+	}
+	for i, ability := range abilities {
+		if ability.Name == "" {
+			v.report.add(file, SeverityError, "schema", "ability %d has no name", i)
+			continue
+		}
+		if v.abilities[ability.Name] {
+			v.report.add(file, SeverityError, "duplicate", "ability %q is defined more than once", ability.Name)
+		}
+		v.abilities[ability.Name] = true
+		// Abilities without an action are passive: the game applies them by
+		// name, e.g. those the items grant
+		if ability.ActionName != "" && len(v.actions) > 0 && !v.actions[ability.ActionName] {
+			v.report.add(file, SeverityError, "unknown-action", "ability %q uses action %q which is not in actions.json", ability.Name, ability.ActionName)
+		}
+	}
+}
+
+func (v *Validator) validateItems(file string) {
+	var itemList []items.Item
+	if !v.decodeStrict(file, &itemList) {
+		return
+	}
+	for i, item := range itemList {
+		if item.Name == "" {
+			v.report.add(file, SeverityError, "schema", "item %d has no name", i)
+			continue
+		}
+		if _, exists := v.items[item.Name]; exists {
+			v.report.add(file, SeverityError, "duplicate", "item %q is defined more than once", item.Name)
+		}
+		v.items[item.Name] = item
+		for _, ability := range item.Abilities {
+			if !v.abilities[ability] {
+				v.report.add(file, SeverityError, "unknown-ability", "item %q grants ability %q which is not in abilities.json", item.Name, ability)
+			}
+		}
+		for damageType, amount := range item.Resistances {
+			if !knownDamageType(damageType) {
+				v.report.add(file, SeverityError, "schema", "item %q resists unknown damage type %q", item.Name, damageType)
+			}
+			if amount < 0 || amount > 1 {
+				v.report.add(file, SeverityError, "schema", "item %q resists %.2f of %s damage, expected 0 to 1", item.Name, amount, damageType)
+			}
+		}
+		v.checkAsset(file, item.Icon, fmt.Sprintf("icon of item %q", item.Name))
+		v.checkAsset(file, item.Image, fmt.Sprintf("image of item %q", item.Name))
+	}
+}
+
+func (v *Validator) validateLevelList(file string) {
+	var levels levelsFile
+	if !v.decodeStrict(file, &levels) {
+		return
+	}
+	names := make(map[string]bool)
+	for i, level := range levels.Levels {
+		names[level.Name] = true
+		if level.File == "" {
+			continue
+		}
+		v.checkAsset(file, level.File, fmt.Sprintf("file of level %q", level.Name))
+		// The career only goes past levels with an exit.
+		if data, err := levelfile.Load(level.File); err == nil && i+1 < len(levels.Levels) {
+			if _, ok := data.Exit(); !ok {
+				v.report.add(file, SeverityWarning, "no-exit", "level %q has no exit, so level %q is never reached", level.Name, levels.Levels[i+1].Name)
+			}
+		}
+	}
+	if levels.StartingLevel != "" && !names[levels.StartingLevel] {
+		v.report.add(file, SeverityError, "unknown-level", "starting level %q is not listed", levels.StartingLevel)
+	}
+}
+
+// checkAsset reports an error when an asset path referenced by file does not exist.
+func (v *Validator) checkAsset(file, assetPath, what string) {
+	if assetPath == "" {
+		return
+	}
+	if _, err := os.Stat(assetPath); err != nil {
+		v.report.add(file, SeverityError, "missing-asset", "%s %q does not exist", what, assetPath)
+	}
+}
+
+// ValidateLevel checks a single level file.
+func (v *Validator) ValidateLevel(file string) {
+	if strings.EqualFold(path.Ext(file), ".json") {
+		raw := &levelfile.LevelData{}
+		if !v.decodeStrict(file, raw) {
+			return
+		}
+	} else {
+		v.report.Files = append(v.report.Files, file)
+	}
+
+	level, err := levelfile.Load(file)
+	if err != nil {
+		v.report.add(file, SeverityError, "schema", "%v", err)
+		return
+	}
+
+	v.checkBodies(file, level)
+	for _, item := range level.Items {
+		if item.Name == "" {
+			v.report.add(file, SeverityError, "schema", "item without a name")
+		} else if len(v.items) > 0 {
+			if _, exists := v.items[item.Name]; !exists {
+				v.report.add(file, SeverityError, "unknown-item", "item %q is not in items.json", item.Name)
+			}
+		}
+	}
+	v.checkAsset(file, level.Background, "background")
+	if exit, ok := level.Exit(); ok {
+		if next, ok := exit.Properties["next"].(string); ok {
+			v.checkAsset(file, path.Join(path.Dir(file), next), "next level of the exit")
+		}
+	}
+	for i, layer := range level.Layers {
+		if layer.Image == "" {
+			v.report.add(file, SeverityError, "schema", "layer %d has no image", i)
+		}
+		v.checkAsset(file, layer.Image, fmt.Sprintf("image of layer %d", i))
+		if _, err := levelfile.ParseTint(layer.Tint); err != nil {
+			v.report.add(file, SeverityError, "schema", "layer %d: %v", i, err)
+		}
+	}
+	for _, tileset := range level.Tilesets {
+		v.checkAsset(file, tileset.Image, fmt.Sprintf("image of tileset %q", tileset.Name))
+	}
+	v.checkLogic(file, level)
+	v.checkOverlaps(file, level)
+	v.checkReachability(file, level)
+}
+
+// checkLogic checks that the logic objects are wired together and that those
+// placed in the level have an area.
+func (v *Validator) checkLogic(file string, level *levelfile.LevelData) {
+	if _, err := level.Circuit(); err != nil {
+		v.report.add(file, SeverityError, "logic", "%v", err)
+	}
+	for _, object := range level.Logic {
+		switch object.Kind {
+		case logic.KindSwitch, logic.KindPlate, logic.KindDoor, logic.KindTimedGate, logic.KindSpawner:
+			if object.Body == nil || object.Body.Size.X <= 0 || object.Body.Size.Y <= 0 {
+				v.report.add(file, SeverityError, "invalid-size", "%s %q has no area", object.Kind, object.ID)
+			}
+		}
+		switch object.Kind {
+		case logic.KindSpawner:
+			if object.Spawn == "" {
+				v.report.add(file, SeverityError, "schema", "spawner %q has no obstacle type to spawn", object.ID)
+			}
+		case logic.KindDelay, logic.KindTimedGate:
+			if object.Duration <= 0 {
+				v.report.add(file, SeverityWarning, "logic", "%s %q has no duration", object.Kind, object.ID)
+			}
+		}
+	}
+}
+
+func (v *Validator) checkBodies(file string, level *levelfile.LevelData) {
+	for i, platform := range level.Platforms {
+		checkSize(v.report, file, fmt.Sprintf("platform %d", i), platform.RigidBody)
+		switch platform.Kind {
+		case "", levelfile.PlatformStatic, levelfile.PlatformCrumbling, levelfile.PlatformOneWay:
+		case levelfile.PlatformMoving:
+			if platform.Movement.Type == "" {
+				v.report.add(file, SeverityWarning, "static-movement", "platform %d is moving but has no movement", i)
+			}
+		default:
+			v.report.add(file, SeverityError, "schema", "platform %d has unknown kind %q", i, platform.Kind)
+		}
+	}
+	for i, obstacle := range level.Obstacles {
+		what := fmt.Sprintf("obstacle %d (%s)", i, obstacle.Type)
+		checkSize(v.report, file, what, obstacle.RigidBody)
+		if obstacle.Type == "" {
+			v.report.add(file, SeverityError, "schema", "obstacle %d has no type", i)
+		}
+		switch obstacle.Movement.Type {
+		case "":
+		case "horizontal", "vertical":
+			if obstacle.Movement.Distance <= 0 || obstacle.Movement.Speed == 0 {
+				v.report.add(file, SeverityWarning, "static-movement", "%s moves %s but has no distance or speed", what, obstacle.Movement.Type)
+			}
+		default:
+			v.report.add(file, SeverityError, "schema", "%s has unknown movement type %q", what, obstacle.Movement.Type)
+		}
+		if obstacle.Damage != nil {
+			v.checkDamage(file, what, *obstacle.Damage)
+		}
+	}
+	for _, item := range level.Items {
+		checkSize(v.report, file, fmt.Sprintf("item %q", item.Name), item.RigidBody)
+	}
+	for i, hazard := range level.Hazards {
+		what := fmt.Sprintf("hazard %d (%s)", i, hazard.Name)
+		if hazard.Body.Size.X <= 0 || hazard.Body.Size.Y <= 0 {
+			v.report.add(file, SeverityError, "invalid-size", "%s has size %.0fx%.0f", what, hazard.Body.Size.X, hazard.Body.Size.Y)
+		}
+		v.checkDamage(file, what, hazard.Damage)
+	}
+	fallY, hasFall := level.FallLimit()
+	for i, checkpoint := range level.Checkpoints {
+		body := checkpoint.Body
+		if body.Size.X <= 0 || body.Size.Y <= 0 {
+			v.report.add(file, SeverityError, "invalid-size", "checkpoint %d (%s) has size %.0fx%.0f", i, checkpoint.Name, body.Size.X, body.Size.Y)
+		}
+		if hasFall && body.Position.Y+body.Size.Y >= fallY {
+			v.report.add(file, SeverityError, "checkpoint-below-kill-plane", "checkpoint %d (%s) is below the kill plane at %.0f", i, checkpoint.Name, fallY)
+		}
+	}
+}
+
+func (v *Validator) checkDamage(file, what string, damage core.Damage) {
+	if damage.Amount <= 0 {
+		v.report.add(file, SeverityWarning, "harmless-damage", "%s deals no damage", what)
+	}
+	if damage.Type != "" && !knownDamageType(damage.Type) {
+		v.report.add(file, SeverityError, "schema", "%s deals unknown damage type %q", what, damage.Type)
+	}
+}
+
+func knownDamageType(damageType core.DamageType) bool {
+	for _, known := range core.DamageTypes {
+		if known == damageType {
+			return true
+		}
+	}
+	return false
+}
+
+func checkSize(report *Report, file, what string, rb *physics.RigidBody) {
+	if rb.Size.X <= 0 || rb.Size.Y <= 0 {
+		report.add(file, SeverityError, "invalid-size", "%s has size %.0fx%.0f", what, rb.Size.X, rb.Size.Y)
+	}
+}
+
+type staticBody struct {
+	name string
+	rect core.Rect
+}
+
+// staticBodies returns the platforms and obstacles that never move.
+func staticBodies(level *levelfile.LevelData) []staticBody {
+	var bodies []staticBody
+	for i, platform := range level.Platforms {
+		if platform.Movement.Type == "" {
+			bodies = append(bodies, staticBody{fmt.Sprintf("platform %d", i), physics.BodyRect(platform.RigidBody)})
+		}
+	}
+	for i, obstacle := range level.Obstacles {
+		if obstacle.Movement.Type == "" {
+			bodies = append(bodies, staticBody{fmt.Sprintf("obstacle %d (%s)", i, obstacle.Type), physics.BodyRect(obstacle.RigidBody)})
+		}
+	}
+	return bodies
+}
+
+func (v *Validator) checkOverlaps(file string, level *levelfile.LevelData) {
+	bodies := staticBodies(level)
+	for i := 0; i < len(bodies); i++ {
+		for j := i + 1; j < len(bodies); j++ {
+			if overlaps(bodies[i].rect, bodies[j].rect) {
+				v.report.add(file, SeverityError, "overlapping-bodies", "%s overlaps %s", bodies[i].name, bodies[j].name)
+			}
+		}
+	}
+}
+
+func overlaps(a, b core.Rect) bool {
+	return a.Position.X < b.Position.X+b.Size.X && a.Position.X+a.Size.X > b.Position.X &&
+		a.Position.Y < b.Position.Y+b.Size.Y && a.Position.Y+a.Size.Y > b.Position.Y
+}
+
+// checkReachability walks the platforms the player can jump between, starting
+// from the platform below the "start" spawn point (or the lowest platform), and
+// reports the platforms it never reaches.
+func (v *Validator) checkReachability(file string, level *levelfile.LevelData) {
+	if len(level.Platforms) == 0 || v.config.JumpArc.Gravity <= 0 {
+		return
+	}
+	rects := make([]core.Rect, len(level.Platforms))
+	for i, platform := range level.Platforms {
+		rects[i] = physics.BodyRect(platform.RigidBody)
+	}
+
+	start := startPlatform(level, rects)
+	reached := map[int]bool{start: true}
+	queue := []int{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for next := range rects {
+			if !reached[next] && v.config.JumpArc.CanReach(rects[current], rects[next]) {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	var unreachable []int
+	for i := range rects {
+		if !reached[i] {
+			unreachable = append(unreachable, i)
+		}
+	}
+	sort.Ints(unreachable)
+	for _, i := range unreachable {
+		v.report.add(file, SeverityError, "unreachable-platform", "platform %d at (%.0f, %.0f) cannot be reached from platform %d",
+			i, rects[i].Position.X, rects[i].Position.Y, start)
+	}
+}
+
+// startPlatform returns the platform the player lands on when spawning.
+func startPlatform(level *levelfile.LevelData, rects []core.Rect) int {
+	for _, spawn := range level.SpawnPoints {
+		if spawn.Name != "start" && spawn.Name != "player" {
+			continue
+		}
+		best := -1
+		for i, rect := range rects {
+			below := rect.Position.Y >= spawn.Position.Y
+			above := spawn.Position.X >= rect.Position.X && spawn.Position.X <= rect.Position.X+rect.Size.X
+			if below && above && (best < 0 || rect.Position.Y < rects[best].Position.Y) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			return best
+		}
+	}
+
+	lowest := 0
+	for i, rect := range rects {
+		if rect.Position.Y > rects[lowest].Position.Y {
+			lowest = i
+		}
+	}
+	return lowest
+}